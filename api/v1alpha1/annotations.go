@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "time"
+
+// MaxBreakGlassDuration is the longest break-glass override the controller honours.
+const MaxBreakGlassDuration = 24 * time.Hour
+
+const (
+	// AnnotationPaused suspends key submission for a VaultUnsealer when set to "true".
+	AnnotationPaused = "autounseal.vault.io/paused"
+
+	// AnnotationBreakGlassUntil holds an RFC 3339 timestamp until which keys are
+	// submitted even while the VaultUnsealer is paused or in a maintenance window.
+	AnnotationBreakGlassUntil = "autounseal.vault.io/break-glass-until"
+
+	// AnnotationReconcileRequestedAt forces a reconcile when its value changes.
+	// On a Vault pod, a new value makes the next reconcile check and unseal
+	// that pod alone, ignoring its failure backoff, and leave the other pods
+	// untouched.
+	AnnotationReconcileRequestedAt = "autounseal.vault.io/reconcile-requested-at"
+
+	// AnnotationPodAddress on a Vault pod overrides the address its Vault API
+	// is reached at, such as "https://10.1.2.3:8201". It is only honoured for
+	// VaultUnsealers with spec.vault.allowPodAddressOverride set.
+	AnnotationPodAddress = "autounseal.vault.io/address"
+
+	// AnnotationModifiedBy on an unseal keys Secret names who last changed its
+	// keys, for rotation tooling that writes through a shared field manager.
+	// It is reported in place of the field manager in status.keySecrets.
+	AnnotationModifiedBy = "autounseal.vault.io/modified-by"
+
+	// LabelDiscover opts a Vault StatefulSet into automatic VaultUnsealer provisioning when set to "true".
+	LabelDiscover = "autounseal.vault.io/discover"
+
+	// LabelDiscoveredFrom marks a VaultUnsealer as managed by discovery and names its StatefulSet.
+	LabelDiscoveredFrom = "autounseal.vault.io/discovered-from"
+
+	// AnnotationUnsealKeysSecret overrides the unseal keys Secret of a discovered
+	// StatefulSet, as "name" or "name/key".
+	AnnotationUnsealKeysSecret = "autounseal.vault.io/unseal-keys-secret"
+
+	// AnnotationVaultURL overrides the Vault URL of a discovered StatefulSet.
+	AnnotationVaultURL = "autounseal.vault.io/vault-url"
+
+	// AnnotationKeyThreshold overrides the key threshold of a discovered StatefulSet.
+	AnnotationKeyThreshold = "autounseal.vault.io/key-threshold"
+
+	// AnnotationAllowedUnsealerNamespaces on a Namespace lists, comma separated,
+	// the namespaces whose VaultUnsealers may read unseal keys Secrets from it.
+	// "*" allows every namespace.
+	AnnotationAllowedUnsealerNamespaces = "autounseal.vault.io/allowed-unsealer-namespaces"
+
+	// LabelShard pins a VaultUnsealer to a shard when the operator runs sharded.
+	LabelShard = "autounseal.vault.io/shard"
+)
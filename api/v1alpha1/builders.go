@@ -0,0 +1,222 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewVaultUnsealer returns a VaultUnsealer with its type metadata set, ready to
+// be customised with the With* builders and submitted with any Kubernetes client.
+func NewVaultUnsealer(namespace, name string) *VaultUnsealer {
+	return &VaultUnsealer{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: GroupVersion.String(),
+			Kind:       "VaultUnsealer",
+		},
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+	}
+}
+
+// WithVaultURL sets the Vault API address.
+func (vu *VaultUnsealer) WithVaultURL(url string) *VaultUnsealer {
+	vu.Spec.Vault.Address = url
+	return vu
+}
+
+// WithCABundleSecret sets the Secret key holding the CA bundle used to verify Vault.
+func (vu *VaultUnsealer) WithCABundleSecret(name, key string) *VaultUnsealer {
+	vu.Spec.Vault.CABundleSecretRef = &SecretRef{Name: name, Key: key}
+	return vu
+}
+
+// WithCABundleSecrets adds Secret keys holding further CA bundles trusted
+// alongside the one set by WithCABundleSecret.
+func (vu *VaultUnsealer) WithCABundleSecrets(refs ...SecretRef) *VaultUnsealer {
+	vu.Spec.Vault.CABundleSecretRefs = append(vu.Spec.Vault.CABundleSecretRefs, refs...)
+	return vu
+}
+
+// WithInsecureSkipVerify disables TLS verification of the Vault API.
+func (vu *VaultUnsealer) WithInsecureSkipVerify(insecure bool) *VaultUnsealer {
+	vu.Spec.Vault.InsecureSkipVerify = insecure
+	return vu
+}
+
+// WithPodAddressOverride sets whether pods may override their address with
+// the AnnotationPodAddress annotation.
+func (vu *VaultUnsealer) WithPodAddressOverride(allow bool) *VaultUnsealer {
+	vu.Spec.Vault.AllowPodAddressOverride = allow
+	return vu
+}
+
+// WithContainerName sets the Vault container evaluated in multi-container pods.
+func (vu *VaultUnsealer) WithContainerName(name string) *VaultUnsealer {
+	vu.Spec.Vault.ContainerName = name
+	return vu
+}
+
+// WithHealthCheck sets the health endpoint path and accepted status codes.
+func (vu *VaultUnsealer) WithHealthCheck(path string, acceptedStatusCodes ...int) *VaultUnsealer {
+	vu.Spec.Vault.HealthCheck = &HealthCheckSpec{Path: path, AcceptedStatusCodes: acceptedStatusCodes}
+	return vu
+}
+
+// WithTokenSecret sets the Secret key holding the token sent with status checks.
+func (vu *VaultUnsealer) WithTokenSecret(name, key string) *VaultUnsealer {
+	vu.Spec.Vault.TokenSecretRef = &SecretRef{Name: name, Key: key}
+	return vu
+}
+
+// WithRequirePodReady sets whether only Ready pods are unsealed.
+func (vu *VaultUnsealer) WithRequirePodReady(require bool) *VaultUnsealer {
+	vu.Spec.RequirePodReady = &require
+	return vu
+}
+
+// WithErrorPolicy sets what a reconcile does when a pod fails.
+func (vu *VaultUnsealer) WithErrorPolicy(policy string) *VaultUnsealer {
+	vu.Spec.ErrorPolicy = policy
+	return vu
+}
+
+// WithPriority sets the priority of the VaultUnsealer's reconciles.
+func (vu *VaultUnsealer) WithPriority(priority string) *VaultUnsealer {
+	vu.Spec.Priority = priority
+	return vu
+}
+
+// WithSealMigration sets whether keys are submitted in migration mode during
+// a seal migration.
+func (vu *VaultUnsealer) WithSealMigration(migrate bool) *VaultUnsealer {
+	vu.Spec.SealMigration = migrate
+	return vu
+}
+
+// WithKeySubmissionDelay sets the pause between unseal key submissions.
+func (vu *VaultUnsealer) WithKeySubmissionDelay(delay time.Duration) *VaultUnsealer {
+	vu.Spec.KeySubmissionDelay = &metav1.Duration{Duration: delay}
+	return vu
+}
+
+// WithSealWatch enables polling of pod seal status at interval.
+func (vu *VaultUnsealer) WithSealWatch(interval time.Duration) *VaultUnsealer {
+	vu.Spec.SealWatch = &SealWatchSpec{Interval: metav1.Duration{Duration: interval}}
+	return vu
+}
+
+// WithCanary enables canary unsealing of podCount pods soaking for soakTime.
+func (vu *VaultUnsealer) WithCanary(podCount int32, soakTime time.Duration) *VaultUnsealer {
+	vu.Spec.Canary = &CanarySpec{Enabled: true, PodCount: podCount, SoakTime: &metav1.Duration{Duration: soakTime}}
+	return vu
+}
+
+// WithMaintenanceWindow appends a maintenance window.
+func (vu *VaultUnsealer) WithMaintenanceWindow(window MaintenanceWindow) *VaultUnsealer {
+	vu.Spec.MaintenanceWindows = append(vu.Spec.MaintenanceWindows, window)
+	return vu
+}
+
+// WithUnsealKeysSecret appends a Secret key holding unseal keys.
+func (vu *VaultUnsealer) WithUnsealKeysSecret(name, key string) *VaultUnsealer {
+	vu.Spec.UnsealKeysSecretRefs = append(vu.Spec.UnsealKeysSecretRefs, SecretRef{Name: name, Key: key})
+	return vu
+}
+
+// WithCustodian appends a custodian whose shares are held in key of the
+// Secret name. A zero maxShares submits all of them.
+func (vu *VaultUnsealer) WithCustodian(name, secretName, key string, maxShares int32) *VaultUnsealer {
+	vu.Spec.Custodians = append(vu.Spec.Custodians, Custodian{
+		Name:      name,
+		SecretRef: SecretRef{Name: secretName, Key: key},
+		MaxShares: maxShares,
+	})
+	return vu
+}
+
+// WithMinCustodians sets spec.minCustodians.
+func (vu *VaultUnsealer) WithMinCustodians(count int32) *VaultUnsealer {
+	vu.Spec.MinCustodians = count
+	return vu
+}
+
+// WithLabelSelector sets the label selector matching the Vault pods.
+func (vu *VaultUnsealer) WithLabelSelector(selector string) *VaultUnsealer {
+	vu.Spec.VaultLabelSelector = selector
+	return vu
+}
+
+// WithLabelSelectors appends to spec.vaultLabelSelectors.
+func (vu *VaultUnsealer) WithLabelSelectors(selectors ...string) *VaultUnsealer {
+	vu.Spec.VaultLabelSelectors = append(vu.Spec.VaultLabelSelectors, selectors...)
+	return vu
+}
+
+// WithHA sets the deprecated spec.mode.ha field.
+func (vu *VaultUnsealer) WithHA(ha bool) *VaultUnsealer {
+	vu.Spec.Mode.HA = &ha
+	return vu
+}
+
+// WithReplicaScope sets spec.mode.scope.
+func (vu *VaultUnsealer) WithReplicaScope(scope string) *VaultUnsealer {
+	vu.Spec.Mode.Scope = scope
+	return vu
+}
+
+// WithMonitorOnly sets spec.mode.monitorOnly.
+func (vu *VaultUnsealer) WithMonitorOnly(monitorOnly bool) *VaultUnsealer {
+	vu.Spec.Mode.MonitorOnly = monitorOnly
+	return vu
+}
+
+// WithStopAfterFirstUnseal sets whether each pass ends once a pod is unsealed.
+func (vu *VaultUnsealer) WithStopAfterFirstUnseal(stop bool) *VaultUnsealer {
+	vu.Spec.Mode.StopAfterFirstUnseal = &stop
+	return vu
+}
+
+// WithKeyThreshold sets the number of keys submitted per pod.
+func (vu *VaultUnsealer) WithKeyThreshold(threshold int) *VaultUnsealer {
+	vu.Spec.KeyThreshold = threshold
+	return vu
+}
+
+// WithInterval sets the periodic reconciliation interval.
+func (vu *VaultUnsealer) WithInterval(interval time.Duration) *VaultUnsealer {
+	vu.Spec.Interval = &metav1.Duration{Duration: interval}
+	return vu
+}
+
+// WithFastInterval sets the reconcile interval used while pods need attention.
+func (vu *VaultUnsealer) WithFastInterval(interval time.Duration) *VaultUnsealer {
+	vu.Spec.FastInterval = &metav1.Duration{Duration: interval}
+	return vu
+}
+
+// WithLabels merges labels into the VaultUnsealer metadata.
+func (vu *VaultUnsealer) WithLabels(labels map[string]string) *VaultUnsealer {
+	if vu.Labels == nil {
+		vu.Labels = make(map[string]string, len(labels))
+	}
+	for k, v := range labels {
+		vu.Labels[k] = v
+	}
+	return vu
+}
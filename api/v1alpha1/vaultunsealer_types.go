@@ -17,6 +17,9 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"slices"
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -31,25 +34,608 @@ type SecretRef struct {
 }
 
 // VaultConnectionSpec defines how to connect to the Vault cluster.
+// +kubebuilder:validation:XValidation:rule="has(self.address) || has(self.url)",message="address is required"
+// +kubebuilder:validation:XValidation:rule="!has(self.address) || !has(self.url) || self.address == self.url",message="url must match address when both are set"
 type VaultConnectionSpec struct {
-	URL                string     `json:"url"`
-	CABundleSecretRef  *SecretRef `json:"caBundleSecretRef,omitempty"`
-	InsecureSkipVerify bool       `json:"insecureSkipVerify,omitempty"`
+	// Address is the Vault API address, such as https://vault.vault.svc:8200.
+	// +optional
+	Address string `json:"address,omitempty"`
+
+	// URL is the former name of Address and is still accepted in its place.
+	//
+	// Deprecated: use Address.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// PathPrefix is prepended to every Vault API path, for a Vault served
+	// under a path such as /vault behind a shared ingress. Seal status is then
+	// read from /vault/v1/sys/seal-status.
+	// +optional
+	PathPrefix string `json:"pathPrefix,omitempty"`
+
+	CABundleSecretRef *SecretRef `json:"caBundleSecretRef,omitempty"`
+
+	// InsecureSkipVerify disables verification of the Vault server
+	// certificate. Defaults to false.
+	// +kubebuilder:default=false
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+
+	// CABundleSecretRefs lists further CA bundles trusted alongside
+	// CABundleSecretRef. Every certificate of every bundle is added to the root
+	// pool, so the old and new CA can both be trusted while Vault's serving
+	// certificates are rotated.
+	// +optional
+	CABundleSecretRefs []SecretRef `json:"caBundleSecretRefs,omitempty"`
+
+	// PerPodHostTemplate is a Go template for the hostname of each replica,
+	// such as "vault-{{ .Ordinal }}.vault.example.com", for topologies where
+	// every replica is exposed on its own external hostname and pod IPs are
+	// unreachable. It replaces the host of URL; the scheme, port and path are
+	// kept. The template sees .Ordinal, .PodName and .Namespace; .Ordinal is
+	// parsed from the pod name, so only StatefulSet pods can use it.
+	// +optional
+	PerPodHostTemplate string `json:"perPodHostTemplate,omitempty"`
+
+	// MeshSidecar sends every Vault request to a service mesh sidecar
+	// listening on localhost instead of to the pod, with the pod's address in
+	// the Host header. The sidecar then routes the request and upgrades it to
+	// mTLS, for meshes such as Istio in STRICT mode where direct connections
+	// to pod IPs are rejected.
+	// +optional
+	MeshSidecar *MeshSidecarSpec `json:"meshSidecar,omitempty"`
+
+	// APIServerProxy reaches each pod through the Kubernetes apiserver's pod
+	// proxy, /api/v1/namespaces/<namespace>/pods/<pod>:<port>/proxy/, with
+	// the operator's own credentials, for operators running outside the pod
+	// network such as in a management cluster. The scheme and port of the
+	// computed pod address are kept. The apiserver does not verify the Vault
+	// server certificate, so the CA bundle settings do not apply.
+	// +optional
+	APIServerProxy bool `json:"apiServerProxy,omitempty"`
+
+	// AllowPodAddressOverride lets the autounseal.vault.io/address annotation
+	// on a Vault pod replace the computed address of that pod, for asymmetric
+	// networks and debugging. Anyone able to annotate the pods can then direct
+	// unseal keys to an address of their choosing, so it is off by default.
+	// +optional
+	AllowPodAddressOverride bool `json:"allowPodAddressOverride,omitempty"`
+
+	// ContainerName names the Vault container in pods that also run sidecars
+	// such as Vault Agent or Envoy. When set, that container's state and
+	// readiness decide whether keys are submitted instead of the whole pod's,
+	// and pod IP addresses use its "http" or "https" port, or its first
+	// declared port, instead of the port in Address.
+	// +optional
+	ContainerName string `json:"containerName,omitempty"`
+
+	// HealthCheck overrides how each pod's health endpoint is probed.
+	// +optional
+	HealthCheck *HealthCheckSpec `json:"healthCheck,omitempty"`
+
+	// DNS configures how the hostname in Address, or the hostnames from
+	// PerPodHostTemplate, are resolved.
+	// +optional
+	DNS *DNSSpec `json:"dns,omitempty"`
+
+	// TokenSecretRef names a Secret key in the VaultUnsealer's namespace
+	// holding a Vault token sent with seal status and health requests, for
+	// Vaults that put those endpoints behind an authenticating proxy. Keys are
+	// submitted without it unless the unseal request is rejected as
+	// unauthorized.
+	// +optional
+	TokenSecretRef *SecretRef `json:"tokenSecretRef,omitempty"`
+}
+
+// Default mesh sidecar listener scheme.
+const DefaultMeshSidecarScheme = "http"
+
+// MeshSidecarSpec is the localhost listener of the operator's mesh sidecar.
+type MeshSidecarSpec struct {
+	// Scheme of the sidecar listener, http or https. Defaults to http.
+	// +kubebuilder:validation:Enum=http;https
+	// +optional
+	Scheme string `json:"scheme,omitempty"`
+
+	// Port of the sidecar listener on localhost.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
 }
 
+// Endpoint returns the Vault API address, taken from Address or, for
+// resources written before it existed, from the deprecated URL.
+func (v VaultConnectionSpec) Endpoint() string {
+	if v.Address != "" {
+		return v.Address
+	}
+	return v.URL
+}
+
+// DNS resolution modes for spec.vault.dns.resolution.
+const (
+	// DNSResolutionPerAttempt looks hostnames up on every connection.
+	DNSResolutionPerAttempt = "PerAttempt"
+	// DNSResolutionPerReconcile looks each hostname up once per reconcile
+	// and connects to the same addresses for every pod and key submission.
+	DNSResolutionPerReconcile = "PerReconcile"
+)
+
+// DNSSpec configures the resolution of Vault hostnames, for clusters where a
+// slow or overloaded cluster DNS makes lookups time out between key
+// submissions.
+type DNSSpec struct {
+	// Resolution is PerAttempt or PerReconcile. Defaults to PerAttempt.
+	// +kubebuilder:validation:Enum=PerAttempt;PerReconcile
+	// +optional
+	Resolution string `json:"resolution,omitempty"`
+
+	// Resolver is the address of a DNS server, such as 10.96.0.10 or
+	// node-local-dns:53, queried instead of the servers in the operator
+	// pod's resolv.conf. The port defaults to 53.
+	// +optional
+	Resolver string `json:"resolver,omitempty"`
+}
+
+// HealthCheckSpec configures the health probe used to read each unsealed
+// pod's HA role, for proxies that rewrite paths or listeners with
+// non-default status codes.
+type HealthCheckSpec struct {
+	// Path is the health endpoint path, optionally with a query string.
+	// Defaults to /v1/sys/health.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// AcceptedStatusCodes are the response codes that count as a healthy
+	// node. Defaults to 200, 429, 472 and 473. Accepted codes other than
+	// Vault's standard ones report no HA role.
+	// +optional
+	AcceptedStatusCodes []int `json:"acceptedStatusCodes,omitempty"`
+}
+
+// Replica scopes for spec.mode.scope.
+const (
+	// ReplicaScopeCluster treats the matching pods as replicas of one HA
+	// cluster, so readiness policies other than AnyPod apply.
+	ReplicaScopeCluster = "Cluster"
+	// ReplicaScopeSingle expects a single Vault replica.
+	ReplicaScopeSingle = "Single"
+)
+
 // ModeSpec defines the unsealing strategy.
 type ModeSpec struct {
-	HA bool `json:"ha"`
+	// HA is deprecated in favour of scope and stopAfterFirstUnseal. While
+	// neither is set, ha: true means scope Cluster, and an explicit ha: false
+	// means scope Single with stopAfterFirstUnseal. Leaving it unset means
+	// scope Cluster without stopping early.
+	// +optional
+	HA *bool `json:"ha,omitempty"`
+
+	// Scope is Cluster when the matching pods are replicas of one HA
+	// cluster, or Single. Defaults to the value derived from ha, or Cluster.
+	// +kubebuilder:validation:Enum=Cluster;Single
+	// +optional
+	Scope string `json:"scope,omitempty"`
+
+	// StopAfterFirstUnseal ends each pass as soon as one pod is unsealed,
+	// leaving the remaining pods sealed. Defaults to false, or to true for an
+	// explicit ha: false while scope is unset.
+	// +optional
+	StopAfterFirstUnseal *bool `json:"stopAfterFirstUnseal,omitempty"`
+
+	// MonitorOnly reads and reports the seal status of every matching pod,
+	// with the usual status, metrics and conditions, but never loads or
+	// submits keys. It suits Vault clusters that auto-unseal with a KMS.
+	// +optional
+	MonitorOnly bool `json:"monitorOnly,omitempty"`
+}
+
+// Legacy reports whether the mode is configured only through the deprecated
+// ha field.
+func (m ModeSpec) Legacy() bool {
+	return m.HA != nil && m.Scope == "" && m.StopAfterFirstUnseal == nil
+}
+
+// LegacySingle reports whether the mode is a legacy spec with an explicit
+// ha: false.
+func (m ModeSpec) LegacySingle() bool {
+	return m.Legacy() && !*m.HA
+}
+
+// ReplicaScope returns the effective scope, falling back to ha and then to
+// Cluster.
+func (m ModeSpec) ReplicaScope() string {
+	if m.Scope != "" {
+		return m.Scope
+	}
+	if m.HA != nil && !*m.HA {
+		return ReplicaScopeSingle
+	}
+	return ReplicaScopeCluster
+}
+
+// StopsAfterFirstUnseal returns the effective stopAfterFirstUnseal. It only
+// falls back to an explicit ha: false for legacy specs, so an empty mode or
+// setting scope alone never stops early. Monitor-only mode always checks
+// every pod.
+func (m ModeSpec) StopsAfterFirstUnseal() bool {
+	if m.MonitorOnly {
+		return false
+	}
+	if m.StopAfterFirstUnseal != nil {
+		return *m.StopAfterFirstUnseal
+	}
+	return m.LegacySingle()
 }
 
+// Readiness policies controlling when the Ready condition is True in HA mode.
+const (
+	// ReadinessPolicyAnyPod is Ready once at least one pod is unsealed.
+	ReadinessPolicyAnyPod = "AnyPod"
+	// ReadinessPolicyQuorum is Ready once a majority of pods are unsealed.
+	ReadinessPolicyQuorum = "Quorum"
+	// ReadinessPolicyAllPods is Ready only when every pod is unsealed.
+	ReadinessPolicyAllPods = "AllPods"
+	// ReadinessPolicyActivePod is Ready once the active node is unsealed.
+	ReadinessPolicyActivePod = "ActivePod"
+)
+
+// Error policies deciding what a reconcile does when a pod fails.
+const (
+	// ErrorPolicyContinueOtherPods records the failure and moves on to the
+	// next pod.
+	ErrorPolicyContinueOtherPods = "ContinueOtherPods"
+	// ErrorPolicyAbortReconcile stops the pass at the first failing pod and
+	// raises a Warning event.
+	ErrorPolicyAbortReconcile = "AbortReconcile"
+)
+
+// Priorities ordering reconciles when more VaultUnsealers are due than the
+// operator has workers for.
+const (
+	// PriorityHigh is reconciled ahead of every other VaultUnsealer.
+	PriorityHigh = "High"
+	// PriorityNormal is the default.
+	PriorityNormal = "Normal"
+	// PriorityLow is reconciled only once no other VaultUnsealer is waiting.
+	PriorityLow = "Low"
+)
+
+// Roles of an unsealed Vault node, as reported by /sys/health.
+const (
+	PodRoleActive             = "Active"
+	PodRoleStandby            = "Standby"
+	PodRolePerformanceStandby = "PerformanceStandby"
+	PodRoleDRSecondary        = "DRSecondary"
+)
+
+// Reconciliation interval defaults. spec.interval is clamped to the minimum
+// and maximum unless the operator is started with other bounds.
+const (
+	DefaultInterval    = 60 * time.Second
+	DefaultMinInterval = 10 * time.Second
+	DefaultMaxInterval = 24 * time.Hour
+)
+
 // VaultUnsealerSpec defines the desired state of VaultUnsealer.
 type VaultUnsealerSpec struct {
 	Vault                VaultConnectionSpec `json:"vault"`
-	UnsealKeysSecretRefs []SecretRef         `json:"unsealKeysSecretRefs"`
-	Interval             *metav1.Duration    `json:"interval,omitempty"`
-	VaultLabelSelector   string              `json:"vaultLabelSelector"`
-	Mode                 ModeSpec            `json:"mode"`
-	KeyThreshold         int                 `json:"keyThreshold,omitempty"`
+	UnsealKeysSecretRefs []SecretRef         `json:"unsealKeysSecretRefs,omitempty"`
+
+	// Interval is how often the pods are checked, clamped to the operator's
+	// --min-interval and --max-interval. Defaults to 60s.
+	// +kubebuilder:default="60s"
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	VaultLabelSelector string `json:"vaultLabelSelector,omitempty"`
+
+	// Mode has no schema defaults: scope and stopAfterFirstUnseal are only
+	// derived from the deprecated ha field while both are unset.
+	Mode ModeSpec `json:"mode"`
+
+	KeyThreshold int `json:"keyThreshold,omitempty"`
+
+	// VaultLabelSelectors are further label selectors for Vault pods. A pod
+	// matching vaultLabelSelector or any of these is unsealed, so pods with
+	// old and new labels can be covered by one resource during a migration.
+	// +optional
+	VaultLabelSelectors []string `json:"vaultLabelSelectors,omitempty"`
+
+	// FastInterval replaces Interval while any pod is sealed, unreachable or
+	// not ready, or no pod is found, so problems are retried sooner than the
+	// steady-state Interval. It is clamped like Interval and never exceeds it.
+	// Defaults to Interval.
+	// +optional
+	FastInterval *metav1.Duration `json:"fastInterval,omitempty"`
+
+	// ReadinessPolicy controls how many pods must be unsealed for Ready to be
+	// True in HA mode. Defaults to AnyPod.
+	// +kubebuilder:validation:Enum=ActivePod;AllPods;AnyPod;Quorum
+	// +kubebuilder:default=AnyPod
+	// +optional
+	ReadinessPolicy string `json:"readinessPolicy,omitempty"`
+
+	// RequirePodReady skips pods whose Ready condition is not True. Set it to
+	// false when the Vault readiness probe only passes once Vault is unsealed,
+	// so running pods are unsealed before they report Ready. Defaults to true.
+	// +kubebuilder:default=true
+	// +optional
+	RequirePodReady *bool `json:"requirePodReady,omitempty"`
+
+	// ErrorPolicy decides whether a pod that cannot be checked or unsealed
+	// stops the rest of the pass. Defaults to ContinueOtherPods.
+	// +kubebuilder:validation:Enum=ContinueOtherPods;AbortReconcile
+	// +optional
+	ErrorPolicy string `json:"errorPolicy,omitempty"`
+
+	// Priority orders this VaultUnsealer against others waiting for a
+	// reconcile when the operator is saturated, so production Vaults can be
+	// unsealed ahead of development ones. Defaults to Normal.
+	// +kubebuilder:validation:Enum=High;Normal;Low
+	// +optional
+	Priority string `json:"priority,omitempty"`
+
+	// SealMigration submits keys in migration mode while Vault reports a
+	// seal migration in progress. Without it, keys are not submitted to a
+	// migrating pod, since Vault rejects them, and the
+	// SealMigrationInProgress condition is set instead.
+	// +optional
+	SealMigration bool `json:"sealMigration,omitempty"`
+
+	// KeySubmissionDelay pauses between unseal key submissions to a pod and
+	// re-reads its seal status, so that when other unsealers or operators act
+	// on the same pod concurrently no further shares are sent once it is
+	// unsealed. Unset or zero submits the shares back to back.
+	// +optional
+	KeySubmissionDelay *metav1.Duration `json:"keySubmissionDelay,omitempty"`
+
+	// ReconcileBudget bounds how long one reconcile spends checking and
+	// unsealing pods. Once it is used up the pod in progress is finished,
+	// the remaining pods keep their last known status and a new reconcile is
+	// queued right away to continue with them, so a slow Vault cluster does
+	// not hold a worker other VaultUnsealers are waiting for. Unset checks
+	// every pod in one reconcile.
+	// +optional
+	ReconcileBudget *metav1.Duration `json:"reconcileBudget,omitempty"`
+
+	// SealWatch polls pod seal status between reconciles to report pods that
+	// seal, without submitting keys.
+	// +optional
+	SealWatch *SealWatchSpec `json:"sealWatch,omitempty"`
+
+	// Canary unseals a few pods first and waits for them to stay healthy
+	// before submitting keys to the rest, limiting the blast radius of
+	// suspect keys or Vault versions.
+	// +optional
+	Canary *CanarySpec `json:"canary,omitempty"`
+
+	// MaintenanceWindows restrict when keys are submitted, so planned sealing
+	// for backups or patching is not immediately undone.
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// SecretsServiceAccountName names a ServiceAccount in the VaultUnsealer's
+	// namespace that the operator impersonates to read unseal keys Secrets in
+	// other namespaces, so only Secrets granted to it can be referenced.
+	// +optional
+	SecretsServiceAccountName string `json:"secretsServiceAccountName,omitempty"`
+
+	// HCPVaultSecrets reads unseal keys from an HCP Vault Secrets app, in
+	// addition to any unsealKeysSecretRefs.
+	// +optional
+	HCPVaultSecrets *HCPVaultSecretsSource `json:"hcpVaultSecrets,omitempty"`
+
+	// Custodians reads unseal keys from one Secret per key custodian instead
+	// of unsealKeysSecretRefs, taking shares from each custodian in turn so
+	// the submitted shares span as many custodians as possible.
+	// +optional
+	Custodians []Custodian `json:"custodians,omitempty"`
+
+	// MinCustodians is how many distinct custodians must contribute shares
+	// before any are submitted, mirroring an M-of-N key ceremony policy.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinCustodians int32 `json:"minCustodians,omitempty"`
+
+	// StatusConfigMap publishes a summary of the unseal status in a
+	// ConfigMap in the VaultUnsealer's namespace, for consumers that can
+	// read ConfigMaps but not VaultUnsealers.
+	// +optional
+	StatusConfigMap *StatusConfigMapSpec `json:"statusConfigMap,omitempty"`
+
+	// ReadinessGate sets a condition on each checked pod, True once the
+	// operator has verified it is unsealed and False while it is sealed, so
+	// pods listing the condition in spec.readinessGates only receive traffic
+	// once unsealed.
+	// +optional
+	ReadinessGate *ReadinessGateSpec `json:"readinessGate,omitempty"`
+}
+
+// KeySecretRefs returns every Secret reference unseal keys are read from:
+// unsealKeysSecretRefs followed by the custodians' Secrets.
+func (s VaultUnsealerSpec) KeySecretRefs() []SecretRef {
+	refs := slices.Clone(s.UnsealKeysSecretRefs)
+	for _, custodian := range s.Custodians {
+		refs = append(refs, custodian.SecretRef)
+	}
+	return refs
+}
+
+// LabelSelectors returns vaultLabelSelector followed by vaultLabelSelectors.
+// vaultLabelSelector is left out when it is empty and vaultLabelSelectors is
+// not.
+func (s VaultUnsealerSpec) LabelSelectors() []string {
+	if s.VaultLabelSelector == "" && len(s.VaultLabelSelectors) > 0 {
+		return s.VaultLabelSelectors
+	}
+	return append([]string{s.VaultLabelSelector}, s.VaultLabelSelectors...)
+}
+
+// Custodian is one holder of unseal key shares in a key ceremony.
+type Custodian struct {
+	// Name identifies the custodian in status.
+	Name string `json:"name"`
+	// SecretRef locates the custodian's shares, parsed like any unseal keys
+	// Secret key.
+	SecretRef SecretRef `json:"secretRef"`
+	// MaxShares caps how many of the custodian's shares are submitted.
+	// Defaults to all of them.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxShares int32 `json:"maxShares,omitempty"`
+}
+
+// DefaultReadinessGateConditionType is the pod condition set for
+// spec.readinessGate when conditionType is empty.
+const DefaultReadinessGateConditionType = "autounseal.vault.io/Unsealed"
+
+// ReadinessGateSpec configures the pod condition backing a readiness gate.
+type ReadinessGateSpec struct {
+	// ConditionType is the pod condition to set. Defaults to
+	// autounseal.vault.io/Unsealed.
+	// +optional
+	ConditionType string `json:"conditionType,omitempty"`
+}
+
+// Type returns the pod condition type, falling back to the default.
+func (g ReadinessGateSpec) Type() string {
+	if g.ConditionType != "" {
+		return g.ConditionType
+	}
+	return DefaultReadinessGateConditionType
+}
+
+// DefaultStatusConfigMapNameTemplate names the status ConfigMap when
+// spec.statusConfigMap.nameTemplate is empty.
+const DefaultStatusConfigMapNameTemplate = "{{ .Name }}-unseal-status"
+
+// StatusConfigMapSpec configures the status ConfigMap.
+type StatusConfigMapSpec struct {
+	// NameTemplate is a Go template rendering the ConfigMap name from the
+	// VaultUnsealer's .Name and .Namespace. Defaults to
+	// "{{ .Name }}-unseal-status".
+	// +optional
+	NameTemplate string `json:"nameTemplate,omitempty"`
+}
+
+// Keys of the status ConfigMap.
+const (
+	// StatusConfigMapReadyKey holds the status of the Ready condition:
+	// True, False or Unknown.
+	StatusConfigMapReadyKey = "ready"
+	// StatusConfigMapAllPodsUnsealedKey holds status.allPodsUnsealed.
+	StatusConfigMapAllPodsUnsealedKey = "allPodsUnsealed"
+	// StatusConfigMapPodsKey holds the number of pods in status.pods.
+	StatusConfigMapPodsKey = "pods"
+	// StatusConfigMapUnsealedPodsKey holds the number of unsealed pods.
+	StatusConfigMapUnsealedPodsKey = "unsealedPods"
+	// StatusConfigMapSealedPodsKey holds the number of sealed pods.
+	StatusConfigMapSealedPodsKey = "sealedPods"
+	// StatusConfigMapLastUnsealTimeKey holds the most recent time a pod was
+	// unsealed, in RFC 3339, or is empty.
+	StatusConfigMapLastUnsealTimeKey = "lastUnsealTime"
+	// StatusConfigMapLastErrorKey holds status.lastError.
+	StatusConfigMapLastErrorKey = "lastError"
+	// StatusConfigMapPodsJSONKey holds a JSON array with the name, state and
+	// role of each pod.
+	StatusConfigMapPodsJSONKey = "pods.json"
+)
+
+// Keys of the service principal credentials in the Secret named by
+// spec.hcpVaultSecrets.credentialsSecretName.
+const (
+	HCPClientIDKey     = "clientID"
+	HCPClientSecretKey = "clientSecret"
+)
+
+// HCPVaultSecretsSource locates unseal keys escrowed in HCP Vault Secrets.
+type HCPVaultSecretsSource struct {
+	// OrganizationID is the HCP organization ID.
+	OrganizationID string `json:"organizationID"`
+	// ProjectID is the HCP project ID.
+	ProjectID string `json:"projectID"`
+	// AppName is the Vault Secrets app holding the keys.
+	AppName string `json:"appName"`
+	// SecretNames are the app secrets holding the keys. Each value is parsed
+	// like a Secret key: a JSON array, newline separated or a single key.
+	// +kubebuilder:validation:MinItems=1
+	SecretNames []string `json:"secretNames"`
+	// CredentialsSecretName names a Secret in the VaultUnsealer's namespace
+	// holding the service principal's clientID and clientSecret.
+	CredentialsSecretName string `json:"credentialsSecretName"`
+}
+
+// Maintenance window modes.
+const (
+	// MaintenanceWindowSuppress stops unsealing while the window is open.
+	MaintenanceWindowSuppress = "Suppress"
+	// MaintenanceWindowAllow permits unsealing only while an Allow window is open.
+	MaintenanceWindowAllow = "Allow"
+)
+
+// MaintenanceWindow is a recurring period during which unsealing is
+// suppressed or, in Allow mode, exclusively permitted.
+type MaintenanceWindow struct {
+	// Schedule is a five-field cron expression for when the window opens.
+	Schedule string `json:"schedule"`
+	// Duration is how long the window stays open.
+	Duration metav1.Duration `json:"duration"`
+	// TimeZone is the IANA time zone Schedule is evaluated in. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+	// Mode is Suppress (the default) or Allow. Suppress windows take
+	// precedence over Allow windows.
+	// +kubebuilder:validation:Enum=Suppress;Allow
+	// +optional
+	Mode string `json:"mode,omitempty"`
+}
+
+// SealWatchSpec configures detection of pods that seal between reconciles.
+type SealWatchSpec struct {
+	// Interval is how often the seal status of each pod is polled. A pod that
+	// is found sealed raises a VaultSealed Warning event; unsealing still
+	// happens only on the regular reconcile.
+	Interval metav1.Duration `json:"interval"`
+}
+
+// Canary defaults.
+const (
+	DefaultCanaryPodCount = 1
+	DefaultCanarySoakTime = 5 * time.Minute
+)
+
+// CanarySpec configures canary unsealing.
+type CanarySpec struct {
+	// Enabled turns canary unsealing on.
+	Enabled bool `json:"enabled"`
+
+	// PodCount is how many pods must be unsealed and healthy for soakTime
+	// before keys go to the remaining pods. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PodCount int32 `json:"podCount,omitempty"`
+
+	// SoakTime is how long a canary pod must stay unsealed and Ready.
+	// Defaults to 5m.
+	// +optional
+	SoakTime *metav1.Duration `json:"soakTime,omitempty"`
+}
+
+// Pods returns podCount, or its default when unset.
+func (c CanarySpec) Pods() int {
+	if c.PodCount <= 0 {
+		return DefaultCanaryPodCount
+	}
+	return int(c.PodCount)
+}
+
+// Soak returns soakTime, or its default when unset.
+func (c CanarySpec) Soak() time.Duration {
+	if c.SoakTime == nil {
+		return DefaultCanarySoakTime
+	}
+	return c.SoakTime.Duration
 }
 
 // Condition represents the state of a resource.
@@ -60,12 +646,333 @@ type Condition struct {
 	Message string `json:"message,omitempty"`
 }
 
+// Pod seal states reported in PodStatus.
+const (
+	PodStateSealed   = "Sealed"
+	PodStateUnsealed = "Unsealed"
+	PodStateUnknown  = "Unknown"
+	// PodStateNotAVaultEndpoint marks a pod matched by the selector that
+	// answers, but not with the Vault API, such as an exporter or agent.
+	PodStateNotAVaultEndpoint = "NotAVaultEndpoint"
+)
+
+// PodStatus records the observed seal state of a single Vault pod.
+type PodStatus struct {
+	Name           string       `json:"name"`
+	State          string       `json:"state"`
+	LastUnsealTime *metav1.Time `json:"lastUnsealTime,omitempty"`
+	Message        string       `json:"message,omitempty"`
+
+	// Role is the node's HA role while it is unsealed: Active, Standby,
+	// PerformanceStandby or DRSecondary.
+	// +optional
+	Role string `json:"role,omitempty"`
+
+	// Revision is the StatefulSet revision the pod was created from, from its
+	// controller-revision-hash label.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// Stable is true once an unsealed pod has been seen still unsealed by a
+	// follow-up check some time after the operator last unsealed it.
+	// +optional
+	Stable bool `json:"stable,omitempty"`
+
+	// UnsealNonce is the nonce of the unseal round in progress on a sealed
+	// pod, as of the last check. Operators submitting keys by hand can compare
+	// it with `vault status` to tell whether they are adding to the
+	// operator's round, instead of resetting it. Empty while no round is in
+	// progress.
+	// +optional
+	UnsealNonce string `json:"unsealNonce,omitempty"`
+	// UnsealProgress is how many key shares the round has received.
+	// +optional
+	UnsealProgress int32 `json:"unsealProgress,omitempty"`
+	// UnsealThreshold is how many key shares the pod needs to unseal.
+	// +optional
+	UnsealThreshold int32 `json:"unsealThreshold,omitempty"`
+
+	// ConsecutiveFailures counts failed unseal attempts since the last success.
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+	// NextAttemptTime is when the pod may be retried after a failure.
+	NextAttemptTime *metav1.Time `json:"nextAttemptTime,omitempty"`
+
+	// ReconcileRequestedAt is the value of the pod's reconcile-requested-at
+	// annotation that was last acted on. A pod whose annotation differs is
+	// reconciled on its own by the next reconcile.
+	// +optional
+	ReconcileRequestedAt string `json:"reconcileRequestedAt,omitempty"`
+
+	// Keys accounts for each unseal key share submitted to the pod.
+	Keys []KeyStat `json:"keys,omitempty"`
+
+	// UnsealHistory lists the most recent unseal attempts on the pod, oldest
+	// first. Passes that find the pod already unsealed are not recorded.
+	// +optional
+	UnsealHistory []UnsealEvent `json:"unsealHistory,omitempty"`
+}
+
+// Results recorded in UnsealEvent.
+const (
+	// UnsealResultUnsealed means the attempt unsealed the pod.
+	UnsealResultUnsealed = "Unsealed"
+	// UnsealResultIncomplete means keys were submitted but the pod is still
+	// sealed, for example because too few shares are available.
+	UnsealResultIncomplete = "Incomplete"
+	// UnsealResultFailed means the attempt returned an error.
+	UnsealResultFailed = "Failed"
+)
+
+// UnsealEvent records one unseal attempt on a pod.
+type UnsealEvent struct {
+	// Time is when the attempt started.
+	Time metav1.Time `json:"time"`
+	// Result is Unsealed, Incomplete or Failed.
+	Result string `json:"result"`
+	// Duration is how long the attempt took.
+	Duration metav1.Duration `json:"duration"`
+}
+
+// KeyStat counts the outcomes of submitting one unseal key share to a pod.
+// Shares are identified by position and fingerprint, never by value.
+type KeyStat struct {
+	// Index is the 1-based position of the share in the loaded key set.
+	Index int32 `json:"index"`
+	// Fingerprint is a truncated SHA-256 digest of the share.
+	Fingerprint string `json:"fingerprint"`
+
+	// Advanced counts submissions that moved unseal progress forward.
+	Advanced int32 `json:"advanced,omitempty"`
+	// NoProgress counts submissions Vault accepted without advancing progress,
+	// such as a share already provided in the current round.
+	NoProgress int32 `json:"noProgress,omitempty"`
+	// Rejected counts submissions Vault refused as invalid.
+	Rejected int32 `json:"rejected,omitempty"`
+	// ConsecutiveRejections counts rejections since the share last advanced progress.
+	ConsecutiveRejections int32 `json:"consecutiveRejections,omitempty"`
+	// Quarantined marks a share that was rejected too many times in a row.
+	// It is no longer submitted to the pod until it changes or is removed
+	// from the key set.
+	Quarantined bool `json:"quarantined,omitempty"`
+}
+
+// KeySecretStatus records who created and last changed an unseal keys
+// Secret.
+type KeySecretStatus struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+
+	// CreationTime is when the Secret was created.
+	CreationTime metav1.Time `json:"creationTime"`
+	// CreatedBy is the field manager that created the Secret, while its
+	// managedFields entry is still present.
+	// +optional
+	CreatedBy string `json:"createdBy,omitempty"`
+
+	// ModifiedTime is when the keys last changed, taken from managedFields.
+	ModifiedTime metav1.Time `json:"modifiedTime"`
+	// ModifiedBy is the autounseal.vault.io/modified-by annotation of the
+	// Secret when set, otherwise the field manager that last wrote the keys,
+	// such as kubectl-edit.
+	// +optional
+	ModifiedBy string `json:"modifiedBy,omitempty"`
+
+	// ResourceVersion is the resourceVersion of the Secret the keys were last
+	// loaded from.
+	// +optional
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+	// LastUnsealResourceVersion is the resourceVersion of the Secret the keys
+	// were loaded from when they last unsealed a pod, so the key version that
+	// unlocked Vault at LastUnsealTime can be traced.
+	// +optional
+	LastUnsealResourceVersion string `json:"lastUnsealResourceVersion,omitempty"`
+	// LastUnsealTime is when keys from this version of the Secret last
+	// unsealed a pod.
+	// +optional
+	LastUnsealTime *metav1.Time `json:"lastUnsealTime,omitempty"`
+}
+
+// CustodianStatus records the contribution of one custodian.
+type CustodianStatus struct {
+	Name string `json:"name"`
+	// SharesAvailable is the number of valid, distinct shares the
+	// custodian's Secret holds.
+	SharesAvailable int32 `json:"sharesAvailable"`
+	// SharesUsed is the number of those shares that were submitted.
+	SharesUsed int32 `json:"sharesUsed"`
+}
+
+// SealConfigStatus is the shape of the Vault seal as its pods report it in
+// sys/seal-status.
+type SealConfigStatus struct {
+	// Shares is the number of key shares the root key was split into (n).
+	Shares int32 `json:"shares"`
+	// Threshold is the number of shares needed to unseal (t).
+	Threshold int32 `json:"threshold"`
+}
+
 // VaultUnsealerStatus defines the observed state of VaultUnsealer.
 type VaultUnsealerStatus struct {
-	PodsChecked       []string     `json:"podsChecked,omitempty"`
-	UnsealedPods      []string     `json:"unsealedPods,omitempty"`
-	Conditions        []Condition  `json:"conditions,omitempty"`
+	PodsChecked  []string    `json:"podsChecked,omitempty"`
+	UnsealedPods []string    `json:"unsealedPods,omitempty"`
+	Pods         []PodStatus `json:"pods,omitempty"`
+	Conditions   []Condition `json:"conditions,omitempty"`
+
+	// LastReconcileTime is when a reconcile last changed the status. Reconciles
+	// that change nothing else leave it untouched to avoid needless writes.
 	LastReconcileTime *metav1.Time `json:"lastReconcileTime,omitempty"`
+
+	// BreakGlassUntil is the expiry of the break-glass override currently in
+	// effect, if any.
+	BreakGlassUntil *metav1.Time `json:"breakGlassUntil,omitempty"`
+
+	// PodDiscoveryFailures counts consecutive failures to list the Vault pods.
+	// Reconciles are retried with exponential backoff while it is non-zero.
+	PodDiscoveryFailures int32 `json:"podDiscoveryFailures,omitempty"`
+
+	// EffectiveConfig is the configuration the controller is applying once
+	// defaults, derivations and operator-wide bounds are taken into account.
+	EffectiveConfig *EffectiveConfig `json:"effectiveConfig,omitempty"`
+
+	// OperatorVersion is the version of the operator that last reconciled
+	// this resource, to spot resources left behind during an upgrade.
+	OperatorVersion string `json:"operatorVersion,omitempty"`
+
+	// AllPodsUnsealed is true when Vault pods were found and every one of
+	// them was unsealed by the last reconcile that checked them.
+	// +optional
+	AllPodsUnsealed bool `json:"allPodsUnsealed"`
+
+	// Rollout reports the progress of a rolling update of the StatefulSet
+	// owning the Vault pods, such as "2/3 new revision unsealed". It is empty
+	// when no update is in progress.
+	// +optional
+	Rollout string `json:"rollout,omitempty"`
+
+	// ConsecutiveFailures counts the reconciles in a row that failed to
+	// discover the pods, load the keys or unseal a pod. It is reset by the
+	// first reconcile that completes without a failure.
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// LastError is the error of the most recent failed reconcile, on a single
+	// line with any credentials in URLs removed. It is kept after recovery;
+	// ConsecutiveFailures tells whether it is current.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+
+	// KeySecrets records the origin of each unseal keys Secret as of the
+	// last reconcile that loaded the keys. A KeySecretChanged Event is
+	// emitted when one changes.
+	// +optional
+	KeySecrets []KeySecretStatus `json:"keySecrets,omitempty"`
+
+	// Custodians reports how many shares each custodian holds and how many
+	// of them the last reconcile that loaded the keys used. The shares
+	// themselves are never recorded.
+	// +optional
+	Custodians []CustodianStatus `json:"custodians,omitempty"`
+
+	// SealConfig is the number of key shares and the unseal threshold Vault
+	// last reported. A SealConfigChanged Event is emitted when it changes,
+	// as it does when Vault is rekeyed.
+	// +optional
+	SealConfig *SealConfigStatus `json:"sealConfig,omitempty"`
+
+	// StatusConfigMap is the name of the ConfigMap the status summary was
+	// last exported to, so it can be removed when the name changes.
+	// +optional
+	StatusConfigMap string `json:"statusConfigMap,omitempty"`
+
+	// DeferredPods lists the pods the last reconcile did not get to before
+	// spec.reconcileBudget ran out. The next reconcile checks them first.
+	// +optional
+	DeferredPods []string `json:"deferredPods,omitempty"`
+
+	// CanaryPods lists the pods chosen to be unsealed first while
+	// spec.canary holds back the others. It is cleared once enough of them
+	// have soaked.
+	// +optional
+	CanaryPods []string `json:"canaryPods,omitempty"`
+
+	// Warnings lists the misconfigurations and runtime problems found by the
+	// most recent reconcile. It is rebuilt every reconcile, so an entry stays
+	// until its cause is fixed.
+	// +optional
+	// +listType=atomic
+	Warnings []StatusWarning `json:"warnings,omitempty"`
+}
+
+// Sources of the entries in status.warnings.
+const (
+	// WarningSourceAdmission marks a warning the admission webhook returns
+	// for the spec.
+	WarningSourceAdmission = "Admission"
+	// WarningSourceRuntime marks a warning about what the controller found
+	// while reconciling, such as unusable key entries.
+	WarningSourceRuntime = "Runtime"
+)
+
+// StatusWarning is one entry of status.warnings.
+type StatusWarning struct {
+	// Source is Admission or Runtime.
+	// +kubebuilder:validation:Enum=Admission;Runtime
+	Source string `json:"source"`
+	// Message describes the problem.
+	Message string `json:"message"`
+}
+
+// Unsealing strategies reported in status.effectiveConfig.strategy.
+const (
+	// StrategyHA unseals every matching pod.
+	StrategyHA = "HA"
+	// StrategySingle stops after the first pod is unsealed.
+	StrategySingle = "Single"
+	// StrategyMonitorOnly reads the seal status of every matching pod and
+	// never submits keys.
+	StrategyMonitorOnly = "MonitorOnly"
+)
+
+// Addressing modes reported in status.effectiveConfig.addressingMode.
+const (
+	// AddressingModePodIP reaches each pod on its pod IP.
+	AddressingModePodIP = "PodIP"
+	// AddressingModePerPodHost reaches each pod on spec.vault.perPodHostTemplate.
+	AddressingModePerPodHost = "PerPodHost"
+	// AddressingModeMeshSidecar reaches each pod through the mesh sidecar
+	// listener on localhost set in spec.vault.meshSidecar.
+	AddressingModeMeshSidecar = "MeshSidecar"
+	// AddressingModeAPIServerProxy reaches each pod through the apiserver's
+	// pod proxy, as set by spec.vault.apiServerProxy.
+	AddressingModeAPIServerProxy = "APIServerProxy"
+)
+
+// EffectiveConfig records the resolved settings of a VaultUnsealer.
+type EffectiveConfig struct {
+	// Interval is the reconcile interval after defaulting and clamping to
+	// the operator's bounds.
+	Interval metav1.Duration `json:"interval"`
+	// FastInterval is the reconcile interval used while any pod needs
+	// attention, after defaulting and clamping.
+	FastInterval metav1.Duration `json:"fastInterval"`
+	// KeyThreshold is how many unseal keys are submitted to a sealed pod:
+	// spec.keyThreshold, or every loaded key when it is unset or larger.
+	KeyThreshold int `json:"keyThreshold,omitempty"`
+	// Strategy is HA or Single, from spec.mode.stopAfterFirstUnseal.
+	Strategy string `json:"strategy"`
+	// ReplicaScope is Cluster or Single, from spec.mode.scope.
+	ReplicaScope string `json:"replicaScope,omitempty"`
+	// ReadinessPolicy is the policy deciding the Ready condition.
+	ReadinessPolicy string `json:"readinessPolicy"`
+	// AddressingMode is how each pod's Vault API is reached: PodIP,
+	// PerPodHost, MeshSidecar or APIServerProxy.
+	AddressingMode string `json:"addressingMode"`
+	// RequirePodReady reports whether only Ready pods are unsealed.
+	RequirePodReady bool `json:"requirePodReady"`
+	// ErrorPolicy is ContinueOtherPods or AbortReconcile.
+	ErrorPolicy string `json:"errorPolicy"`
+	// Priority is High, Normal or Low, from spec.priority.
+	Priority string `json:"priority"`
 }
 
 // +kubebuilder:object:root=true
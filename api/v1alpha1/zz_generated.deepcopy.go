@@ -0,0 +1,672 @@
+//go:build !ignore_autogenerated
+
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanarySpec) DeepCopyInto(out *CanarySpec) {
+	*out = *in
+	if in.SoakTime != nil {
+		in, out := &in.SoakTime, &out.SoakTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanarySpec.
+func (in *CanarySpec) DeepCopy() *CanarySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CanarySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Custodian) DeepCopyInto(out *Custodian) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Custodian.
+func (in *Custodian) DeepCopy() *Custodian {
+	if in == nil {
+		return nil
+	}
+	out := new(Custodian)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustodianStatus) DeepCopyInto(out *CustodianStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustodianStatus.
+func (in *CustodianStatus) DeepCopy() *CustodianStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CustodianStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSSpec) DeepCopyInto(out *DNSSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSSpec.
+func (in *DNSSpec) DeepCopy() *DNSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EffectiveConfig) DeepCopyInto(out *EffectiveConfig) {
+	*out = *in
+	out.Interval = in.Interval
+	out.FastInterval = in.FastInterval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveConfig.
+func (in *EffectiveConfig) DeepCopy() *EffectiveConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EffectiveConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HCPVaultSecretsSource) DeepCopyInto(out *HCPVaultSecretsSource) {
+	*out = *in
+	if in.SecretNames != nil {
+		in, out := &in.SecretNames, &out.SecretNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HCPVaultSecretsSource.
+func (in *HCPVaultSecretsSource) DeepCopy() *HCPVaultSecretsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(HCPVaultSecretsSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckSpec) DeepCopyInto(out *HealthCheckSpec) {
+	*out = *in
+	if in.AcceptedStatusCodes != nil {
+		in, out := &in.AcceptedStatusCodes, &out.AcceptedStatusCodes
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckSpec.
+func (in *HealthCheckSpec) DeepCopy() *HealthCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeySecretStatus) DeepCopyInto(out *KeySecretStatus) {
+	*out = *in
+	in.CreationTime.DeepCopyInto(&out.CreationTime)
+	in.ModifiedTime.DeepCopyInto(&out.ModifiedTime)
+	if in.LastUnsealTime != nil {
+		in, out := &in.LastUnsealTime, &out.LastUnsealTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeySecretStatus.
+func (in *KeySecretStatus) DeepCopy() *KeySecretStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeySecretStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyStat) DeepCopyInto(out *KeyStat) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyStat.
+func (in *KeyStat) DeepCopy() *KeyStat {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyStat)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MeshSidecarSpec) DeepCopyInto(out *MeshSidecarSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeshSidecarSpec.
+func (in *MeshSidecarSpec) DeepCopy() *MeshSidecarSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MeshSidecarSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModeSpec) DeepCopyInto(out *ModeSpec) {
+	*out = *in
+	if in.HA != nil {
+		in, out := &in.HA, &out.HA
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StopAfterFirstUnseal != nil {
+		in, out := &in.StopAfterFirstUnseal, &out.StopAfterFirstUnseal
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModeSpec.
+func (in *ModeSpec) DeepCopy() *ModeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodStatus) DeepCopyInto(out *PodStatus) {
+	*out = *in
+	if in.LastUnsealTime != nil {
+		in, out := &in.LastUnsealTime, &out.LastUnsealTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextAttemptTime != nil {
+		in, out := &in.NextAttemptTime, &out.NextAttemptTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]KeyStat, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnsealHistory != nil {
+		in, out := &in.UnsealHistory, &out.UnsealHistory
+		*out = make([]UnsealEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodStatus.
+func (in *PodStatus) DeepCopy() *PodStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PodStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessGateSpec) DeepCopyInto(out *ReadinessGateSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessGateSpec.
+func (in *ReadinessGateSpec) DeepCopy() *ReadinessGateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessGateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SealConfigStatus) DeepCopyInto(out *SealConfigStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SealConfigStatus.
+func (in *SealConfigStatus) DeepCopy() *SealConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SealConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SealWatchSpec) DeepCopyInto(out *SealWatchSpec) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SealWatchSpec.
+func (in *SealWatchSpec) DeepCopy() *SealWatchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SealWatchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretRef) DeepCopyInto(out *SecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretRef.
+func (in *SecretRef) DeepCopy() *SecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatusConfigMapSpec) DeepCopyInto(out *StatusConfigMapSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusConfigMapSpec.
+func (in *StatusConfigMapSpec) DeepCopy() *StatusConfigMapSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusConfigMapSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatusWarning) DeepCopyInto(out *StatusWarning) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusWarning.
+func (in *StatusWarning) DeepCopy() *StatusWarning {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusWarning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnsealEvent) DeepCopyInto(out *UnsealEvent) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnsealEvent.
+func (in *UnsealEvent) DeepCopy() *UnsealEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(UnsealEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultConnectionSpec) DeepCopyInto(out *VaultConnectionSpec) {
+	*out = *in
+	if in.CABundleSecretRef != nil {
+		in, out := &in.CABundleSecretRef, &out.CABundleSecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+	if in.CABundleSecretRefs != nil {
+		in, out := &in.CABundleSecretRefs, &out.CABundleSecretRefs
+		*out = make([]SecretRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.MeshSidecar != nil {
+		in, out := &in.MeshSidecar, &out.MeshSidecar
+		*out = new(MeshSidecarSpec)
+		**out = **in
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(HealthCheckSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DNS != nil {
+		in, out := &in.DNS, &out.DNS
+		*out = new(DNSSpec)
+		**out = **in
+	}
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(SecretRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultConnectionSpec.
+func (in *VaultConnectionSpec) DeepCopy() *VaultConnectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultConnectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultUnsealer) DeepCopyInto(out *VaultUnsealer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultUnsealer.
+func (in *VaultUnsealer) DeepCopy() *VaultUnsealer {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultUnsealer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultUnsealer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultUnsealerList) DeepCopyInto(out *VaultUnsealerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VaultUnsealer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultUnsealerList.
+func (in *VaultUnsealerList) DeepCopy() *VaultUnsealerList {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultUnsealerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VaultUnsealerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultUnsealerSpec) DeepCopyInto(out *VaultUnsealerSpec) {
+	*out = *in
+	in.Vault.DeepCopyInto(&out.Vault)
+	if in.UnsealKeysSecretRefs != nil {
+		in, out := &in.UnsealKeysSecretRefs, &out.UnsealKeysSecretRefs
+		*out = make([]SecretRef, len(*in))
+		copy(*out, *in)
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	in.Mode.DeepCopyInto(&out.Mode)
+	if in.VaultLabelSelectors != nil {
+		in, out := &in.VaultLabelSelectors, &out.VaultLabelSelectors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FastInterval != nil {
+		in, out := &in.FastInterval, &out.FastInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RequirePodReady != nil {
+		in, out := &in.RequirePodReady, &out.RequirePodReady
+		*out = new(bool)
+		**out = **in
+	}
+	if in.KeySubmissionDelay != nil {
+		in, out := &in.KeySubmissionDelay, &out.KeySubmissionDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ReconcileBudget != nil {
+		in, out := &in.ReconcileBudget, &out.ReconcileBudget
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.SealWatch != nil {
+		in, out := &in.SealWatch, &out.SealWatch
+		*out = new(SealWatchSpec)
+		**out = **in
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(CanarySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.HCPVaultSecrets != nil {
+		in, out := &in.HCPVaultSecrets, &out.HCPVaultSecrets
+		*out = new(HCPVaultSecretsSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Custodians != nil {
+		in, out := &in.Custodians, &out.Custodians
+		*out = make([]Custodian, len(*in))
+		copy(*out, *in)
+	}
+	if in.StatusConfigMap != nil {
+		in, out := &in.StatusConfigMap, &out.StatusConfigMap
+		*out = new(StatusConfigMapSpec)
+		**out = **in
+	}
+	if in.ReadinessGate != nil {
+		in, out := &in.ReadinessGate, &out.ReadinessGate
+		*out = new(ReadinessGateSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultUnsealerSpec.
+func (in *VaultUnsealerSpec) DeepCopy() *VaultUnsealerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultUnsealerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultUnsealerStatus) DeepCopyInto(out *VaultUnsealerStatus) {
+	*out = *in
+	if in.PodsChecked != nil {
+		in, out := &in.PodsChecked, &out.PodsChecked
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnsealedPods != nil {
+		in, out := &in.UnsealedPods, &out.UnsealedPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]PodStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastReconcileTime != nil {
+		in, out := &in.LastReconcileTime, &out.LastReconcileTime
+		*out = (*in).DeepCopy()
+	}
+	if in.BreakGlassUntil != nil {
+		in, out := &in.BreakGlassUntil, &out.BreakGlassUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.EffectiveConfig != nil {
+		in, out := &in.EffectiveConfig, &out.EffectiveConfig
+		*out = new(EffectiveConfig)
+		**out = **in
+	}
+	if in.KeySecrets != nil {
+		in, out := &in.KeySecrets, &out.KeySecrets
+		*out = make([]KeySecretStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Custodians != nil {
+		in, out := &in.Custodians, &out.Custodians
+		*out = make([]CustodianStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.SealConfig != nil {
+		in, out := &in.SealConfig, &out.SealConfig
+		*out = new(SealConfigStatus)
+		**out = **in
+	}
+	if in.DeferredPods != nil {
+		in, out := &in.DeferredPods, &out.DeferredPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CanaryPods != nil {
+		in, out := &in.CanaryPods, &out.CanaryPods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]StatusWarning, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultUnsealerStatus.
+func (in *VaultUnsealerStatus) DeepCopy() *VaultUnsealerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultUnsealerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -17,20 +17,30 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	// Embed the time zone database for maintenance windows; the distroless
+	// base image ships without one.
+	_ "time/tzdata"
 
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
@@ -38,7 +48,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/admin"
+	"github.com/panteparak/vault-unsealer/internal/certs"
+	"github.com/panteparak/vault-unsealer/internal/cli"
 	"github.com/panteparak/vault-unsealer/internal/controller"
+	"github.com/panteparak/vault-unsealer/internal/logging"
+	"github.com/panteparak/vault-unsealer/internal/metrics"
 	"github.com/panteparak/vault-unsealer/internal/secrets"
 	vaultwebhook "github.com/panteparak/vault-unsealer/internal/webhook"
 	// +kubebuilder:scaffold:imports
@@ -49,6 +64,13 @@ var (
 	setupLog = ctrl.Log.WithName("setup")
 )
 
+// Build information, set with -ldflags "-X main.version=...".
+var (
+	version   = "dev"
+	gitCommit = "unknown"
+	buildDate = ""
+)
+
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 
@@ -58,29 +80,134 @@ func init() {
 
 // nolint:gocyclo
 func main() {
+	if len(os.Args) > 1 {
+		if run, ok := cli.Lookup(os.Args[1]); ok {
+			if err := run(os.Args[2:], os.Stdout); err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			os.Exit(0)
+		}
+	}
+
 	var metricsAddr string
 	var metricsCertPath, metricsCertName, metricsCertKey string
 	var webhookCertPath, webhookCertName, webhookCertKey string
+	var enableWebhooks, selfManagedWebhookCerts bool
+	var operatorNamespace, webhookServiceName, webhookSecretName, webhookConfigName string
+	var adminAddr, adminCertPath, adminCertName, adminCertKey, adminClientCAFile string
 	var enableLeaderElection bool
+	var leaseDuration, renewDeadline, retryPeriod time.Duration
+	var syncPeriod time.Duration
+	var kubeAPIQPS float64
+	var kubeAPIBurst int
+	var shardCount, shardID int
+	var minInterval, maxInterval time.Duration
+	var enableDiscovery bool
+	var enableHelmDiscovery bool
+	var enableFinalizer bool
+	var requireSecretAccessGrants bool
+	var globalPause bool
+	var configMapName string
+	var maxConcurrentReconciles, startupConcurrency int
+	var startupBurstDuration, reconcileTimeout time.Duration
 	var probeAddr string
-	var secureMetrics bool
+	var pprofAddr string
+	var secureMetrics, metricsAuth bool
 	var enableHTTP2 bool
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.StringVar(&pprofAddr, "pprof-bind-address", "0", "The address the pprof endpoint binds to, e.g. 127.0.0.1:6060. "+
+		"Use \"0\" to disable it. The endpoint is unauthenticated, so only bind it to a local or otherwise protected address.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.DurationVar(&leaseDuration, "leader-elect-lease-duration", 15*time.Second,
+		"How long standby replicas wait after the last renewal before taking over leadership. "+
+			"Shorter values speed up failover at the cost of more API requests.")
+	flag.DurationVar(&renewDeadline, "leader-elect-renew-deadline", 10*time.Second,
+		"How long the leader keeps retrying to renew its lease before giving up leadership. "+
+			"Must be shorter than --leader-elect-lease-duration.")
+	flag.DurationVar(&retryPeriod, "leader-elect-retry-period", 2*time.Second,
+		"How often leader election clients retry acquiring or renewing the lease.")
+	flag.DurationVar(&syncPeriod, "sync-period", 10*time.Hour,
+		"How often the informer caches are resynced, requeueing every watched object.")
+	flag.Float64Var(&kubeAPIQPS, "kube-api-qps", 20,
+		"The sustained queries per second the manager may send to the Kubernetes API server.")
+	flag.IntVar(&kubeAPIBurst, "kube-api-burst", 30,
+		"The number of queries the manager may burst to the Kubernetes API server above --kube-api-qps.")
+	flag.BoolVar(&enableDiscovery, "enable-discovery", false,
+		"If set, a VaultUnsealer is provisioned automatically for every StatefulSet labelled "+
+			opsv1alpha1.LabelDiscover+"=true.")
+	flag.BoolVar(&enableHelmDiscovery, "enable-helm-discovery", false,
+		"If set, a VaultUnsealer is provisioned automatically for every Vault server StatefulSet installed by the "+
+			"HashiCorp Vault Helm chart, with the address and TLS settings inferred from the release.")
+	flag.BoolVar(&enableFinalizer, "enable-finalizer", false,
+		"If set, a finalizer is added to every VaultUnsealer so its metrics are cleaned up even when it is deleted "+
+			"while the operator is down. The finalizer blocks namespace deletion until the operator is running.")
+	flag.BoolVar(&requireSecretAccessGrants, "require-secret-access-grants", true,
+		"If set, VaultUnsealers may only read unseal keys Secrets from another namespace when that namespace lists "+
+			"theirs in its "+opsv1alpha1.AnnotationAllowedUnsealerNamespaces+" annotation.")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"The number of VaultUnsealers reconciled in parallel.")
+	flag.IntVar(&startupConcurrency, "startup-concurrency", 10,
+		"The number of VaultUnsealers reconciled in parallel right after the controller starts, so Vault is "+
+			"unsealed quickly after a cluster-wide restart. Set it to max-concurrent-reconciles or lower to disable the burst.")
+	flag.DurationVar(&startupBurstDuration, "startup-burst-duration", 2*time.Minute,
+		"How long the startup-concurrency burst lasts once the controller starts reconciling.")
+	flag.DurationVar(&reconcileTimeout, "reconcile-timeout", controller.DefaultReconcileTimeout,
+		"How long a reconcile may run before it is cancelled, a ReconcileTimeout condition is recorded and its "+
+			"worker is freed. Set it to 0 to disable the watchdog.")
+	flag.IntVar(&shardCount, "shard-count", 1,
+		"The number of shards VaultUnsealer resources are split across. Each shard is reconciled by its own "+
+			"replica, with leader election (if enabled) scoped to the shard.")
+	flag.IntVar(&shardID, "shard-id", -1,
+		"The shard handled by this replica. Defaults to the ordinal suffix of the POD_NAME environment variable.")
+	flag.DurationVar(&minInterval, "min-interval", opsv1alpha1.DefaultMinInterval,
+		"The shortest spec.interval honoured. Shorter intervals are raised to this value with a Warning event.")
+	flag.DurationVar(&maxInterval, "max-interval", opsv1alpha1.DefaultMaxInterval,
+		"The longest spec.interval honoured. Longer intervals are lowered to this value with a Warning event.")
 	flag.BoolVar(&secureMetrics, "metrics-secure", true,
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
+	flag.BoolVar(&metricsAuth, "metrics-auth", true,
+		"If set along with --metrics-secure, scrapes must present a bearer token that is authenticated with a "+
+			"TokenReview and authorized for the request path with a SubjectAccessReview.")
 	flag.StringVar(&webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
 	flag.StringVar(&webhookCertName, "webhook-cert-name", "tls.crt", "The name of the webhook certificate file.")
 	flag.StringVar(&webhookCertKey, "webhook-cert-key", "tls.key", "The name of the webhook key file.")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", os.Getenv("ENABLE_WEBHOOKS") != "false",
+		"If set, the validating admission webhook is registered. When disabled, the controller validates "+
+			"resources itself and reports failures through the InvalidSpec condition.")
+	flag.BoolVar(&selfManagedWebhookCerts, "self-managed-webhook-certs", false,
+		"If set, the manager generates and rotates the webhook serving certificate itself and injects "+
+			"the CA into the ValidatingWebhookConfiguration, removing the need for cert-manager.")
+	flag.StringVar(&operatorNamespace, "operator-namespace", envOrDefault("POD_NAMESPACE", "vault-unsealer-system"),
+		"The namespace the manager runs in, used for the self-managed webhook certificate Secret.")
+	flag.BoolVar(&globalPause, "global-pause", false,
+		"If set, no unseal keys are submitted to any Vault pod. Reconciles continue and report the Paused condition.")
+	flag.StringVar(&configMapName, "config-map-name", "vault-unsealer-config",
+		"The ConfigMap in the operator namespace read for runtime settings such as globalPause. "+
+			"Leave empty to disable it.")
+	flag.StringVar(&webhookServiceName, "webhook-service-name", "vault-unsealer-webhook-service",
+		"The Service fronting the webhook server, used for the self-managed certificate DNS names.")
+	flag.StringVar(&webhookSecretName, "webhook-cert-secret-name", "vault-unsealer-webhook-server-cert",
+		"The Secret storing the self-managed webhook CA and serving certificate.")
+	flag.StringVar(&webhookConfigName, "webhook-config-name", "vault-unsealer-validating-webhook-configuration",
+		"The ValidatingWebhookConfiguration whose caBundle is kept in sync with the self-managed CA.")
 	flag.StringVar(&metricsCertPath, "metrics-cert-path", "",
 		"The directory that contains the metrics server certificate.")
 	flag.StringVar(&metricsCertName, "metrics-cert-name", "tls.crt", "The name of the metrics server certificate file.")
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
+	flag.StringVar(&adminAddr, "admin-bind-address", "0", "The address the admin API binds to. "+
+		"Use :9443 to enable it, or leave as 0 to disable the admin API.")
+	flag.StringVar(&adminCertPath, "admin-cert-path", "",
+		"The directory that contains the admin API certificate. A self-signed certificate is generated when empty.")
+	flag.StringVar(&adminCertName, "admin-cert-name", "tls.crt", "The name of the admin API certificate file.")
+	flag.StringVar(&adminCertKey, "admin-cert-key", "tls.key", "The name of the admin API key file.")
+	flag.StringVar(&adminClientCAFile, "admin-client-ca-file", "",
+		"If set, clients presenting a certificate signed by this CA are authenticated via mTLS.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
 	opts := zap.Options{
@@ -89,7 +216,10 @@ func main() {
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
+	logLevel := logging.AtomicLevel(&opts)
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	setupLog.Info("Starting vault-unsealer", "version", version, "commit", gitCommit, "buildDate", buildDate)
+	metrics.SetBuildInfo(version, gitCommit)
 
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
 	// due to its vulnerabilities. More specifically, disabling http/2 will
@@ -106,13 +236,89 @@ func main() {
 		tlsOpts = append(tlsOpts, disableHTTP2)
 	}
 
+	if maxConcurrentReconciles < 1 {
+		setupLog.Error(fmt.Errorf("max-concurrent-reconciles must be at least 1, got %d", maxConcurrentReconciles),
+			"invalid concurrency configuration")
+		os.Exit(1)
+	}
+
+	if minInterval <= 0 || maxInterval < minInterval {
+		setupLog.Error(fmt.Errorf("min-interval %s and max-interval %s do not form a valid range", minInterval, maxInterval),
+			"invalid interval configuration")
+		os.Exit(1)
+	}
+
+	leaderElectionID := "1f47e4d3.autounseal.vault.io"
+	var shard *controller.Shard
+	if shardCount > 1 {
+		if shardID < 0 {
+			ordinal, err := controller.StatefulSetOrdinal(os.Getenv("POD_NAME"))
+			if err != nil {
+				setupLog.Error(err, "unable to determine shard ID, set --shard-id or POD_NAME")
+				os.Exit(1)
+			}
+			shardID = ordinal
+		}
+		if shardID >= shardCount {
+			setupLog.Error(fmt.Errorf("shard ID %d out of range for %d shards", shardID, shardCount), "invalid sharding configuration")
+			os.Exit(1)
+		}
+		shard = &controller.Shard{ID: shardID, Count: shardCount}
+		leaderElectionID = fmt.Sprintf("%s-shard-%d", leaderElectionID, shardID)
+		setupLog.Info("Sharding enabled", "shard-id", shardID, "shard-count", shardCount)
+	}
+
+	if renewDeadline >= leaseDuration {
+		setupLog.Error(fmt.Errorf("renew deadline %s is not shorter than lease duration %s", renewDeadline, leaseDuration),
+			"invalid leader election configuration")
+		os.Exit(1)
+	}
+	if retryPeriod <= 0 || syncPeriod <= 0 {
+		setupLog.Error(fmt.Errorf("--leader-elect-retry-period and --sync-period must be positive"), "invalid manager configuration")
+		os.Exit(1)
+	}
+
+	restConfig := ctrl.GetConfigOrDie()
+	restConfig.QPS = float32(kubeAPIQPS)
+	restConfig.Burst = kubeAPIBurst
+
 	// Create watchers for metrics and webhooks certificates
 	var metricsCertWatcher, webhookCertWatcher *certwatcher.CertWatcher
 
 	// Initial webhook TLS options
 	webhookTLSOpts := tlsOpts
 
-	if len(webhookCertPath) > 0 {
+	// The self-managed certificate must exist on disk before the webhook
+	// certificate watcher below is created, so provision it up front.
+	var webhookCertManager *certs.WebhookCertManager
+	if enableWebhooks && selfManagedWebhookCerts {
+		if len(webhookCertPath) == 0 {
+			webhookCertPath = filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs")
+		}
+
+		certClient, err := client.New(restConfig, client.Options{Scheme: scheme})
+		if err != nil {
+			setupLog.Error(err, "unable to create client for webhook certificate management")
+			os.Exit(1)
+		}
+		webhookCertManager = &certs.WebhookCertManager{
+			Client:            certClient,
+			Namespace:         operatorNamespace,
+			SecretName:        webhookSecretName,
+			ServiceName:       webhookServiceName,
+			WebhookConfigName: webhookConfigName,
+			CertDir:           webhookCertPath,
+			CertName:          webhookCertName,
+			KeyName:           webhookCertKey,
+			RenewBefore:       30 * 24 * time.Hour,
+		}
+		if err := webhookCertManager.Ensure(context.Background()); err != nil {
+			setupLog.Error(err, "unable to provision self-managed webhook certificates")
+			os.Exit(1)
+		}
+	}
+
+	if enableWebhooks && len(webhookCertPath) > 0 {
 		setupLog.Info("Initializing webhook certificate watcher using provided certificates",
 			"webhook-cert-path", webhookCertPath, "webhook-cert-name", webhookCertName, "webhook-cert-key", webhookCertKey)
 
@@ -145,12 +351,23 @@ func main() {
 		TLSOpts:       tlsOpts,
 	}
 
-	if secureMetrics {
+	switch {
+	case secureMetrics && !metricsAuth:
+		setupLog.Info("Metrics authentication disabled, the metrics endpoint serves any client that can reach it")
+	case !secureMetrics && metricsAuth:
+		// Bearer tokens must not travel in plaintext, so authn/authz needs TLS.
+		setupLog.Info("Metrics authentication requires --metrics-secure, serving metrics without it")
+	}
+	if secureMetrics && metricsAuth {
 		// FilterProvider is used to protect the metrics endpoint with authn/authz.
 		// These configurations ensure that only authorized users and service accounts
 		// can access the metrics endpoint. The RBAC are configured in 'config/rbac/kustomization.yaml'. More info:
 		// https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.21.0/pkg/metrics/filters#WithAuthenticationAndAuthorization
 		metricsServerOptions.FilterProvider = filters.WithAuthenticationAndAuthorization
+
+		// The log level can be changed at runtime through the metrics server,
+		// behind the same authn/authz. It is never served unauthenticated.
+		metricsServerOptions.ExtraHandlers = map[string]http.Handler{logging.LogLevelPath: logLevel}
 	}
 
 	// If the certificate is not specified, controller-runtime will automatically
@@ -180,13 +397,18 @@ func main() {
 		})
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme:                 scheme,
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
+		PprofBindAddress:       pprofAddr,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "1f47e4d3.autounseal.vault.io",
+		LeaderElectionID:       leaderElectionID,
+		LeaseDuration:          &leaseDuration,
+		RenewDeadline:          &renewDeadline,
+		RetryPeriod:            &retryPeriod,
+		Cache:                  cache.Options{SyncPeriod: &syncPeriod},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -204,24 +426,124 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := (&controller.VaultUnsealerReconciler{
-		Client:        mgr.GetClient(),
-		Scheme:        mgr.GetScheme(),
-		SecretsLoader: secrets.NewLoader(mgr.GetClient()),
-	}).SetupWithManager(mgr); err != nil {
+	secretsLoader := secrets.NewLoader(mgr.GetClient()).WithImpersonation(
+		secrets.NewImpersonatingClientFactory(mgr.GetConfig(), mgr.GetScheme(), mgr.GetRESTMapper()))
+	if requireSecretAccessGrants {
+		secretsLoader.RequireGrants()
+	}
+	reconciler := &controller.VaultUnsealerReconciler{
+		Client:          mgr.GetClient(),
+		APIReader:       mgr.GetAPIReader(),
+		Scheme:          mgr.GetScheme(),
+		SecretsLoader:   secretsLoader,
+		Shard:           shard,
+		Recorder:        mgr.GetEventRecorderFor("vaultunsealer-controller"),
+		MinInterval:     minInterval,
+		MaxInterval:     maxInterval,
+		EnableFinalizer: enableFinalizer,
+
+		MaxConcurrentReconciles: maxConcurrentReconciles,
+		StartupConcurrency:      startupConcurrency,
+		StartupBurstDuration:    startupBurstDuration,
+		ReconcileTimeout:        reconcileTimeout,
+
+		Version: version,
+	}
+	apiServerProxy, err := controller.NewAPIServerProxy(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to set up the apiserver pod proxy")
+		os.Exit(1)
+	}
+	reconciler.APIServerProxy = apiServerProxy
+	reconciler.GlobalPause = &controller.GlobalPause{
+		Forced:     globalPause,
+		Reader:     mgr.GetAPIReader(),
+		ConfigMap:  types.NamespacedName{Namespace: operatorNamespace, Name: configMapName},
+		Reconciler: reconciler,
+	}
+	if globalPause {
+		setupLog.Info("Global pause set by flag, no unseal keys will be submitted")
+	}
+	if enableDiscovery {
+		if err := (&controller.VaultDiscoveryReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+			Shard:  shard,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "VaultDiscovery")
+			os.Exit(1)
+		}
+	}
+	if enableHelmDiscovery {
+		if err := (&controller.HelmReleaseDiscoveryReconciler{
+			Client: mgr.GetClient(),
+			Scheme: mgr.GetScheme(),
+			Shard:  shard,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "HelmReleaseDiscovery")
+			os.Exit(1)
+		}
+	}
+
+	validator := &vaultwebhook.VaultUnsealerValidator{
+		Client:      mgr.GetClient(),
+		MinInterval: minInterval,
+		MaxInterval: maxInterval,
+	}
+	reconciler.SpecWarnings = func(ctx context.Context, vu *opsv1alpha1.VaultUnsealer) []string {
+		warnings, _ := validator.Validate(ctx, vu)
+		return warnings
+	}
+	if !enableWebhooks {
+		setupLog.Info("Webhooks disabled, validating VaultUnsealer resources in the controller")
+		reconciler.SpecValidator = func(ctx context.Context, vu *opsv1alpha1.VaultUnsealer) error {
+			_, err := validator.Validate(ctx, vu)
+			return err
+		}
+	}
+	if err := reconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "VaultUnsealer")
 		os.Exit(1)
 	}
+	if err := mgr.Add(&controller.SealWatcher{Reconciler: reconciler}); err != nil {
+		setupLog.Error(err, "unable to add seal watcher")
+		os.Exit(1)
+	}
+	if err := mgr.Add(&controller.KeyResidencyReporter{Reconciler: reconciler}); err != nil {
+		setupLog.Error(err, "unable to add key residency reporter")
+		os.Exit(1)
+	}
+	if err := mgr.Add(reconciler.GlobalPause); err != nil {
+		setupLog.Error(err, "unable to add global pause watcher")
+		os.Exit(1)
+	}
 
 	// Setup webhook
-	if err := (&vaultwebhook.VaultUnsealerValidator{
-		Client: mgr.GetClient(),
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create webhook", "webhook", "VaultUnsealer")
-		os.Exit(1)
+	if enableWebhooks {
+		if err := validator.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "VaultUnsealer")
+			os.Exit(1)
+		}
 	}
 	// +kubebuilder:scaffold:builder
 
+	if adminAddr != "0" && adminAddr != "" {
+		setupLog.Info("Enabling admin API", "admin-bind-address", adminAddr)
+		if err := mgr.Add(&admin.Server{
+			Client:       mgr.GetClient(),
+			Diagnoser:    reconciler,
+			BindAddress:  adminAddr,
+			CertPath:     adminCertPath,
+			CertName:     adminCertName,
+			CertKey:      adminCertKey,
+			ClientCAFile: adminClientCAFile,
+			TLSOpts:      tlsOpts,
+		}); err != nil {
+			setupLog.Error(err, "unable to add admin server to manager")
+			os.Exit(1)
+		}
+	}
+
 	if metricsCertWatcher != nil {
 		setupLog.Info("Adding metrics certificate watcher to manager")
 		if err := mgr.Add(metricsCertWatcher); err != nil {
@@ -230,6 +552,14 @@ func main() {
 		}
 	}
 
+	if webhookCertManager != nil {
+		setupLog.Info("Adding webhook certificate rotation to manager")
+		if err := mgr.Add(webhookCertManager); err != nil {
+			setupLog.Error(err, "unable to add webhook certificate manager to manager")
+			os.Exit(1)
+		}
+	}
+
 	if webhookCertWatcher != nil {
 		setupLog.Info("Adding webhook certificate watcher to manager")
 		if err := mgr.Add(webhookCertWatcher); err != nil {
@@ -253,3 +583,11 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// envOrDefault returns the value of the environment variable key, or def when unset.
+func envOrDefault(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
@@ -0,0 +1,24 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/// Package config embeds the operator's kustomize bases, so the install
+// subcommand can apply them without kustomize or Helm.
+package config
+
+import "embed"
+
+// Manifests holds the CRDs, RBAC, manager Deployment and webhook manifests.
+//
+//go:embed crd/kustomization.yaml crd/bases/*.yaml rbac/*.yaml manager/*.yaml webhook/*.yaml default/metrics_service.yaml
+var Manifests embed.FS
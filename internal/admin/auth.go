@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+type userContextKey struct{}
+
+// withAuthentication rejects requests that carry neither a verified client
+// certificate nor a bearer token accepted by the API server's TokenReview.
+func (s *Server) withAuthentication(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, err := s.authenticate(r)
+		if err != nil {
+			adminlog.Info("Rejected unauthenticated admin request", "path", r.URL.Path, "reason", err.Error())
+			writeError(w, http.StatusUnauthorized, err)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userContextKey{}, user)))
+	})
+}
+
+func (s *Server) authenticate(r *http.Request) (authenticationv1.UserInfo, error) {
+	if r.TLS != nil && len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
+		cert := r.TLS.VerifiedChains[0][0]
+		return authenticationv1.UserInfo{
+			Username: cert.Subject.CommonName,
+			Groups:   cert.Subject.Organization,
+		}, nil
+	}
+
+	token, ok := bearerToken(r)
+	if !ok {
+		return authenticationv1.UserInfo{}, fmt.Errorf("missing bearer token or client certificate")
+	}
+
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}
+	if err := s.Client.Create(r.Context(), review); err != nil {
+		return authenticationv1.UserInfo{}, fmt.Errorf("token review failed: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return authenticationv1.UserInfo{}, fmt.Errorf("token not authenticated")
+	}
+	return review.Status.User, nil
+}
+
+// withAuthorization only serves requests whose user may perform verb on the
+// VaultUnsealer named in the path, as decided by a SubjectAccessReview, so the
+// admin API never grants more than the user's own RBAC on the resource.
+func (s *Server) withAuthorization(verb string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		namespace, name := r.PathValue("namespace"), r.PathValue("name")
+		if namespace == "" {
+			namespace = r.URL.Query().Get("namespace")
+		}
+		user := userFrom(r.Context())
+		if err := s.authorize(r.Context(), user, verb, namespace, name); err != nil {
+			adminlog.Info("Rejected unauthorized admin request", "path", r.URL.Path, "user", user.Username, "reason", err.Error())
+			writeError(w, http.StatusForbidden, err)
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) authorize(ctx context.Context, user authenticationv1.UserInfo, verb, namespace, name string) error {
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, values := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(values)
+	}
+
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      verb,
+				Group:     opsv1alpha1.GroupVersion.Group,
+				Version:   opsv1alpha1.GroupVersion.Version,
+				Resource:  "vaultunsealers",
+				Name:      name,
+			},
+		},
+	}
+	if err := s.Client.Create(ctx, review); err != nil {
+		return fmt.Errorf("subject access review failed: %w", err)
+	}
+	if !review.Status.Allowed {
+		return fmt.Errorf("user %q cannot %s vaultunsealers %s/%s", user.Username, verb, namespace, name)
+	}
+	return nil
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	token, found := strings.CutPrefix(header, "Bearer ")
+	if !found || strings.TrimSpace(token) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+// userFrom returns the authenticated user attached to the request context.
+func userFrom(ctx context.Context) authenticationv1.UserInfo {
+	user, _ := ctx.Value(userContextKey{}).(authenticationv1.UserInfo)
+	return user
+}
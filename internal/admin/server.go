@@ -0,0 +1,369 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/certs"
+	"github.com/panteparak/vault-unsealer/internal/controller"
+)
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;patch
+
+var adminlog = logf.Log.WithName("admin")
+
+// Diagnoser returns live per-pod diagnostics for a VaultUnsealer.
+type Diagnoser interface {
+	Diagnose(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) ([]controller.PodDiagnostic, error)
+}
+
+// VaultUnsealerSummary is the admin API representation of a VaultUnsealer.
+type VaultUnsealerSummary struct {
+	Namespace string                          `json:"namespace"`
+	Name      string                          `json:"name"`
+	Paused    bool                            `json:"paused"`
+	Status    opsv1alpha1.VaultUnsealerStatus `json:"status"`
+}
+
+// Server serves the authenticated admin HTTP API used by operational tooling.
+type Server struct {
+	Client    client.Client
+	Diagnoser Diagnoser
+
+	// BindAddress is the address the admin server listens on.
+	BindAddress string
+	// CertPath is the directory containing the serving certificate. A self-signed
+	// certificate is generated when empty.
+	CertPath string
+	CertName string
+	CertKey  string
+	// ClientCAFile enables mTLS authentication for clients presenting a certificate
+	// signed by this CA. Bearer tokens are still accepted via TokenReview.
+	ClientCAFile string
+	TLSOpts      []func(*tls.Config)
+}
+
+// NeedLeaderElection allows every replica to serve the admin API.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start runs the admin server until the context is cancelled.
+func (s *Server) Start(ctx context.Context) error {
+	tlsConfig, certWatcher, err := s.tlsConfig()
+	if err != nil {
+		return err
+	}
+	if certWatcher != nil {
+		go func() {
+			if err := certWatcher.Start(ctx); err != nil {
+				adminlog.Error(err, "Admin certificate watcher failed")
+			}
+		}()
+	}
+
+	listener, err := tls.Listen("tcp", s.BindAddress, tlsConfig)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.BindAddress, err)
+	}
+
+	srv := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			adminlog.Error(err, "Failed to shut down admin server")
+		}
+	}()
+
+	adminlog.Info("Starting admin server", "address", listener.Addr().String())
+	if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Handler returns the admin API routes wrapped in authentication and
+// per-route authorization.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/vaultunsealers", s.withAuthorization("list", s.handleList))
+	mux.HandleFunc("GET /api/v1/namespaces/{namespace}/vaultunsealers/{name}", s.withAuthorization("get", s.handleGet))
+	mux.HandleFunc("POST /api/v1/namespaces/{namespace}/vaultunsealers/{name}/reconcile", s.withAuthorization("update", s.handleReconcile))
+	mux.HandleFunc("POST /api/v1/namespaces/{namespace}/vaultunsealers/{name}/pods/{pod}/reconcile", s.withAuthorization("update", s.handleReconcilePod))
+	mux.HandleFunc("POST /api/v1/namespaces/{namespace}/vaultunsealers/{name}/pause", s.withAuthorization("update", s.handlePause(true)))
+	mux.HandleFunc("POST /api/v1/namespaces/{namespace}/vaultunsealers/{name}/resume", s.withAuthorization("update", s.handlePause(false)))
+	mux.HandleFunc("POST /api/v1/namespaces/{namespace}/vaultunsealers/{name}/break-glass", s.withAuthorization("update", s.handleBreakGlass))
+	mux.HandleFunc("GET /api/v1/namespaces/{namespace}/vaultunsealers/{name}/diagnostics", s.withAuthorization("get", s.handleDiagnostics))
+	return s.withAuthentication(mux)
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	var list opsv1alpha1.VaultUnsealerList
+	opts := []client.ListOption{}
+	if ns := r.URL.Query().Get("namespace"); ns != "" {
+		opts = append(opts, client.InNamespace(ns))
+	}
+	if err := s.Client.List(r.Context(), &list, opts...); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	summaries := make([]VaultUnsealerSummary, 0, len(list.Items))
+	for i := range list.Items {
+		summaries = append(summaries, Summarize(&list.Items[i]))
+	}
+	writeJSON(w, http.StatusOK, summaries)
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request) {
+	vaultUnsealer, ok := s.getVaultUnsealer(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, Summarize(vaultUnsealer))
+}
+
+func (s *Server) handleReconcile(w http.ResponseWriter, r *http.Request) {
+	vaultUnsealer, ok := s.getVaultUnsealer(w, r)
+	if !ok {
+		return
+	}
+
+	requestedAt := time.Now().UTC().Format(time.RFC3339Nano)
+	if err := s.patchAnnotation(r.Context(), vaultUnsealer, opsv1alpha1.AnnotationReconcileRequestedAt, requestedAt); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	adminlog.Info("Reconcile requested", "namespace", vaultUnsealer.Namespace, "name", vaultUnsealer.Name, "user", userFrom(r.Context()).Username)
+	writeJSON(w, http.StatusAccepted, Summarize(vaultUnsealer))
+}
+
+// handleReconcilePod annotates one of the VaultUnsealer's pods so the next
+// reconcile checks that pod alone.
+func (s *Server) handleReconcilePod(w http.ResponseWriter, r *http.Request) {
+	vaultUnsealer, ok := s.getVaultUnsealer(w, r)
+	if !ok {
+		return
+	}
+
+	pod := &corev1.Pod{}
+	if err := s.Client.Get(r.Context(), types.NamespacedName{Namespace: vaultUnsealer.Namespace, Name: r.PathValue("pod")}, pod); err != nil {
+		if apierrors.IsNotFound(err) {
+			writeError(w, http.StatusNotFound, err)
+		} else {
+			writeError(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	if !controller.MatchesVaultPod(vaultUnsealer, pod.Labels) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("pod %s is not selected by VaultUnsealer %s", pod.Name, vaultUnsealer.Name))
+		return
+	}
+
+	base := pod.DeepCopy()
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[opsv1alpha1.AnnotationReconcileRequestedAt] = time.Now().UTC().Format(time.RFC3339Nano)
+	if err := s.Client.Patch(r.Context(), pod, client.MergeFrom(base)); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	adminlog.Info("Pod reconcile requested", "namespace", vaultUnsealer.Namespace, "name", vaultUnsealer.Name,
+		"pod", pod.Name, "user", userFrom(r.Context()).Username)
+	writeJSON(w, http.StatusAccepted, Summarize(vaultUnsealer))
+}
+
+func (s *Server) handlePause(paused bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vaultUnsealer, ok := s.getVaultUnsealer(w, r)
+		if !ok {
+			return
+		}
+
+		value := ""
+		if paused {
+			value = "true"
+		}
+		if err := s.patchAnnotation(r.Context(), vaultUnsealer, opsv1alpha1.AnnotationPaused, value); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+		adminlog.Info("Pause state changed", "namespace", vaultUnsealer.Namespace, "name", vaultUnsealer.Name,
+			"paused", paused, "user", userFrom(r.Context()).Username)
+		writeJSON(w, http.StatusOK, Summarize(vaultUnsealer))
+	}
+}
+
+func (s *Server) handleBreakGlass(w http.ResponseWriter, r *http.Request) {
+	duration, err := time.ParseDuration(r.URL.Query().Get("duration"))
+	if err != nil || duration <= 0 || duration > opsv1alpha1.MaxBreakGlassDuration {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("duration must be a positive duration of at most %s", opsv1alpha1.MaxBreakGlassDuration))
+		return
+	}
+
+	vaultUnsealer, ok := s.getVaultUnsealer(w, r)
+	if !ok {
+		return
+	}
+
+	until := time.Now().Add(duration).UTC().Format(time.RFC3339)
+	if err := s.patchAnnotation(r.Context(), vaultUnsealer, opsv1alpha1.AnnotationBreakGlassUntil, until); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	adminlog.Info("Break-glass override granted", "namespace", vaultUnsealer.Namespace, "name", vaultUnsealer.Name,
+		"until", until, "user", userFrom(r.Context()).Username)
+	writeJSON(w, http.StatusOK, Summarize(vaultUnsealer))
+}
+
+func (s *Server) handleDiagnostics(w http.ResponseWriter, r *http.Request) {
+	vaultUnsealer, ok := s.getVaultUnsealer(w, r)
+	if !ok {
+		return
+	}
+	if s.Diagnoser == nil {
+		writeError(w, http.StatusNotImplemented, fmt.Errorf("diagnostics are not available"))
+		return
+	}
+
+	diagnostics, err := s.Diagnoser.Diagnose(r.Context(), vaultUnsealer)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, diagnostics)
+}
+
+func (s *Server) getVaultUnsealer(w http.ResponseWriter, r *http.Request) (*opsv1alpha1.VaultUnsealer, bool) {
+	key := types.NamespacedName{Namespace: r.PathValue("namespace"), Name: r.PathValue("name")}
+
+	vaultUnsealer := &opsv1alpha1.VaultUnsealer{}
+	if err := s.Client.Get(r.Context(), key, vaultUnsealer); err != nil {
+		if apierrors.IsNotFound(err) {
+			writeError(w, http.StatusNotFound, err)
+		} else {
+			writeError(w, http.StatusInternalServerError, err)
+		}
+		return nil, false
+	}
+	return vaultUnsealer, true
+}
+
+// patchAnnotation sets an annotation on the VaultUnsealer, removing it when value is empty.
+func (s *Server) patchAnnotation(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer, key, value string) error {
+	base := vaultUnsealer.DeepCopy()
+	if value == "" {
+		delete(vaultUnsealer.Annotations, key)
+	} else {
+		if vaultUnsealer.Annotations == nil {
+			vaultUnsealer.Annotations = map[string]string{}
+		}
+		vaultUnsealer.Annotations[key] = value
+	}
+	return s.Client.Patch(ctx, vaultUnsealer, client.MergeFrom(base))
+}
+
+func (s *Server) tlsConfig() (*tls.Config, *certwatcher.CertWatcher, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	var watcher *certwatcher.CertWatcher
+
+	if s.CertPath != "" {
+		var err error
+		watcher, err = certwatcher.New(
+			filepath.Join(s.CertPath, s.CertName),
+			filepath.Join(s.CertPath, s.CertKey),
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to initialize admin certificate watcher: %w", err)
+		}
+		cfg.GetCertificate = watcher.GetCertificate
+	} else {
+		adminlog.Info("No admin certificate configured, generating a self-signed certificate")
+		cert, err := certs.SelfSignedCertificate("vault-unsealer-admin", []string{"localhost"})
+		if err != nil {
+			return nil, nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if s.ClientCAFile != "" {
+		caData, err := os.ReadFile(s.ClientCAFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read admin client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caData) {
+			return nil, nil, fmt.Errorf("failed to parse admin client CA")
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	for _, opt := range s.TLSOpts {
+		opt(cfg)
+	}
+	return cfg, watcher, nil
+}
+
+// Summarize converts a VaultUnsealer into its admin API representation.
+func Summarize(vaultUnsealer *opsv1alpha1.VaultUnsealer) VaultUnsealerSummary {
+	return VaultUnsealerSummary{
+		Namespace: vaultUnsealer.Namespace,
+		Name:      vaultUnsealer.Name,
+		Paused:    vaultUnsealer.Annotations[opsv1alpha1.AnnotationPaused] == "true",
+		Status:    vaultUnsealer.Status,
+	}
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		adminlog.Error(err, "Failed to encode admin response")
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, map[string]string{"error": err.Error()})
+}
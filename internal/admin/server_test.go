@@ -0,0 +1,231 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/controller"
+)
+
+const (
+	validToken    = "valid-token"
+	readOnlyToken = "read-only-token"
+)
+
+type fakeDiagnoser struct{}
+
+func (fakeDiagnoser) Diagnose(_ context.Context, _ *opsv1alpha1.VaultUnsealer) ([]controller.PodDiagnostic, error) {
+	sealed := true
+	return []controller.PodDiagnostic{{Name: "vault-0", Phase: "Running", Sealed: &sealed}}, nil
+}
+
+func newTestServer(t *testing.T, objs ...client.Object) (*Server, client.Client) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, opsv1alpha1.AddToScheme(scheme))
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				if review, ok := obj.(*authenticationv1.TokenReview); ok {
+					switch review.Spec.Token {
+					case validToken:
+						review.Status.Authenticated = true
+						review.Status.User = authenticationv1.UserInfo{Username: "system:serviceaccount:ops:tooling"}
+					case readOnlyToken:
+						review.Status.Authenticated = true
+						review.Status.User = authenticationv1.UserInfo{Username: "viewer"}
+					}
+					return nil
+				}
+				if review, ok := obj.(*authorizationv1.SubjectAccessReview); ok {
+					verb := review.Spec.ResourceAttributes.Verb
+					review.Status.Allowed = review.Spec.User == "system:serviceaccount:ops:tooling" || verb == "get" || verb == "list"
+					return nil
+				}
+				return c.Create(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	return &Server{Client: k8sClient, Diagnoser: fakeDiagnoser{}}, k8sClient
+}
+
+func testVaultUnsealer() *opsv1alpha1.VaultUnsealer {
+	return &opsv1alpha1.VaultUnsealer{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"},
+		Spec: opsv1alpha1.VaultUnsealerSpec{
+			Vault:              opsv1alpha1.VaultConnectionSpec{URL: "http://vault.vault.svc:8200"},
+			VaultLabelSelector: "app=vault",
+		},
+	}
+}
+
+func doRequest(t *testing.T, handler http.Handler, method, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_RejectsUnauthenticated(t *testing.T) {
+	server, _ := newTestServer(t, testVaultUnsealer())
+	handler := server.Handler()
+
+	rec := doRequest(t, handler, http.MethodGet, "/api/v1/vaultunsealers", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = doRequest(t, handler, http.MethodGet, "/api/v1/vaultunsealers", "bogus")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestServer_RejectsUnauthorized(t *testing.T) {
+	server, k8sClient := newTestServer(t, testVaultUnsealer())
+	handler := server.Handler()
+
+	rec := doRequest(t, handler, http.MethodGet, "/api/v1/namespaces/vault/vaultunsealers/vault", readOnlyToken)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	for _, action := range []string{"reconcile", "pause", "resume", "break-glass?duration=30m"} {
+		rec = doRequest(t, handler, http.MethodPost, "/api/v1/namespaces/vault/vaultunsealers/vault/"+action, readOnlyToken)
+		assert.Equal(t, http.StatusForbidden, rec.Code, action)
+	}
+
+	var vu opsv1alpha1.VaultUnsealer
+	require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "vault", Name: "vault"}, &vu))
+	assert.Empty(t, vu.Annotations)
+}
+
+func TestServer_List(t *testing.T) {
+	server, _ := newTestServer(t, testVaultUnsealer())
+
+	rec := doRequest(t, server.Handler(), http.MethodGet, "/api/v1/vaultunsealers", validToken)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var summaries []VaultUnsealerSummary
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &summaries))
+	require.Len(t, summaries, 1)
+	assert.Equal(t, "vault", summaries[0].Name)
+	assert.False(t, summaries[0].Paused)
+}
+
+func TestServer_PauseAndResume(t *testing.T) {
+	server, k8sClient := newTestServer(t, testVaultUnsealer())
+	handler := server.Handler()
+	key := types.NamespacedName{Namespace: "vault", Name: "vault"}
+
+	rec := doRequest(t, handler, http.MethodPost, "/api/v1/namespaces/vault/vaultunsealers/vault/pause", validToken)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var vu opsv1alpha1.VaultUnsealer
+	require.NoError(t, k8sClient.Get(context.Background(), key, &vu))
+	assert.Equal(t, "true", vu.Annotations[opsv1alpha1.AnnotationPaused])
+
+	rec = doRequest(t, handler, http.MethodPost, "/api/v1/namespaces/vault/vaultunsealers/vault/resume", validToken)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	require.NoError(t, k8sClient.Get(context.Background(), key, &vu))
+	assert.NotContains(t, vu.Annotations, opsv1alpha1.AnnotationPaused)
+}
+
+func TestServer_ReconcileSetsAnnotation(t *testing.T) {
+	server, k8sClient := newTestServer(t, testVaultUnsealer())
+
+	rec := doRequest(t, server.Handler(), http.MethodPost, "/api/v1/namespaces/vault/vaultunsealers/vault/reconcile", validToken)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+
+	var vu opsv1alpha1.VaultUnsealer
+	require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "vault", Name: "vault"}, &vu))
+	assert.NotEmpty(t, vu.Annotations[opsv1alpha1.AnnotationReconcileRequestedAt])
+}
+
+func TestServer_ReconcilePodSetsAnnotation(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "vault-1", Namespace: "vault", Labels: map[string]string{"app": "vault"}}}
+	other := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "exporter", Namespace: "vault", Labels: map[string]string{"app": "exporter"}}}
+	server, k8sClient := newTestServer(t, testVaultUnsealer(), pod, other)
+	handler := server.Handler()
+
+	rec := doRequest(t, handler, http.MethodPost, "/api/v1/namespaces/vault/vaultunsealers/vault/pods/vault-1/reconcile", validToken)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(pod), pod))
+	assert.NotEmpty(t, pod.Annotations[opsv1alpha1.AnnotationReconcileRequestedAt])
+
+	rec = doRequest(t, handler, http.MethodPost, "/api/v1/namespaces/vault/vaultunsealers/vault/pods/exporter/reconcile", validToken)
+	assert.Equal(t, http.StatusBadRequest, rec.Code, "pod not selected by the VaultUnsealer")
+	rec = doRequest(t, handler, http.MethodPost, "/api/v1/namespaces/vault/vaultunsealers/vault/pods/missing/reconcile", validToken)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = doRequest(t, handler, http.MethodPost, "/api/v1/namespaces/vault/vaultunsealers/vault/pods/vault-1/reconcile", readOnlyToken)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestServer_BreakGlass(t *testing.T) {
+	server, k8sClient := newTestServer(t, testVaultUnsealer())
+	handler := server.Handler()
+
+	rec := doRequest(t, handler, http.MethodPost, "/api/v1/namespaces/vault/vaultunsealers/vault/break-glass?duration=30m", validToken)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var vu opsv1alpha1.VaultUnsealer
+	require.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Namespace: "vault", Name: "vault"}, &vu))
+	until, err := time.Parse(time.RFC3339, vu.Annotations[opsv1alpha1.AnnotationBreakGlassUntil])
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(30*time.Minute), until, time.Minute)
+
+	rec = doRequest(t, handler, http.MethodPost, "/api/v1/namespaces/vault/vaultunsealers/vault/break-glass?duration=48h", validToken)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestServer_Diagnostics(t *testing.T) {
+	server, _ := newTestServer(t, testVaultUnsealer())
+	handler := server.Handler()
+
+	rec := doRequest(t, handler, http.MethodGet, "/api/v1/namespaces/vault/vaultunsealers/vault/diagnostics", validToken)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var diagnostics []controller.PodDiagnostic
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &diagnostics))
+	require.Len(t, diagnostics, 1)
+	assert.Equal(t, "vault-0", diagnostics[0].Name)
+
+	rec = doRequest(t, handler, http.MethodGet, "/api/v1/namespaces/vault/vaultunsealers/missing/diagnostics", validToken)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
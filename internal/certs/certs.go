@@ -0,0 +1,223 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package certs generates and rotates the certificates used by the operator's
+// own HTTPS endpoints.
+package certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+)
+
+const (
+	caValidity      = 10 * 365 * 24 * time.Hour
+	servingValidity = 365 * 24 * time.Hour
+)
+
+// Bundle holds a CA and a serving certificate signed by it, all PEM encoded.
+type Bundle struct {
+	CACert      []byte
+	CAKey       []byte
+	ServingCert []byte
+	ServingKey  []byte
+}
+
+// NewBundle generates a fresh CA and a serving certificate for dnsNames.
+func NewBundle(commonName string, dnsNames []string, now time.Time) (*Bundle, error) {
+	caCert, caKey, err := generateCA(commonName, now)
+	if err != nil {
+		return nil, err
+	}
+	bundle := &Bundle{CACert: caCert, CAKey: caKey}
+	if err := bundle.RenewServing(dnsNames, now); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// SelfSignedCertificate returns an in-memory serving certificate signed by a
+// throwaway CA, for endpoints that only need transport encryption.
+func SelfSignedCertificate(commonName string, dnsNames []string) (tls.Certificate, error) {
+	bundle, err := NewBundle(commonName, dnsNames, time.Now())
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.X509KeyPair(bundle.ServingCert, bundle.ServingKey)
+}
+
+// RenewServing replaces the serving certificate while keeping the existing CA.
+func (b *Bundle) RenewServing(dnsNames []string, now time.Time) error {
+	ca, caKey, err := b.parseCA()
+	if err != nil {
+		return err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf("failed to generate serving key: %w", err)
+	}
+	serial, err := newSerial()
+	if err != nil {
+		return err
+	}
+
+	commonName := ""
+	if len(dnsNames) > 0 {
+		commonName = dnsNames[0]
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     dnsNames,
+		NotBefore:    now.Add(-time.Hour),
+		NotAfter:     now.Add(servingValidity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return fmt.Errorf("failed to sign serving certificate: %w", err)
+	}
+	keyPEM, err := encodeKey(key)
+	if err != nil {
+		return err
+	}
+
+	b.ServingCert = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	b.ServingKey = keyPEM
+	return nil
+}
+
+// Validate checks that the CA and serving certificate are well formed, chain
+// correctly, cover dnsNames and do not expire within renewBefore.
+func (b *Bundle) Validate(dnsNames []string, now time.Time, renewBefore time.Duration) error {
+	ca, _, err := b.parseCA()
+	if err != nil {
+		return err
+	}
+	if now.Add(renewBefore).After(ca.NotAfter) {
+		return fmt.Errorf("CA certificate expires at %s", ca.NotAfter)
+	}
+
+	if _, err := tls.X509KeyPair(b.ServingCert, b.ServingKey); err != nil {
+		return fmt.Errorf("invalid serving key pair: %w", err)
+	}
+	serving, err := parseCertificate(b.ServingCert)
+	if err != nil {
+		return err
+	}
+	if now.Add(renewBefore).After(serving.NotAfter) {
+		return fmt.Errorf("serving certificate expires at %s", serving.NotAfter)
+	}
+	for _, name := range dnsNames {
+		if !slices.Contains(serving.DNSNames, name) {
+			return fmt.Errorf("serving certificate does not cover %s", name)
+		}
+	}
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+	if _, err := serving.Verify(x509.VerifyOptions{
+		Roots:       pool,
+		CurrentTime: now,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}); err != nil {
+		return fmt.Errorf("serving certificate does not chain to CA: %w", err)
+	}
+	return nil
+}
+
+func (b *Bundle) parseCA() (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	ca, err := parseCertificate(b.CACert)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid CA certificate: %w", err)
+	}
+	block, _ := pem.Decode(b.CAKey)
+	if block == nil {
+		return nil, nil, fmt.Errorf("invalid CA key: no PEM data")
+	}
+	key, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid CA key: %w", err)
+	}
+	return ca, key, nil
+}
+
+func generateCA(commonName string, now time.Time) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate CA key: %w", err)
+	}
+	serial, err := newSerial()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create CA certificate: %w", err)
+	}
+	keyPEM, err := encodeKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), keyPEM, nil
+}
+
+func parseCertificate(data []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("no PEM certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func encodeKey(key *ecdsa.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal private key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), nil
+}
+
+func newSerial() (*big.Int, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+	return serial, nil
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var testDNSNames = []string{"svc", "svc.ns.svc"}
+
+func TestNewBundle_Validates(t *testing.T) {
+	now := time.Now()
+	bundle, err := NewBundle("test-ca", testDNSNames, now)
+	require.NoError(t, err)
+
+	assert.NoError(t, bundle.Validate(testDNSNames, now, 24*time.Hour))
+	assert.Error(t, bundle.Validate([]string{"other"}, now, 24*time.Hour), "uncovered DNS name")
+	assert.Error(t, bundle.Validate(testDNSNames, now, 2*servingValidity), "expiring serving certificate")
+}
+
+func TestRenewServing_KeepsCA(t *testing.T) {
+	now := time.Now()
+	bundle, err := NewBundle("test-ca", testDNSNames, now)
+	require.NoError(t, err)
+	caCert, servingCert := bundle.CACert, bundle.ServingCert
+
+	require.NoError(t, bundle.RenewServing([]string{"new"}, now))
+	assert.Equal(t, caCert, bundle.CACert)
+	assert.NotEqual(t, servingCert, bundle.ServingCert)
+	assert.NoError(t, bundle.Validate([]string{"new"}, now, time.Hour))
+}
+
+func TestSelfSignedCertificate(t *testing.T) {
+	cert, err := SelfSignedCertificate("test", []string{"localhost"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, cert.Certificate)
+}
+
+func newTestManager(t *testing.T) (*WebhookCertManager, *admissionregistrationv1.ValidatingWebhookConfiguration) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	webhookConfig := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "webhooks"},
+		Webhooks:   []admissionregistrationv1.ValidatingWebhook{{Name: "a.example.com"}, {Name: "b.example.com"}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(webhookConfig).Build()
+
+	return &WebhookCertManager{
+		Client:            c,
+		Namespace:         "ns",
+		SecretName:        "webhook-cert",
+		ServiceName:       "svc",
+		WebhookConfigName: "webhooks",
+		CertDir:           t.TempDir(),
+		CertName:          "tls.crt",
+		KeyName:           "tls.key",
+		RenewBefore:       24 * time.Hour,
+	}, webhookConfig
+}
+
+func TestWebhookCertManager_Ensure(t *testing.T) {
+	ctx := context.Background()
+	m, _ := newTestManager(t)
+
+	require.NoError(t, m.Ensure(ctx))
+
+	secret := &corev1.Secret{}
+	require.NoError(t, m.Client.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "webhook-cert"}, secret))
+	bundle := dataToBundle(secret.Data)
+	require.NoError(t, bundle.Validate(m.DNSNames(), time.Now(), m.RenewBefore))
+
+	onDisk, err := os.ReadFile(filepath.Join(m.CertDir, "tls.crt"))
+	require.NoError(t, err)
+	assert.Equal(t, bundle.ServingCert, onDisk)
+
+	webhookConfig := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	require.NoError(t, m.Client.Get(ctx, types.NamespacedName{Name: "webhooks"}, webhookConfig))
+	for _, webhook := range webhookConfig.Webhooks {
+		assert.Equal(t, bundle.CACert, webhook.ClientConfig.CABundle)
+	}
+
+	// A second run with a valid Secret must not rotate anything.
+	require.NoError(t, m.Ensure(ctx))
+	again := &corev1.Secret{}
+	require.NoError(t, m.Client.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "webhook-cert"}, again))
+	assert.Equal(t, secret.Data, again.Data)
+}
+
+func TestWebhookCertManager_RotatesExpiringServingCert(t *testing.T) {
+	ctx := context.Background()
+	m, _ := newTestManager(t)
+	require.NoError(t, m.Ensure(ctx))
+
+	key := types.NamespacedName{Namespace: "ns", Name: "webhook-cert"}
+	before := &corev1.Secret{}
+	require.NoError(t, m.Client.Get(ctx, key, before))
+
+	// Renewing within the serving validity window forces a serving rotation
+	// while the CA stays valid.
+	m.RenewBefore = servingValidity + time.Hour
+	require.NoError(t, m.Ensure(ctx))
+
+	after := &corev1.Secret{}
+	require.NoError(t, m.Client.Get(ctx, key, after))
+	assert.Equal(t, before.Data[SecretCACertKey], after.Data[SecretCACertKey])
+	assert.NotEqual(t, before.Data[corev1.TLSCertKey], after.Data[corev1.TLSCertKey])
+}
+
+func TestWebhookCertManager_RegeneratesInvalidCA(t *testing.T) {
+	ctx := context.Background()
+	m, _ := newTestManager(t)
+	require.NoError(t, m.Client.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "webhook-cert"},
+		Data:       map[string][]byte{SecretCACertKey: []byte("garbage")},
+	}))
+
+	require.NoError(t, m.Ensure(ctx))
+
+	secret := &corev1.Secret{}
+	require.NoError(t, m.Client.Get(ctx, types.NamespacedName{Namespace: "ns", Name: "webhook-cert"}, secret))
+	assert.NoError(t, dataToBundle(secret.Data).Validate(m.DNSNames(), time.Now(), m.RenewBefore))
+}
+
+func TestWebhookCertManager_MissingWebhookConfig(t *testing.T) {
+	m, _ := newTestManager(t)
+	m.WebhookConfigName = "missing"
+	assert.NoError(t, m.Ensure(context.Background()))
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=get;list;watch;update;patch
+
+// Keys used in the webhook certificate Secret.
+const (
+	SecretCACertKey = "ca.crt"
+	SecretCAKeyKey  = "ca.key"
+)
+
+var certlog = logf.Log.WithName("webhook-certs")
+
+// WebhookCertManager provisions the admission webhook serving certificate from a
+// Secret shared by all replicas, writes it to the webhook server's certificate
+// directory and keeps the ValidatingWebhookConfiguration caBundle in sync.
+type WebhookCertManager struct {
+	Client client.Client
+
+	Namespace         string
+	SecretName        string
+	ServiceName       string
+	WebhookConfigName string
+
+	CertDir  string
+	CertName string
+	KeyName  string
+
+	// RenewBefore is how long before expiry certificates are rotated.
+	RenewBefore time.Duration
+	// CheckInterval is how often the certificates are re-validated.
+	CheckInterval time.Duration
+}
+
+// DNSNames returns the service DNS names the serving certificate must cover.
+func (m *WebhookCertManager) DNSNames() []string {
+	return []string{
+		m.ServiceName,
+		fmt.Sprintf("%s.%s", m.ServiceName, m.Namespace),
+		fmt.Sprintf("%s.%s.svc", m.ServiceName, m.Namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", m.ServiceName, m.Namespace),
+	}
+}
+
+// NeedLeaderElection keeps rotation running on every replica, since each one
+// serves the webhook from its own local certificate directory.
+func (m *WebhookCertManager) NeedLeaderElection() bool {
+	return false
+}
+
+// Start periodically re-validates and rotates the certificates.
+func (m *WebhookCertManager) Start(ctx context.Context) error {
+	interval := m.CheckInterval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := m.Ensure(ctx); err != nil {
+				certlog.Error(err, "Failed to ensure webhook certificates")
+			}
+		}
+	}
+}
+
+// Ensure makes sure a valid certificate exists in the Secret, on disk and in
+// the webhook configuration caBundle.
+func (m *WebhookCertManager) Ensure(ctx context.Context) error {
+	bundle, err := m.ensureSecret(ctx)
+	if err != nil {
+		return err
+	}
+	if err := m.writeFiles(bundle); err != nil {
+		return err
+	}
+	return m.injectCABundle(ctx, bundle.CACert)
+}
+
+func (m *WebhookCertManager) ensureSecret(ctx context.Context) (*Bundle, error) {
+	now := time.Now()
+	dnsNames := m.DNSNames()
+	key := types.NamespacedName{Namespace: m.Namespace, Name: m.SecretName}
+
+	secret := &corev1.Secret{}
+	err := m.Client.Get(ctx, key, secret)
+	if apierrors.IsNotFound(err) {
+		bundle, err := NewBundle("vault-unsealer-webhook-ca", dnsNames, now)
+		if err != nil {
+			return nil, err
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: m.Namespace, Name: m.SecretName},
+			Type:       corev1.SecretTypeTLS,
+			Data:       bundleToData(bundle),
+		}
+		if err := m.Client.Create(ctx, secret); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				// Another replica created it first; use theirs.
+				return m.ensureSecret(ctx)
+			}
+			return nil, fmt.Errorf("failed to create webhook certificate secret: %w", err)
+		}
+		certlog.Info("Created webhook certificate secret", "secret", key.String())
+		return bundle, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook certificate secret: %w", err)
+	}
+
+	bundle := dataToBundle(secret.Data)
+	validationErr := bundle.Validate(dnsNames, now, m.RenewBefore)
+	if validationErr == nil {
+		return bundle, nil
+	}
+
+	certlog.Info("Rotating webhook certificate", "secret", key.String(), "reason", validationErr.Error())
+	if _, _, caErr := bundle.parseCA(); caErr != nil || caExpiring(bundle, now, m.RenewBefore) {
+		bundle, err = NewBundle("vault-unsealer-webhook-ca", dnsNames, now)
+	} else {
+		err = bundle.RenewServing(dnsNames, now)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	secret.Data = bundleToData(bundle)
+	if err := m.Client.Update(ctx, secret); err != nil {
+		return nil, fmt.Errorf("failed to update webhook certificate secret: %w", err)
+	}
+	return bundle, nil
+}
+
+func (m *WebhookCertManager) writeFiles(bundle *Bundle) error {
+	if err := os.MkdirAll(m.CertDir, 0o700); err != nil {
+		return fmt.Errorf("failed to create webhook certificate directory: %w", err)
+	}
+	files := map[string][]byte{
+		m.CertName: bundle.ServingCert,
+		m.KeyName:  bundle.ServingKey,
+	}
+	for name, data := range files {
+		path := filepath.Join(m.CertDir, name)
+		if existing, err := os.ReadFile(path); err == nil && bytes.Equal(existing, data) {
+			continue
+		}
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func (m *WebhookCertManager) injectCABundle(ctx context.Context, caBundle []byte) error {
+	if m.WebhookConfigName == "" {
+		return nil
+	}
+
+	webhookConfig := &admissionregistrationv1.ValidatingWebhookConfiguration{}
+	if err := m.Client.Get(ctx, types.NamespacedName{Name: m.WebhookConfigName}, webhookConfig); err != nil {
+		if apierrors.IsNotFound(err) {
+			certlog.Info("ValidatingWebhookConfiguration not found, skipping caBundle injection", "name", m.WebhookConfigName)
+			return nil
+		}
+		return fmt.Errorf("failed to get ValidatingWebhookConfiguration: %w", err)
+	}
+
+	changed := false
+	for i := range webhookConfig.Webhooks {
+		if !bytes.Equal(webhookConfig.Webhooks[i].ClientConfig.CABundle, caBundle) {
+			webhookConfig.Webhooks[i].ClientConfig.CABundle = caBundle
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	if err := m.Client.Update(ctx, webhookConfig); err != nil {
+		return fmt.Errorf("failed to update ValidatingWebhookConfiguration caBundle: %w", err)
+	}
+	certlog.Info("Injected caBundle into ValidatingWebhookConfiguration", "name", m.WebhookConfigName)
+	return nil
+}
+
+func caExpiring(bundle *Bundle, now time.Time, renewBefore time.Duration) bool {
+	ca, err := parseCertificate(bundle.CACert)
+	if err != nil {
+		return true
+	}
+	return now.Add(renewBefore).After(ca.NotAfter)
+}
+
+func bundleToData(bundle *Bundle) map[string][]byte {
+	return map[string][]byte{
+		SecretCACertKey:         bundle.CACert,
+		SecretCAKeyKey:          bundle.CAKey,
+		corev1.TLSCertKey:       bundle.ServingCert,
+		corev1.TLSPrivateKeyKey: bundle.ServingKey,
+	}
+}
+
+func dataToBundle(data map[string][]byte) *Bundle {
+	return &Bundle{
+		CACert:      data[SecretCACertKey],
+		CAKey:       data[SecretCAKeyKey],
+		ServingCert: data[corev1.TLSCertKey],
+		ServingKey:  data[corev1.TLSPrivateKeyKey],
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cli implements the vault-unsealer subcommands that run alongside the manager binary.
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/panteparak/vault-unsealer/pkg/clientset"
+)
+
+// Command runs a subcommand with its arguments, writing output to out.
+type Command func(args []string, out io.Writer) error
+
+var commands = map[string]Command{
+	"escrow":   RunEscrow,
+	"install":  RunInstall,
+	"status":   RunStatus,
+	"validate": RunValidate,
+}
+
+// Lookup returns the subcommand registered under name.
+func Lookup(name string) (Command, bool) {
+	cmd, ok := commands[name]
+	return cmd, ok
+}
+
+// newClient builds a Kubernetes client for the given kubeconfig path, falling
+// back to the standard in-cluster and KUBECONFIG discovery when empty.
+func newClient(kubeconfig string) (client.Client, error) {
+	var cfg *rest.Config
+	var err error
+	if kubeconfig != "" {
+		cfg, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	} else {
+		cfg, err = ctrl.GetConfig()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	return client.New(cfg, client.Options{Scheme: clientset.NewScheme()})
+}
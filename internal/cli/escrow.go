@@ -0,0 +1,189 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgparmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+	"k8s.io/apimachinery/pkg/types"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/secrets"
+)
+
+type escrowOptions struct {
+	namespace     string
+	kubeconfig    string
+	ageRecipients stringSlice
+	pgpKeyFile    string
+	timeout       time.Duration
+	vaultUnsealer string
+}
+
+// RunEscrow encrypts the unseal keys referenced by a VaultUnsealer to an age
+// or PGP recipient and prints the armored ciphertext. The keys are never
+// written out in plaintext; decrypting the output yields a JSON array in the
+// keys.json format the unseal key Secrets accept.
+//
+//	vault-unsealer escrow name [-n namespace] (--age-recipient age1... | --pgp-key key.asc)
+func RunEscrow(args []string, out io.Writer) error {
+	opts, err := parseEscrowFlags(args)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+	defer cancel()
+
+	k8sClient, err := newClient(opts.kubeconfig)
+	if err != nil {
+		return err
+	}
+	var vaultUnsealer opsv1alpha1.VaultUnsealer
+	key := types.NamespacedName{Namespace: opts.namespace, Name: opts.vaultUnsealer}
+	if err := k8sClient.Get(ctx, key, &vaultUnsealer); err != nil {
+		return fmt.Errorf("failed to get VaultUnsealer %s: %w", key, err)
+	}
+
+	// Escrow every referenced share rather than the keyThreshold submitted to
+	// Vault, and read the Secrets with the caller's own credentials.
+	vaultUnsealer.Spec.KeyThreshold = 0
+	vaultUnsealer.Spec.SecretsServiceAccountName = ""
+	loader := secrets.NewLoader(k8sClient).WithHCPClient(secrets.NewHCPClient())
+	keys, err := loader.LoadUnsealKeysFor(ctx, &vaultUnsealer)
+	if err != nil {
+		return err
+	}
+	return writeEscrow(out, keys, opts)
+}
+
+func parseEscrowFlags(args []string) (*escrowOptions, error) {
+	opts := &escrowOptions{}
+	fs := flag.NewFlagSet("escrow", flag.ContinueOnError)
+	fs.StringVar(&opts.namespace, "namespace", "default", "Namespace of the VaultUnsealer.")
+	fs.StringVar(&opts.namespace, "n", "default", "Shorthand for --namespace.")
+	fs.StringVar(&opts.kubeconfig, "kubeconfig", "", "Path to a kubeconfig file.")
+	fs.Var(&opts.ageRecipients, "age-recipient", "age recipient (age1...) to encrypt to (repeatable).")
+	fs.StringVar(&opts.pgpKeyFile, "pgp-key", "", "File holding the PGP public key(s) to encrypt to, armored or binary.")
+	fs.DurationVar(&opts.timeout, "timeout", 30*time.Second, "Timeout for reading the keys.")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	// Allow flags after the positional name, e.g. `escrow vault -n vault`.
+	if fs.NArg() > 0 {
+		opts.vaultUnsealer = fs.Arg(0)
+		if err := fs.Parse(fs.Args()[1:]); err != nil {
+			return nil, err
+		}
+		if fs.NArg() > 0 {
+			return nil, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+		}
+	}
+
+	if opts.vaultUnsealer == "" {
+		return nil, fmt.Errorf("the name of a VaultUnsealer is required")
+	}
+	if (len(opts.ageRecipients) == 0) == (opts.pgpKeyFile == "") {
+		return nil, fmt.Errorf("exactly one of --age-recipient or --pgp-key is required")
+	}
+	return opts, nil
+}
+
+// writeEscrow encrypts keys as a JSON array and writes the armored result.
+func writeEscrow(out io.Writer, keys []secrets.SecretString, opts *escrowOptions) error {
+	values := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, key.Reveal())
+	}
+	plaintext, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("failed to encode unseal keys: %w", err)
+	}
+	defer clear(plaintext)
+
+	if len(opts.ageRecipients) > 0 {
+		return encryptAge(out, plaintext, opts.ageRecipients)
+	}
+	return encryptPGP(out, plaintext, opts.pgpKeyFile)
+}
+
+func encryptAge(out io.Writer, plaintext []byte, recipientArgs []string) error {
+	recipients, err := age.ParseRecipients(strings.NewReader(strings.Join(recipientArgs, "\n")))
+	if err != nil {
+		return fmt.Errorf("invalid age recipient: %w", err)
+	}
+
+	armored := armor.NewWriter(out)
+	w, err := age.Encrypt(armored, recipients...)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt unseal keys: %w", err)
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return fmt.Errorf("failed to encrypt unseal keys: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to encrypt unseal keys: %w", err)
+	}
+	return armored.Close()
+}
+
+func encryptPGP(out io.Writer, plaintext []byte, keyFile string) error {
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to read PGP key: %w", err)
+	}
+	entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+	if err != nil {
+		entities, err = openpgp.ReadKeyRing(bytes.NewReader(data))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse PGP key %s: %w", keyFile, err)
+	}
+
+	armored, err := pgparmor.Encode(out, "PGP MESSAGE", nil)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt unseal keys: %w", err)
+	}
+	w, err := openpgp.Encrypt(armored, entities, nil, &openpgp.FileHints{IsBinary: true}, nil)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt unseal keys: %w", err)
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return fmt.Errorf("failed to encrypt unseal keys: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to encrypt unseal keys: %w", err)
+	}
+	if err := armored.Close(); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out)
+	return err
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgparmor "github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/panteparak/vault-unsealer/internal/secrets"
+)
+
+var escrowKeys = []secrets.SecretString{secrets.NewSecretString("key-1"), secrets.NewSecretString("key-2")}
+
+func TestParseEscrowFlags(t *testing.T) {
+	opts, err := parseEscrowFlags([]string{"main", "-n", "vault", "--age-recipient", "age1a", "--age-recipient", "age1b"})
+	require.NoError(t, err)
+	assert.Equal(t, "main", opts.vaultUnsealer)
+	assert.Equal(t, "vault", opts.namespace)
+	assert.Equal(t, stringSlice{"age1a", "age1b"}, opts.ageRecipients)
+
+	_, err = parseEscrowFlags([]string{"--age-recipient", "age1a"})
+	assert.ErrorContains(t, err, "name of a VaultUnsealer")
+	_, err = parseEscrowFlags([]string{"main"})
+	assert.ErrorContains(t, err, "exactly one of")
+	_, err = parseEscrowFlags([]string{"main", "--age-recipient", "age1a", "--pgp-key", "key.asc"})
+	assert.ErrorContains(t, err, "exactly one of")
+}
+
+func TestWriteEscrow_Age(t *testing.T) {
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, writeEscrow(&out, escrowKeys, &escrowOptions{ageRecipients: stringSlice{identity.Recipient().String()}}))
+	assert.True(t, strings.HasPrefix(out.String(), armor.Header))
+	assert.NotContains(t, out.String(), "key-1")
+
+	plaintext, err := age.Decrypt(armor.NewReader(&out), identity)
+	require.NoError(t, err)
+	assertEscrowedKeys(t, plaintext)
+
+	err = writeEscrow(&out, escrowKeys, &escrowOptions{ageRecipients: stringSlice{"not-a-recipient"}})
+	assert.ErrorContains(t, err, "invalid age recipient")
+}
+
+func TestWriteEscrow_PGP(t *testing.T) {
+	entity, err := openpgp.NewEntity("escrow", "", "escrow@example.com", nil)
+	require.NoError(t, err)
+	var public bytes.Buffer
+	armored, err := pgparmor.Encode(&public, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(armored))
+	require.NoError(t, armored.Close())
+	keyFile := filepath.Join(t.TempDir(), "escrow.asc")
+	require.NoError(t, os.WriteFile(keyFile, public.Bytes(), 0o600))
+
+	var out bytes.Buffer
+	require.NoError(t, writeEscrow(&out, escrowKeys, &escrowOptions{pgpKeyFile: keyFile}))
+	assert.NotContains(t, out.String(), "key-1")
+
+	block, err := pgparmor.Decode(&out)
+	require.NoError(t, err)
+	message, err := openpgp.ReadMessage(block.Body, openpgp.EntityList{entity}, nil, nil)
+	require.NoError(t, err)
+	assertEscrowedKeys(t, message.UnverifiedBody)
+}
+
+func assertEscrowedKeys(t *testing.T, plaintext io.Reader) {
+	t.Helper()
+	var keys []string
+	require.NoError(t, json.NewDecoder(plaintext).Decode(&keys))
+	assert.Equal(t, []string{"key-1", "key-2"}, keys)
+}
@@ -0,0 +1,332 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/panteparak/vault-unsealer/config"
+)
+
+// installNamePrefix is the namePrefix of config/default, prepended to the
+// names of everything but the CRDs and the Namespace.
+const installNamePrefix = "vault-unsealer-"
+
+// installKindOrder is the order objects are applied in, so the Namespace,
+// CRDs and RBAC exist before the Deployment that needs them starts.
+var installKindOrder = []string{
+	"Namespace", "CustomResourceDefinition", "ServiceAccount", "ClusterRole", "ClusterRoleBinding",
+	"Role", "RoleBinding", "Service", "Deployment", "ValidatingWebhookConfiguration",
+}
+
+// installClusterScoped are the kinds left without a namespace.
+var installClusterScoped = []string{
+	"Namespace", "CustomResourceDefinition", "ClusterRole", "ClusterRoleBinding", "ValidatingWebhookConfiguration",
+}
+
+type installOptions struct {
+	namespace  string
+	kubeconfig string
+	image      string
+	webhook    bool
+	dryRun     bool
+	timeout    time.Duration
+}
+
+// RunInstall applies the CRD, RBAC, manager Deployment and, optionally, the
+// validating webhook from the manifests embedded in the binary, as
+// `make build-installer` would render them.
+//
+//	vault-unsealer install --image registry.example.com/vault-unsealer:v1.2.3 [--webhook] [--dry-run]
+func RunInstall(args []string, out io.Writer) error {
+	opts := &installOptions{}
+	fs := flag.NewFlagSet("install", flag.ContinueOnError)
+	fs.StringVar(&opts.namespace, "namespace", "vault-unsealer-system", "Namespace the operator is installed in.")
+	fs.StringVar(&opts.namespace, "n", "vault-unsealer-system", "Shorthand for --namespace.")
+	fs.StringVar(&opts.kubeconfig, "kubeconfig", "", "Path to a kubeconfig file.")
+	fs.StringVar(&opts.image, "image", "", "Operator image, such as registry.example.com/vault-unsealer:v1.2.3.")
+	fs.BoolVar(&opts.webhook, "webhook", false,
+		"Install the validating admission webhook with self-managed certificates. Without it the manager runs with --enable-webhooks=false.")
+	fs.BoolVar(&opts.dryRun, "dry-run", false, "Print the manifests instead of applying them.")
+	fs.DurationVar(&opts.timeout, "timeout", 2*time.Minute, "Timeout for applying the manifests.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	if opts.image == "" {
+		return fmt.Errorf("the operator image must be given with --image")
+	}
+
+	objs, err := installManifests(config.Manifests, opts)
+	if err != nil {
+		return err
+	}
+	if opts.dryRun {
+		return printManifests(out, objs)
+	}
+
+	k8sClient, err := newClient(opts.kubeconfig)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+	defer cancel()
+	return applyManifests(ctx, k8sClient, objs, out)
+}
+
+// installManifests reads the resources listed in the embedded kustomizations
+// and applies the namespace, name prefix and Deployment settings of
+// config/default to them.
+func installManifests(manifests fs.FS, opts *installOptions) ([]*unstructured.Unstructured, error) {
+	dirs := []string{"crd", "rbac", "manager"}
+	if opts.webhook {
+		dirs = append(dirs, "webhook")
+	}
+	var files []string
+	for _, dir := range dirs {
+		resources, err := kustomizationResources(manifests, dir)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, resources...)
+	}
+	files = append(files, "default/metrics_service.yaml")
+
+	var objs []*unstructured.Unstructured
+	for _, file := range files {
+		docs, err := readManifests(manifests, file)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range docs {
+			if !opts.webhook && slices.Contains([]string{"webhook-cert-role", "webhook-cert-rolebinding"}, obj.GetName()) {
+				continue
+			}
+			if err := customizeManifest(obj, opts); err != nil {
+				return nil, fmt.Errorf("%s: %w", file, err)
+			}
+			objs = append(objs, obj)
+		}
+	}
+	slices.SortStableFunc(objs, func(a, b *unstructured.Unstructured) int {
+		return slices.Index(installKindOrder, a.GetKind()) - slices.Index(installKindOrder, b.GetKind())
+	})
+	return objs, nil
+}
+
+// kustomizationResources lists the files dir/kustomization.yaml includes.
+func kustomizationResources(manifests fs.FS, dir string) ([]string, error) {
+	data, err := fs.ReadFile(manifests, path.Join(dir, "kustomization.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s kustomization: %w", dir, err)
+	}
+	var kustomization struct {
+		Resources []string `json:"resources"`
+	}
+	if err := yaml.Unmarshal(data, &kustomization); err != nil {
+		return nil, fmt.Errorf("failed to parse %s kustomization: %w", dir, err)
+	}
+	files := make([]string, 0, len(kustomization.Resources))
+	for _, resource := range kustomization.Resources {
+		files = append(files, path.Join(dir, resource))
+	}
+	return files, nil
+}
+
+func readManifests(manifests fs.FS, file string) ([]*unstructured.Unstructured, error) {
+	data, err := fs.ReadFile(manifests, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	var objs []*unstructured.Unstructured
+	docs := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for {
+		doc, err := docs.Read()
+		if errors.Is(err, io.EOF) {
+			return objs, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(doc, &obj.Object); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		if len(obj.Object) > 0 {
+			objs = append(objs, obj)
+		}
+	}
+}
+
+// customizeManifest renames obj and the objects it refers to as the
+// config/default namespace and namePrefix would, and points the manager
+// Deployment at the operator image.
+func customizeManifest(obj *unstructured.Unstructured, opts *installOptions) error {
+	switch obj.GetKind() {
+	case "CustomResourceDefinition":
+		return nil
+	case "Namespace":
+		obj.SetName(opts.namespace)
+		return nil
+	}
+	obj.SetName(installNamePrefix + obj.GetName())
+	if !slices.Contains(installClusterScoped, obj.GetKind()) {
+		obj.SetNamespace(opts.namespace)
+	}
+
+	switch obj.GetKind() {
+	case "ClusterRoleBinding", "RoleBinding":
+		roleRef, _, _ := unstructured.NestedString(obj.Object, "roleRef", "name")
+		if err := unstructured.SetNestedField(obj.Object, installNamePrefix+roleRef, "roleRef", "name"); err != nil {
+			return err
+		}
+		subjects, _, _ := unstructured.NestedSlice(obj.Object, "subjects")
+		for _, subject := range subjects {
+			if subject, ok := subject.(map[string]any); ok && subject["kind"] == "ServiceAccount" {
+				subject["name"] = installNamePrefix + fmt.Sprint(subject["name"])
+				subject["namespace"] = opts.namespace
+			}
+		}
+		return unstructured.SetNestedSlice(obj.Object, subjects, "subjects")
+	case "ValidatingWebhookConfiguration":
+		webhooks, _, _ := unstructured.NestedSlice(obj.Object, "webhooks")
+		for _, webhook := range webhooks {
+			webhook, ok := webhook.(map[string]any)
+			if !ok {
+				continue
+			}
+			if service, ok, _ := unstructured.NestedMap(webhook, "clientConfig", "service"); ok {
+				service["name"] = installNamePrefix + fmt.Sprint(service["name"])
+				service["namespace"] = opts.namespace
+				if err := unstructured.SetNestedMap(webhook, service, "clientConfig", "service"); err != nil {
+					return err
+				}
+			}
+		}
+		return unstructured.SetNestedSlice(obj.Object, webhooks, "webhooks")
+	case "Deployment":
+		return customizeDeployment(obj, opts)
+	}
+	return nil
+}
+
+func customizeDeployment(obj *unstructured.Unstructured, opts *installOptions) error {
+	serviceAccount, _, _ := unstructured.NestedString(obj.Object, "spec", "template", "spec", "serviceAccountName")
+	if err := unstructured.SetNestedField(obj.Object, installNamePrefix+serviceAccount, "spec", "template", "spec", "serviceAccountName"); err != nil {
+		return err
+	}
+	containers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+	for _, container := range containers {
+		container, ok := container.(map[string]any)
+		if !ok || container["name"] != "manager" {
+			continue
+		}
+		container["image"] = opts.image
+		args, _, _ := unstructured.NestedStringSlice(container, "args")
+		args = append([]string{"--metrics-bind-address=:8443"}, args...)
+		if opts.webhook {
+			args = append(args, "--self-managed-webhook-certs")
+			container["ports"] = []any{map[string]any{"containerPort": int64(9443), "name": "webhook-server", "protocol": "TCP"}}
+		} else {
+			args = append(args, "--enable-webhooks=false")
+		}
+		if err := unstructured.SetNestedStringSlice(container, args, "args"); err != nil {
+			return err
+		}
+	}
+	return unstructured.SetNestedSlice(obj.Object, containers, "spec", "template", "spec", "containers")
+}
+
+func printManifests(out io.Writer, objs []*unstructured.Unstructured) error {
+	for _, obj := range objs {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return fmt.Errorf("failed to render %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		_, _ = fmt.Fprintf(out, "---\n%s", data)
+	}
+	return nil
+}
+
+// applyManifests creates each object, or replaces it when it already exists,
+// so running install again upgrades an earlier installation.
+func applyManifests(ctx context.Context, k8sClient client.Client, objs []*unstructured.Unstructured, out io.Writer) error {
+	for _, obj := range objs {
+		ref := fmt.Sprintf("%s/%s", obj.GetKind(), obj.GetName())
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(obj.GroupVersionKind())
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+		switch {
+		case apierrors.IsNotFound(err):
+			if err := k8sClient.Create(ctx, obj); err != nil {
+				return fmt.Errorf("failed to create %s: %w", ref, err)
+			}
+			_, _ = fmt.Fprintf(out, "%s created\n", ref)
+		case err != nil:
+			return fmt.Errorf("failed to get %s: %w", ref, err)
+		default:
+			obj.SetResourceVersion(existing.GetResourceVersion())
+			if obj.GetKind() == "ValidatingWebhookConfiguration" {
+				keepCABundles(obj, existing)
+			}
+			if err := k8sClient.Update(ctx, obj); err != nil {
+				return fmt.Errorf("failed to update %s: %w", ref, err)
+			}
+			_, _ = fmt.Fprintf(out, "%s configured\n", ref)
+		}
+	}
+	return nil
+}
+
+// keepCABundles copies the caBundle the manager injected into each existing
+// webhook, so reinstalling does not break admission until it injects again.
+func keepCABundles(obj, existing *unstructured.Unstructured) {
+	caBundles := map[string]any{}
+	existingWebhooks, _, _ := unstructured.NestedSlice(existing.Object, "webhooks")
+	for _, webhook := range existingWebhooks {
+		if webhook, ok := webhook.(map[string]any); ok {
+			if caBundle, ok, _ := unstructured.NestedFieldNoCopy(webhook, "clientConfig", "caBundle"); ok {
+				caBundles[fmt.Sprint(webhook["name"])] = caBundle
+			}
+		}
+	}
+	webhooks, _, _ := unstructured.NestedSlice(obj.Object, "webhooks")
+	for _, webhook := range webhooks {
+		if webhook, ok := webhook.(map[string]any); ok {
+			if caBundle, ok := caBundles[fmt.Sprint(webhook["name"])]; ok {
+				_ = unstructured.SetNestedField(webhook, caBundle, "clientConfig", "caBundle")
+			}
+		}
+	}
+	_ = unstructured.SetNestedSlice(obj.Object, webhooks, "webhooks")
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/panteparak/vault-unsealer/config"
+	"github.com/panteparak/vault-unsealer/pkg/clientset"
+)
+
+func findManifest(objs []*unstructured.Unstructured, kind, name string) *unstructured.Unstructured {
+	for _, obj := range objs {
+		if obj.GetKind() == kind && obj.GetName() == name {
+			return obj
+		}
+	}
+	return nil
+}
+
+func managerArgs(t *testing.T, objs []*unstructured.Unstructured) []string {
+	deployment := findManifest(objs, "Deployment", "vault-unsealer-controller-manager")
+	require.NotNil(t, deployment)
+	containers, _, _ := unstructured.NestedSlice(deployment.Object, "spec", "template", "spec", "containers")
+	require.Len(t, containers, 1)
+	assert.Equal(t, "example.com/vault-unsealer:v1", containers[0].(map[string]any)["image"])
+	args, _, _ := unstructured.NestedStringSlice(containers[0].(map[string]any), "args")
+	return args
+}
+
+func TestInstallManifests(t *testing.T) {
+	objs, err := installManifests(config.Manifests, &installOptions{namespace: "ops", image: "example.com/vault-unsealer:v1"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Namespace", objs[0].GetKind(), "the Namespace is applied first")
+	assert.NotNil(t, findManifest(objs, "Namespace", "ops"))
+	assert.NotNil(t, findManifest(objs, "CustomResourceDefinition", "vaultunsealers.ops.autounseal.vault.io"))
+	assert.Nil(t, findManifest(objs, "ValidatingWebhookConfiguration", "vault-unsealer-validating-webhook-configuration"))
+	assert.Nil(t, findManifest(objs, "Role", "vault-unsealer-webhook-cert-role"))
+	assert.Contains(t, managerArgs(t, objs), "--enable-webhooks=false")
+
+	binding := findManifest(objs, "ClusterRoleBinding", "vault-unsealer-manager-rolebinding")
+	require.NotNil(t, binding)
+	roleRef, _, _ := unstructured.NestedString(binding.Object, "roleRef", "name")
+	assert.Equal(t, "vault-unsealer-manager-role", roleRef)
+	subjects, _, _ := unstructured.NestedSlice(binding.Object, "subjects")
+	assert.Equal(t, []any{map[string]any{"kind": "ServiceAccount", "name": "vault-unsealer-controller-manager", "namespace": "ops"}}, subjects)
+	assert.Equal(t, "ops", findManifest(objs, "ServiceAccount", "vault-unsealer-controller-manager").GetNamespace())
+}
+
+func TestInstallManifests_Webhook(t *testing.T) {
+	objs, err := installManifests(config.Manifests, &installOptions{namespace: "ops", image: "example.com/vault-unsealer:v1", webhook: true})
+	require.NoError(t, err)
+
+	webhookConfig := findManifest(objs, "ValidatingWebhookConfiguration", "vault-unsealer-validating-webhook-configuration")
+	require.NotNil(t, webhookConfig)
+	assert.Empty(t, webhookConfig.GetNamespace())
+	webhooks, _, _ := unstructured.NestedSlice(webhookConfig.Object, "webhooks")
+	service, _, _ := unstructured.NestedMap(webhooks[0].(map[string]any), "clientConfig", "service")
+	assert.Equal(t, "vault-unsealer-webhook-service", service["name"])
+	assert.Equal(t, "ops", service["namespace"])
+	assert.NotNil(t, findManifest(objs, "Service", "vault-unsealer-webhook-service"))
+	assert.NotNil(t, findManifest(objs, "RoleBinding", "vault-unsealer-webhook-cert-rolebinding"))
+	assert.Contains(t, managerArgs(t, objs), "--self-managed-webhook-certs")
+}
+
+func TestApplyManifests(t *testing.T) {
+	ctx := context.Background()
+	k8sClient := fake.NewClientBuilder().WithScheme(clientset.NewScheme()).Build()
+	opts := &installOptions{namespace: "ops", image: "example.com/vault-unsealer:v1", webhook: true}
+	objs, err := installManifests(config.Manifests, opts)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, applyManifests(ctx, k8sClient, objs, &out))
+	assert.Contains(t, out.String(), "Deployment/vault-unsealer-controller-manager created")
+
+	// The manager injects its CA, which a second install must keep.
+	key := types.NamespacedName{Name: "vault-unsealer-validating-webhook-configuration"}
+	webhookConfig := &unstructured.Unstructured{}
+	webhookConfig.SetGroupVersionKind(schema.GroupVersionKind{Group: "admissionregistration.k8s.io", Version: "v1", Kind: "ValidatingWebhookConfiguration"})
+	require.NoError(t, k8sClient.Get(ctx, key, webhookConfig))
+	webhooks, _, _ := unstructured.NestedSlice(webhookConfig.Object, "webhooks")
+	require.NoError(t, unstructured.SetNestedField(webhooks[0].(map[string]any), "Y2E=", "clientConfig", "caBundle"))
+	require.NoError(t, unstructured.SetNestedSlice(webhookConfig.Object, webhooks, "webhooks"))
+	require.NoError(t, k8sClient.Update(ctx, webhookConfig))
+
+	objs, err = installManifests(config.Manifests, opts)
+	require.NoError(t, err)
+	out.Reset()
+	require.NoError(t, applyManifests(ctx, k8sClient, objs, &out))
+	assert.Contains(t, out.String(), "Deployment/vault-unsealer-controller-manager configured")
+
+	require.NoError(t, k8sClient.Get(ctx, key, webhookConfig))
+	webhooks, _, _ = unstructured.NestedSlice(webhookConfig.Object, "webhooks")
+	caBundle, _, _ := unstructured.NestedString(webhooks[0].(map[string]any), "clientConfig", "caBundle")
+	assert.Equal(t, "Y2E=", caBundle)
+}
+
+func TestRunInstall_RequiresImage(t *testing.T) {
+	var out bytes.Buffer
+	assert.ErrorContains(t, RunInstall([]string{"--dry-run"}, &out), "--image")
+
+	require.NoError(t, RunInstall([]string{"--image", "example.com/vault-unsealer:v1", "--dry-run"}, &out))
+	assert.Contains(t, out.String(), "kind: CustomResourceDefinition")
+}
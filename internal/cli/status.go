@@ -0,0 +1,281 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/admin"
+)
+
+type statusOptions struct {
+	namespace   string
+	kubeconfig  string
+	adminURL    string
+	token       string
+	tokenFile   string
+	caFile      string
+	insecure    bool
+	timeout     time.Duration
+	resourceArg string
+}
+
+// RunStatus prints a human-readable summary of one or all VaultUnsealers.
+//
+//	vault-unsealer status [name] [-n namespace] [--admin-url https://host:9443 --token-file path]
+func RunStatus(args []string, out io.Writer) error {
+	opts, err := parseStatusFlags(args)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.timeout)
+	defer cancel()
+
+	var summaries []admin.VaultUnsealerSummary
+	if opts.adminURL != "" {
+		summaries, err = fetchFromAdminAPI(ctx, opts)
+	} else {
+		summaries, err = fetchFromAPIServer(ctx, opts)
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(summaries) == 0 {
+		_, _ = fmt.Fprintln(out, "No VaultUnsealer resources found.")
+		return nil
+	}
+	return renderStatus(out, summaries)
+}
+
+func parseStatusFlags(args []string) (*statusOptions, error) {
+	opts := &statusOptions{}
+	fs := flag.NewFlagSet("status", flag.ContinueOnError)
+	fs.StringVar(&opts.namespace, "namespace", "", "Namespace of the VaultUnsealer. Defaults to all namespaces.")
+	fs.StringVar(&opts.namespace, "n", "", "Shorthand for --namespace.")
+	fs.StringVar(&opts.kubeconfig, "kubeconfig", "", "Path to a kubeconfig file.")
+	fs.StringVar(&opts.adminURL, "admin-url", "", "Query the operator admin API at this URL instead of the API server.")
+	fs.StringVar(&opts.token, "token", "", "Bearer token for the admin API.")
+	fs.StringVar(&opts.tokenFile, "token-file", "", "File containing the bearer token for the admin API.")
+	fs.StringVar(&opts.caFile, "ca-file", "", "CA bundle used to verify the admin API certificate.")
+	fs.BoolVar(&opts.insecure, "insecure-skip-tls-verify", false, "Skip verification of the admin API certificate.")
+	fs.DurationVar(&opts.timeout, "timeout", 30*time.Second, "Timeout for the status request.")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	// Allow flags after the positional name, e.g. `status vault -n vault`.
+	if fs.NArg() > 0 {
+		opts.resourceArg = fs.Arg(0)
+		if err := fs.Parse(fs.Args()[1:]); err != nil {
+			return nil, err
+		}
+		if fs.NArg() > 0 {
+			return nil, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+		}
+	}
+	if opts.resourceArg != "" && opts.namespace == "" {
+		opts.namespace = "default"
+	}
+	return opts, nil
+}
+
+func fetchFromAPIServer(ctx context.Context, opts *statusOptions) ([]admin.VaultUnsealerSummary, error) {
+	k8sClient, err := newClient(opts.kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.resourceArg != "" {
+		var vaultUnsealer opsv1alpha1.VaultUnsealer
+		key := types.NamespacedName{Namespace: opts.namespace, Name: opts.resourceArg}
+		if err := k8sClient.Get(ctx, key, &vaultUnsealer); err != nil {
+			return nil, fmt.Errorf("failed to get VaultUnsealer %s: %w", key, err)
+		}
+		return []admin.VaultUnsealerSummary{admin.Summarize(&vaultUnsealer)}, nil
+	}
+
+	var list opsv1alpha1.VaultUnsealerList
+	if err := k8sClient.List(ctx, &list, client.InNamespace(opts.namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list VaultUnsealers: %w", err)
+	}
+	summaries := make([]admin.VaultUnsealerSummary, 0, len(list.Items))
+	for i := range list.Items {
+		summaries = append(summaries, admin.Summarize(&list.Items[i]))
+	}
+	return summaries, nil
+}
+
+func fetchFromAdminAPI(ctx context.Context, opts *statusOptions) ([]admin.VaultUnsealerSummary, error) {
+	base := strings.TrimSuffix(opts.adminURL, "/")
+	var endpoint string
+	if opts.resourceArg != "" {
+		endpoint = fmt.Sprintf("%s/api/v1/namespaces/%s/vaultunsealers/%s", base,
+			url.PathEscape(opts.namespace), url.PathEscape(opts.resourceArg))
+	} else {
+		endpoint = base + "/api/v1/vaultunsealers"
+		if opts.namespace != "" {
+			endpoint += "?namespace=" + url.QueryEscape(opts.namespace)
+		}
+	}
+
+	body, err := adminGet(ctx, opts, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.resourceArg != "" {
+		var summary admin.VaultUnsealerSummary
+		if err := json.Unmarshal(body, &summary); err != nil {
+			return nil, fmt.Errorf("failed to decode admin API response: %w", err)
+		}
+		return []admin.VaultUnsealerSummary{summary}, nil
+	}
+
+	var summaries []admin.VaultUnsealerSummary
+	if err := json.Unmarshal(body, &summaries); err != nil {
+		return nil, fmt.Errorf("failed to decode admin API response: %w", err)
+	}
+	return summaries, nil
+}
+
+func adminGet(ctx context.Context, opts *statusOptions, endpoint string) ([]byte, error) {
+	token := opts.token
+	if opts.tokenFile != "" {
+		data, err := os.ReadFile(opts.tokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read token file: %w", err)
+		}
+		token = strings.TrimSpace(string(data))
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: opts.insecure} //nolint:gosec // opt-in flag
+	if opts.caFile != "" {
+		caData, err := os.ReadFile(opts.caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caData) {
+			return nil, fmt.Errorf("failed to parse CA file %s", opts.caFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("admin API request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read admin API response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("admin API returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+func renderStatus(out io.Writer, summaries []admin.VaultUnsealerSummary) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	for i, summary := range summaries {
+		if i > 0 {
+			_, _ = fmt.Fprintln(w)
+		}
+
+		header := fmt.Sprintf("%s/%s", summary.Namespace, summary.Name)
+		if summary.Paused {
+			header += " (paused)"
+		}
+		_, _ = fmt.Fprintln(w, header)
+		_, _ = fmt.Fprintf(w, "Last reconcile:\t%s\n", formatTime(summary.Status.LastReconcileTime))
+
+		_, _ = fmt.Fprintln(w)
+		_, _ = fmt.Fprintln(w, "POD\tSTATE\tLAST UNSEAL\tUNSEAL ROUND\tMESSAGE")
+		if len(summary.Status.Pods) == 0 {
+			_, _ = fmt.Fprintln(w, "<none>\t\t\t\t")
+		}
+		for _, pod := range summary.Status.Pods {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", pod.Name, pod.State, formatTime(pod.LastUnsealTime), formatUnsealRound(pod), pod.Message)
+		}
+
+		_, _ = fmt.Fprintln(w)
+		_, _ = fmt.Fprintln(w, "CONDITION\tSTATUS\tREASON\tMESSAGE")
+		if len(summary.Status.Conditions) == 0 {
+			_, _ = fmt.Fprintln(w, "<none>\t\t\t")
+		}
+		for _, condition := range summary.Status.Conditions {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", condition.Type, condition.Status, condition.Reason, condition.Message)
+		}
+
+		if len(summary.Status.Warnings) > 0 {
+			_, _ = fmt.Fprintln(w)
+			_, _ = fmt.Fprintln(w, "WARNING SOURCE\tMESSAGE")
+			for _, warning := range summary.Status.Warnings {
+				_, _ = fmt.Fprintf(w, "%s\t%s\n", warning.Source, warning.Message)
+			}
+		}
+	}
+
+	return w.Flush()
+}
+
+// formatUnsealRound shows the progress and nonce of a pod's unseal round,
+// such as "1/3 8f1c...", so keys submitted by hand can join it.
+func formatUnsealRound(pod opsv1alpha1.PodStatus) string {
+	if pod.UnsealNonce == "" {
+		return "-"
+	}
+	return fmt.Sprintf("%d/%d %s", pod.UnsealProgress, pod.UnsealThreshold, pod.UnsealNonce)
+}
+
+func formatTime(t *metav1.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/admin"
+)
+
+func TestParseStatusFlags(t *testing.T) {
+	opts, err := parseStatusFlags([]string{"vault-unsealer", "-n", "vault"})
+	require.NoError(t, err)
+	assert.Equal(t, "vault-unsealer", opts.resourceArg)
+	assert.Equal(t, "vault", opts.namespace)
+
+	opts, err = parseStatusFlags([]string{"vault-unsealer"})
+	require.NoError(t, err)
+	assert.Equal(t, "default", opts.namespace)
+
+	opts, err = parseStatusFlags(nil)
+	require.NoError(t, err)
+	assert.Empty(t, opts.namespace)
+
+	_, err = parseStatusFlags([]string{"a", "b"})
+	assert.Error(t, err)
+}
+
+func TestRenderStatus(t *testing.T) {
+	unsealedAt := metav1.NewTime(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+	summaries := []admin.VaultUnsealerSummary{{
+		Namespace: "vault",
+		Name:      "vault-unsealer",
+		Paused:    true,
+		Status: opsv1alpha1.VaultUnsealerStatus{
+			Pods: []opsv1alpha1.PodStatus{
+				{Name: "vault-0", State: opsv1alpha1.PodStateUnsealed, LastUnsealTime: &unsealedAt},
+				{Name: "vault-1", State: opsv1alpha1.PodStateUnknown, Message: "Pod is not ready"},
+				{Name: "vault-2", State: opsv1alpha1.PodStateSealed, UnsealNonce: "2c41d0f8", UnsealProgress: 1, UnsealThreshold: 3},
+			},
+			Conditions: []opsv1alpha1.Condition{
+				{Type: "Ready", Status: "True", Reason: "ReconcileSuccess", Message: "Successfully unsealed 1 pods"},
+			},
+			Warnings: []opsv1alpha1.StatusWarning{
+				{Source: opsv1alpha1.WarningSourceAdmission, Message: "keyThreshold is 0, all available keys will be used for unsealing"},
+			},
+		},
+	}}
+
+	var out bytes.Buffer
+	require.NoError(t, renderStatus(&out, summaries))
+
+	rendered := out.String()
+	assert.Contains(t, rendered, "vault/vault-unsealer (paused)")
+	assert.Contains(t, rendered, "2025-01-02T03:04:05Z")
+	assert.Contains(t, rendered, "Pod is not ready")
+	assert.Contains(t, rendered, "1/3 2c41d0f8")
+	assert.Contains(t, rendered, "ReconcileSuccess")
+	assert.Contains(t, rendered, "Last reconcile:  -")
+	assert.Contains(t, rendered, "Admission       keyThreshold is 0, all available keys will be used for unsealing")
+}
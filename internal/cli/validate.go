@@ -0,0 +1,143 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	vaultwebhook "github.com/panteparak/vault-unsealer/internal/webhook"
+)
+
+// stringSlice collects repeated string flags.
+type stringSlice []string
+
+func (s *stringSlice) String() string     { return strings.Join(*s, ",") }
+func (s *stringSlice) Set(v string) error { *s = append(*s, v); return nil }
+
+// RunValidate lints VaultUnsealer manifests with the admission webhook rules.
+//
+//	vault-unsealer validate -f cr.yaml [-f more.yaml] [--strict]
+func RunValidate(args []string, out io.Writer) error {
+	var files stringSlice
+	var strict bool
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	fs.Var(&files, "f", "Manifest file to validate (repeatable, '-' for stdin).")
+	fs.Var(&files, "filename", "Alias for -f.")
+	fs.BoolVar(&strict, "strict", true, "Reject unknown fields in VaultUnsealer documents.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	files = append(files, fs.Args()...)
+	if len(files) == 0 {
+		return fmt.Errorf("at least one manifest must be given with -f")
+	}
+
+	validator := &vaultwebhook.VaultUnsealerValidator{}
+	invalid := 0
+	for _, file := range files {
+		n, err := validateFile(context.Background(), validator, file, strict, out)
+		if err != nil {
+			return err
+		}
+		invalid += n
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("%d invalid VaultUnsealer document(s)", invalid)
+	}
+	return nil
+}
+
+// validateFile validates every VaultUnsealer document in file and returns the
+// number of documents that failed validation.
+func validateFile(ctx context.Context, validator *vaultwebhook.VaultUnsealerValidator, file string, strict bool, out io.Writer) (int, error) {
+	var reader io.Reader
+	if file == "-" {
+		reader = os.Stdin
+	} else {
+		f, err := os.Open(file)
+		if err != nil {
+			return 0, fmt.Errorf("failed to open %s: %w", file, err)
+		}
+		defer func() { _ = f.Close() }()
+		reader = f
+	}
+
+	invalid := 0
+	docs := utilyaml.NewYAMLReader(bufio.NewReader(reader))
+	for index := 0; ; index++ {
+		doc, err := docs.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return invalid, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		var typeMeta metav1.TypeMeta
+		if err := yaml.Unmarshal(doc, &typeMeta); err != nil {
+			_, _ = fmt.Fprintf(out, "%s[%d]: invalid: %v\n", file, index, err)
+			invalid++
+			continue
+		}
+		if typeMeta.Kind != "VaultUnsealer" || typeMeta.APIVersion != opsv1alpha1.GroupVersion.String() {
+			continue
+		}
+
+		var vaultUnsealer opsv1alpha1.VaultUnsealer
+		if strict {
+			err = yaml.UnmarshalStrict(doc, &vaultUnsealer)
+		} else {
+			err = yaml.Unmarshal(doc, &vaultUnsealer)
+		}
+		if err != nil {
+			_, _ = fmt.Fprintf(out, "%s[%d]: invalid: %v\n", file, index, err)
+			invalid++
+			continue
+		}
+
+		ref := fmt.Sprintf("%s[%d] %s/%s", file, index, vaultUnsealer.Namespace, vaultUnsealer.Name)
+		warnings, err := validator.Validate(ctx, &vaultUnsealer)
+		for _, warning := range warnings {
+			_, _ = fmt.Fprintf(out, "%s: warning: %s\n", ref, warning)
+		}
+		if err != nil {
+			_, _ = fmt.Fprintf(out, "%s: invalid: %v\n", ref, err)
+			invalid++
+			continue
+		}
+		_, _ = fmt.Fprintf(out, "%s: valid\n", ref)
+	}
+
+	return invalid, nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validManifest = `apiVersion: v1
+kind: Secret
+metadata:
+  name: vault-keys
+---
+apiVersion: ops.autounseal.vault.io/v1alpha1
+kind: VaultUnsealer
+metadata:
+  name: good
+  namespace: vault
+spec:
+  vault:
+    url: https://vault.vault.svc:8200
+  unsealKeysSecretRefs:
+    - name: vault-keys
+      key: keys.json
+  vaultLabelSelector: app.kubernetes.io/name=vault
+  mode:
+    ha: true
+  keyThreshold: 3
+`
+
+const invalidManifest = `apiVersion: ops.autounseal.vault.io/v1alpha1
+kind: VaultUnsealer
+metadata:
+  name: bad
+  namespace: vault
+spec:
+  vault:
+    url: ftp://vault
+  unsealKeysSecretRefs: []
+  vaultLabelSelector: app=vault
+  mode:
+    ha: true
+`
+
+func writeManifest(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "cr.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestRunValidate_Valid(t *testing.T) {
+	path := writeManifest(t, validManifest)
+
+	var out bytes.Buffer
+	require.NoError(t, RunValidate([]string{"-f", path}, &out))
+	assert.Contains(t, out.String(), "vault/good: valid")
+	assert.NotContains(t, out.String(), "Secret")
+}
+
+func TestRunValidate_Invalid(t *testing.T) {
+	path := writeManifest(t, invalidManifest)
+
+	var out bytes.Buffer
+	err := RunValidate([]string{"-f", path}, &out)
+	require.Error(t, err)
+	assert.Contains(t, out.String(), "URL scheme must be http or https")
+	assert.Contains(t, out.String(), "at least one unseal keys secret reference is required")
+}
+
+func TestRunValidate_UnknownFieldStrict(t *testing.T) {
+	path := writeManifest(t, validManifest+"  unknownField: true\n")
+
+	var out bytes.Buffer
+	require.Error(t, RunValidate([]string{"-f", path}, &out))
+	assert.Contains(t, out.String(), "unknownField")
+
+	out.Reset()
+	require.NoError(t, RunValidate([]string{"-f", path, "--strict=false"}, &out))
+}
+
+func TestRunValidate_RequiresFile(t *testing.T) {
+	var out bytes.Buffer
+	assert.Error(t, RunValidate(nil, &out))
+}
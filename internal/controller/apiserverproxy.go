@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+
+	"github.com/panteparak/vault-unsealer/internal/vault"
+)
+
+// errNoAPIServerProxy is returned for spec.vault.apiServerProxy when the
+// reconciler was set up without an APIServerProxy.
+var errNoAPIServerProxy = errors.New("spec.vault.apiServerProxy is set, but the operator has no apiserver connection to proxy through")
+
+// APIServerProxy reaches Vault pods through the Kubernetes apiserver's pod
+// proxy, for VaultUnsealers with spec.vault.apiServerProxy set.
+type APIServerProxy struct {
+	// Host is the apiserver URL.
+	Host string
+	// Transport authenticates requests to the apiserver.
+	Transport http.RoundTripper
+}
+
+// NewAPIServerProxy returns an APIServerProxy using the credentials of cfg.
+func NewAPIServerProxy(cfg *rest.Config) (*APIServerProxy, error) {
+	transport, err := rest.TransportFor(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create apiserver transport: %w", err)
+	}
+	return &APIServerProxy{Host: cfg.Host, Transport: transport}, nil
+}
+
+// client returns a Vault client for pod, whose Vault API is at podURL, that
+// sends its requests through the pod proxy.
+func (p *APIServerProxy) client(pod *corev1.Pod, podURL string) (*vault.Client, error) {
+	proxyPath, err := apiServerProxyPath(pod, podURL)
+	if err != nil {
+		return nil, err
+	}
+	vaultClient, err := vault.NewClientWithTransport(p.Host, p.Transport)
+	if err != nil {
+		return nil, err
+	}
+	if err := vaultClient.SetPathPrefix(proxyPath); err != nil {
+		return nil, err
+	}
+	return vaultClient, nil
+}
+
+// apiServerProxyPath returns the pod proxy path reaching the scheme, port and
+// path of podURL on pod, such as /api/v1/namespaces/vault/pods/https:vault-0:8200/proxy.
+func apiServerProxyPath(pod *corev1.Pod, podURL string) (string, error) {
+	u, err := url.Parse(podURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid vault URL: %w", err)
+	}
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+	target := pod.Name + ":" + port
+	if u.Scheme == "https" {
+		target = "https:" + target
+	}
+	return fmt.Sprintf("/api/v1/namespaces/%s/pods/%s/proxy%s", url.PathEscape(pod.Namespace), target, u.Path), nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+func TestAPIServerProxyPath(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "vault-0", Namespace: "vault"}}
+	tests := []struct {
+		podURL string
+		want   string
+	}{
+		{"http://10.0.0.7:8200", "/api/v1/namespaces/vault/pods/vault-0:8200/proxy"},
+		{"https://10.0.0.7:8200", "/api/v1/namespaces/vault/pods/https:vault-0:8200/proxy"},
+		{"https://10.0.0.7", "/api/v1/namespaces/vault/pods/https:vault-0:443/proxy"},
+		{"http://10.0.0.7/vault", "/api/v1/namespaces/vault/pods/vault-0:80/proxy/vault"},
+	}
+	for _, tt := range tests {
+		got, err := apiServerProxyPath(pod, tt.podURL)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, got, tt.podURL)
+	}
+}
+
+// newFakeAPIServer serves the pod proxy of vault/vault-0:8200 by forwarding
+// to fake, and records the paths requested.
+func newFakeAPIServer(t *testing.T, fake *vaultfake.Server) (*httptest.Server, func() []string) {
+	const prefix = "/api/v1/namespaces/vault/pods/vault-0:8200/proxy"
+	target, err := url.Parse(fake.URL())
+	require.NoError(t, err)
+	proxy := httputil.NewSingleHostReverseProxy(target)
+
+	var mu sync.Mutex
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		paths = append(paths, req.URL.Path)
+		mu.Unlock()
+		if !strings.HasPrefix(req.URL.Path, prefix+"/") {
+			http.NotFound(w, req)
+			return
+		}
+		req.URL.Path = strings.TrimPrefix(req.URL.Path, prefix)
+		proxy.ServeHTTP(w, req)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), paths...)
+	}
+}
+
+func TestCheckAndUnsealPod_ThroughAPIServerProxy(t *testing.T) {
+	keys := []string{"key-1", "key-2", "key-3"}
+	fake := vaultfake.NewServer(vaultfake.WithKeys(keys, 2))
+	defer fake.Close()
+	apiServer, paths := newFakeAPIServer(t, fake)
+
+	// The pod has no IP reachable from the operator, only the apiserver.
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "vault-0", Namespace: "vault"}}
+	vu := opsv1alpha1.NewVaultUnsealer("vault", "main").WithVaultURL("http://vault.vault.svc:8200")
+	vu.Spec.Vault.APIServerProxy = true
+
+	r := &VaultUnsealerReconciler{APIServerProxy: &APIServerProxy{Host: apiServer.URL, Transport: http.DefaultTransport}}
+	result, err := r.checkAndUnsealPod(context.Background(), pod, vu, newKeys(keys[:2]...), nil)
+	require.NoError(t, err)
+	assert.True(t, result.unsealedNow)
+	assert.False(t, fake.Sealed())
+	assert.Contains(t, paths(), "/api/v1/namespaces/vault/pods/vault-0:8200/proxy/v1/sys/seal-status")
+	assert.Contains(t, paths(), "/api/v1/namespaces/vault/pods/vault-0:8200/proxy/v1/sys/unseal")
+}
+
+func TestCreateVaultClient_APIServerProxyUnavailable(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "vault-0", Namespace: "vault"}}
+	vu := opsv1alpha1.NewVaultUnsealer("vault", "main").WithVaultURL("http://vault.vault.svc:8200")
+	vu.Spec.Vault.APIServerProxy = true
+
+	_, err := (&VaultUnsealerReconciler{}).createVaultClient(context.Background(), pod, vu)
+	assert.ErrorIs(t, err, errNoAPIServerProxy)
+}
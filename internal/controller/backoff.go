@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// Per-pod backoff state lives in status.pods rather than in memory, so a
+// replica that takes over after leader failover keeps honouring it instead
+// of retrying every failing pod at once.
+const (
+	podBackoffBase = 10 * time.Second
+	podBackoffMax  = 5 * time.Minute
+)
+
+// podBackoff returns the delay before the next attempt after the given
+// number of consecutive failures.
+func podBackoff(failures int32) time.Duration {
+	if failures <= 0 {
+		return 0
+	}
+	delay := podBackoffBase
+	for i := int32(1); i < failures; i++ {
+		delay *= 2
+		if delay >= podBackoffMax {
+			return podBackoffMax
+		}
+	}
+	return delay
+}
+
+// inBackoff reports whether the pod's last recorded failure still blocks a new attempt.
+func inBackoff(previous opsv1alpha1.PodStatus, now time.Time) bool {
+	return previous.NextAttemptTime != nil && now.Before(previous.NextAttemptTime.Time)
+}
+
+// recordPodFailure carries the failure count forward and schedules the next attempt.
+func recordPodFailure(podStatus *opsv1alpha1.PodStatus, previous opsv1alpha1.PodStatus, now time.Time) {
+	podStatus.ConsecutiveFailures = previous.ConsecutiveFailures + 1
+	podStatus.NextAttemptTime = &metav1.Time{Time: now.Add(podBackoff(podStatus.ConsecutiveFailures))}
+}
+
+// requeueAfter shortens interval so the reconcile runs when the earliest pod backoff expires.
+func requeueAfter(interval time.Duration, podStatuses []opsv1alpha1.PodStatus, now time.Time) time.Duration {
+	for _, podStatus := range podStatuses {
+		if podStatus.NextAttemptTime == nil {
+			continue
+		}
+		if wait := podStatus.NextAttemptTime.Sub(now); wait > 0 && wait < interval {
+			interval = wait
+		}
+	}
+	return interval
+}
+
+// podDiscoveryBackoff returns the requeue delay after the given number of
+// consecutive pod listing failures. It follows the per-pod schedule, so a
+// throttled API server or an unsynced cache is retried quickly at first and
+// then increasingly rarely.
+func podDiscoveryBackoff(failures int32) time.Duration {
+	return podBackoff(failures)
+}
+
+// podDiscoveryErrorType is the error_type metric label for a pod listing
+// failure, separating API throttling and an unstarted cache from other errors.
+func podDiscoveryErrorType(err error) string {
+	var notStarted *cache.ErrCacheNotStarted
+	switch {
+	case apierrors.IsTooManyRequests(err):
+		return "pod_discovery_throttled"
+	case errors.As(err, &notStarted):
+		return "pod_discovery_cache_not_started"
+	default:
+		return "pod_discovery"
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+func TestPodBackoff(t *testing.T) {
+	assert.Equal(t, time.Duration(0), podBackoff(0))
+	assert.Equal(t, podBackoffBase, podBackoff(1))
+	assert.Equal(t, 2*podBackoffBase, podBackoff(2))
+	assert.Equal(t, 4*podBackoffBase, podBackoff(3))
+	assert.Equal(t, podBackoffMax, podBackoff(100))
+}
+
+func TestRecordPodFailure_SurvivesHandoff(t *testing.T) {
+	now := time.Now()
+	var previous opsv1alpha1.PodStatus
+
+	// Each reconcile only sees the state persisted in status, as a newly
+	// elected leader would.
+	for i := int32(1); i <= 3; i++ {
+		current := opsv1alpha1.PodStatus{Name: "vault-0"}
+		recordPodFailure(&current, previous, now)
+		assert.Equal(t, i, current.ConsecutiveFailures)
+		assert.True(t, inBackoff(current, now))
+		assert.False(t, inBackoff(current, now.Add(podBackoff(i))))
+		previous = current
+	}
+}
+
+func TestRequeueAfter(t *testing.T) {
+	now := time.Now()
+	statuses := []opsv1alpha1.PodStatus{
+		{Name: "vault-0"},
+		{Name: "vault-1", NextAttemptTime: &metav1.Time{Time: now.Add(20 * time.Second)}},
+		{Name: "vault-2", NextAttemptTime: &metav1.Time{Time: now.Add(-time.Second)}},
+	}
+	assert.Equal(t, 20*time.Second, requeueAfter(time.Minute, statuses, now))
+	assert.Equal(t, 10*time.Second, requeueAfter(10*time.Second, statuses, now))
+}
+
+func TestPodDiscoveryErrorType(t *testing.T) {
+	assert.Equal(t, "pod_discovery_throttled", podDiscoveryErrorType(apierrors.NewTooManyRequests("slow down", 1)))
+	assert.Equal(t, "pod_discovery_cache_not_started", podDiscoveryErrorType(&cache.ErrCacheNotStarted{}))
+	assert.Equal(t, "pod_discovery", podDiscoveryErrorType(errors.New("boom")))
+}
+
+func TestReconcile_PodDiscoveryBackoff(t *testing.T) {
+	r := newFakeReconciler(t, newFinalizerTestUnsealer())
+	listErr := apierrors.NewTooManyRequests("slow down", 1)
+	r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if _, ok := list.(*corev1.PodList); ok && listErr != nil {
+				return listErr
+			}
+			return c.List(ctx, list, opts...)
+		},
+	})
+	key := types.NamespacedName{Namespace: "vault", Name: "main"}
+
+	var delays []time.Duration
+	for range 3 {
+		result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+		require.NoError(t, err, "the error is surfaced through the backoff, not the rate limiter")
+		delays = append(delays, result.RequeueAfter)
+	}
+	assert.Equal(t, []time.Duration{podBackoffBase, 2 * podBackoffBase, 4 * podBackoffBase}, delays)
+
+	vu := &opsv1alpha1.VaultUnsealer{}
+	require.NoError(t, r.Get(context.Background(), key, vu))
+	assert.Equal(t, int32(3), vu.Status.PodDiscoveryFailures)
+
+	listErr = nil
+	vu = reconcileAndGet(t, r)
+	assert.Zero(t, vu.Status.PodDiscoveryFailures)
+}
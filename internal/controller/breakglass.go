@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// breakGlassUntil parses the break-glass annotation. It returns the zero time
+// when the annotation is absent.
+func breakGlassUntil(vaultUnsealer *opsv1alpha1.VaultUnsealer, now time.Time) (time.Time, error) {
+	value, ok := vaultUnsealer.Annotations[opsv1alpha1.AnnotationBreakGlassUntil]
+	if !ok || value == "" {
+		return time.Time{}, nil
+	}
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s annotation %q: %w", opsv1alpha1.AnnotationBreakGlassUntil, value, err)
+	}
+	if until.After(now.Add(opsv1alpha1.MaxBreakGlassDuration)) {
+		return time.Time{}, fmt.Errorf("%s annotation %q is more than %s away", opsv1alpha1.AnnotationBreakGlassUntil, value, opsv1alpha1.MaxBreakGlassDuration)
+	}
+	// Status times are stored with second precision.
+	return until.Truncate(time.Second), nil
+}
+
+// reconcileBreakGlass evaluates the break-glass override, records grants and
+// expiries as Events and in status, and returns the expiry when an override
+// is in effect.
+func (r *VaultUnsealerReconciler) reconcileBreakGlass(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer, now time.Time) (time.Time, bool) {
+	until, err := breakGlassUntil(vaultUnsealer, now)
+	if err != nil {
+		r.event(ctx, vaultUnsealer, corev1.EventTypeWarning, ReasonBreakGlassRejected, err.Error())
+	}
+	active := !until.IsZero() && now.Before(until)
+
+	recorded := vaultUnsealer.Status.BreakGlassUntil
+	switch {
+	case active && (recorded == nil || !recorded.Equal(&metav1.Time{Time: until})):
+		r.event(ctx, vaultUnsealer, corev1.EventTypeWarning, ReasonBreakGlassGranted,
+			fmt.Sprintf("Break-glass override granted until %s; pause and maintenance windows are bypassed", until.UTC().Format(time.RFC3339)))
+		vaultUnsealer.Status.BreakGlassUntil = &metav1.Time{Time: until}
+	case !active && recorded != nil:
+		r.event(ctx, vaultUnsealer, corev1.EventTypeNormal, ReasonBreakGlassEnded,
+			fmt.Sprintf("Break-glass override granted until %s has ended", recorded.UTC().Format(time.RFC3339)))
+		vaultUnsealer.Status.BreakGlassUntil = nil
+	}
+	return until, active
+}
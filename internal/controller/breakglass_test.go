@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+func TestBreakGlassUntil(t *testing.T) {
+	now := time.Date(2025, 6, 4, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "absent"},
+		{name: "valid", value: "2025-06-04T12:30:00Z", want: now.Add(30 * time.Minute)},
+		{name: "expired", value: "2025-06-04T11:00:00Z", want: now.Add(-time.Hour)},
+		{name: "not a timestamp", value: "30m", wantErr: true},
+		{name: "too far ahead", value: "2025-06-06T12:00:00Z", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vu := newFinalizerTestUnsealer()
+			if tt.value != "" {
+				vu.Annotations = map[string]string{opsv1alpha1.AnnotationBreakGlassUntil: tt.value}
+			}
+			got, err := breakGlassUntil(vu, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s", got)
+		})
+	}
+}
+
+func TestReconcileBreakGlass_RecordsGrantAndExpiry(t *testing.T) {
+	now := time.Now()
+	recorder := record.NewFakeRecorder(10)
+	r := &VaultUnsealerReconciler{Recorder: recorder}
+	vu := newFinalizerTestUnsealer()
+	vu.Annotations = map[string]string{
+		opsv1alpha1.AnnotationBreakGlassUntil: now.Add(30 * time.Minute).UTC().Format(time.RFC3339),
+	}
+
+	_, active := r.reconcileBreakGlass(context.Background(), vu, now)
+	assert.True(t, active)
+	require.NotNil(t, vu.Status.BreakGlassUntil)
+	assert.Contains(t, <-recorder.Events, ReasonBreakGlassGranted)
+
+	// The same grant is only announced once.
+	_, active = r.reconcileBreakGlass(context.Background(), vu, now.Add(time.Minute))
+	assert.True(t, active)
+	assert.Empty(t, recorder.Events)
+
+	_, active = r.reconcileBreakGlass(context.Background(), vu, now.Add(time.Hour))
+	assert.False(t, active)
+	assert.Nil(t, vu.Status.BreakGlassUntil)
+	assert.Contains(t, <-recorder.Events, corev1.EventTypeNormal+" "+ReasonBreakGlassEnded)
+}
+
+func TestReconcile_BreakGlassOverridesPause(t *testing.T) {
+	vu := newFinalizerTestUnsealer()
+	vu.Annotations = map[string]string{
+		opsv1alpha1.AnnotationPaused:          "true",
+		opsv1alpha1.AnnotationBreakGlassUntil: time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+	}
+	r := newFakeReconciler(t, vu)
+
+	got := reconcileAndGet(t, r)
+
+	require.NotNil(t, got.Status.BreakGlassUntil)
+	for _, cond := range got.Status.Conditions {
+		assert.NotEqual(t, ConditionTypePaused, cond.Type)
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/secrets"
+)
+
+// caExpiryWarning is how long before a CA certificate expires the
+// CAExpiringSoon condition is raised.
+const caExpiryWarning = 30 * 24 * time.Hour
+
+// errCABundleUnusable wraps a CA bundle load failure while building a Vault
+// client, so the pod is reported as a CA misconfiguration instead of being
+// dialled against the system roots and failing verification.
+var errCABundleUnusable = errors.New("CA bundle cannot be used")
+
+// parseCABundle parses every PEM block of a CA bundle. Anything that is not
+// a certificate is rejected, so a pasted private key or a truncated file is
+// reported instead of silently leaving the pool short.
+func parseCABundle(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("CA bundle contains a %s block, only certificates are allowed", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("CA bundle contains no PEM certificates")
+	}
+	return certs, nil
+}
+
+// caExpiry reports the certificate that expires first if it expires within
+// caExpiryWarning of now, with the condition reason and message. During a CA
+// rotation this is the outgoing CA, a reminder to drop it once Vault serves
+// certificates from the new one.
+func caExpiry(certs []*x509.Certificate, now time.Time) (string, string, bool) {
+	first := certs[0]
+	for _, cert := range certs[1:] {
+		if cert.NotAfter.Before(first.NotAfter) {
+			first = cert
+		}
+	}
+
+	switch {
+	case now.After(first.NotAfter):
+		return ReasonCAExpired, fmt.Sprintf("CA certificate %q expired at %s", first.Subject.CommonName, first.NotAfter.UTC().Format(time.RFC3339)), true
+	case first.NotAfter.Sub(now) < caExpiryWarning:
+		return ReasonCAExpiring, fmt.Sprintf("CA certificate %q expires at %s, in %d days",
+			first.Subject.CommonName, first.NotAfter.UTC().Format(time.RFC3339), int(first.NotAfter.Sub(now).Hours()/24)), true
+	default:
+		return "", "", false
+	}
+}
+
+// caBundleRefs returns every CA bundle Secret reference of the resource,
+// caBundleSecretRef first.
+func caBundleRefs(vaultUnsealer *opsv1alpha1.VaultUnsealer) []opsv1alpha1.SecretRef {
+	var refs []opsv1alpha1.SecretRef
+	if vaultUnsealer.Spec.Vault.CABundleSecretRef != nil {
+		refs = append(refs, *vaultUnsealer.Spec.Vault.CABundleSecretRef)
+	}
+	return append(refs, vaultUnsealer.Spec.Vault.CABundleSecretRefs...)
+}
+
+// loadCABundles reads and parses every configured CA bundle. The Secrets are
+// read through the secrets Loader, so cross-namespace references need the
+// same grant as unseal key Secrets. Any bundle that cannot be used fails the
+// whole load, naming the Secret it came from.
+func (r *VaultUnsealerReconciler) loadCABundles(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) ([]*x509.Certificate, error) {
+	loader := r.SecretsLoader
+	if loader == nil {
+		loader = secrets.NewLoader(r.Client)
+	}
+	var certs []*x509.Certificate
+	for _, ref := range caBundleRefs(vaultUnsealer) {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = vaultUnsealer.Namespace
+		}
+
+		secret, err := loader.GetSecret(ctx, vaultUnsealer, ref)
+		if err != nil {
+			return nil, fmt.Errorf("CA bundle secret %s/%s: %w", namespace, ref.Name, err)
+		}
+		caData, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in CA bundle secret %s/%s", ref.Key, namespace, ref.Name)
+		}
+		bundle, err := parseCABundle(caData)
+		if err != nil {
+			return nil, fmt.Errorf("CA bundle secret %s/%s: %w", namespace, ref.Name, err)
+		}
+		certs = append(certs, bundle...)
+	}
+	return certs, nil
+}
+
+func (r *VaultUnsealerReconciler) getTLSConfig(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) (*tls.Config, error) {
+	certs, err := r.loadCABundles(ctx, vaultUnsealer)
+	if err != nil || len(certs) == 0 {
+		return nil, err
+	}
+
+	caCertPool := x509.NewCertPool()
+	for _, cert := range certs {
+		caCertPool.AddCert(cert)
+	}
+	return &tls.Config{RootCAs: caCertPool}, nil
+}
+
+// reconcileCABundle verifies the configured CA bundle and reports a bundle
+// that cannot be used, or a CA that has expired or is about to, as conditions
+// before it causes an unseal outage.
+func (r *VaultUnsealerReconciler) reconcileCABundle(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer, now time.Time) {
+	if len(caBundleRefs(vaultUnsealer)) == 0 {
+		r.clearCondition(vaultUnsealer, ConditionTypeCABundleInvalid)
+		r.clearCondition(vaultUnsealer, ConditionTypeCAExpiring)
+		return
+	}
+
+	certs, err := r.loadCABundles(ctx, vaultUnsealer)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "CA bundle cannot be used")
+		reason := ReasonCABundleInvalid
+		if errors.Is(err, secrets.ErrAccessNotGranted) {
+			reason = ReasonSecretAccessDenied
+			r.event(ctx, vaultUnsealer, corev1.EventTypeWarning, ReasonSecretAccessDenied, err.Error())
+		}
+		r.setCondition(vaultUnsealer, ConditionTypeCABundleInvalid, ConditionStatusTrue, reason, err.Error())
+		r.clearCondition(vaultUnsealer, ConditionTypeCAExpiring)
+		return
+	}
+	r.clearCondition(vaultUnsealer, ConditionTypeCABundleInvalid)
+
+	if reason, message, expiring := caExpiry(certs, now); expiring {
+		logf.FromContext(ctx).Info("CA bundle expires soon", "reason", message)
+		r.setCondition(vaultUnsealer, ConditionTypeCAExpiring, ConditionStatusTrue, reason, message)
+		return
+	}
+	r.clearCondition(vaultUnsealer, ConditionTypeCAExpiring)
+}
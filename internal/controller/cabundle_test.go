@@ -0,0 +1,189 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/secrets"
+)
+
+func newTestCA(t *testing.T, commonName string, notAfter time.Time) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestParseCABundle(t *testing.T) {
+	now := time.Now()
+	bundle := append(newTestCA(t, "root", now.Add(time.Hour)), newTestCA(t, "intermediate", now.Add(time.Hour))...)
+
+	certs, err := parseCABundle(bundle)
+	require.NoError(t, err)
+	assert.Len(t, certs, 2)
+
+	_, err = parseCABundle([]byte("not pem"))
+	assert.ErrorContains(t, err, "no PEM certificates")
+
+	_, err = parseCABundle(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte("key")}))
+	assert.ErrorContains(t, err, "EC PRIVATE KEY")
+
+	_, err = parseCABundle(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("garbage")}))
+	assert.ErrorContains(t, err, "failed to parse CA certificate")
+}
+
+func TestCAExpiry(t *testing.T) {
+	now := time.Now()
+	parse := func(bundle []byte) []*x509.Certificate {
+		certs, err := parseCABundle(bundle)
+		require.NoError(t, err)
+		return certs
+	}
+
+	_, _, expiring := caExpiry(parse(newTestCA(t, "root", now.Add(365*24*time.Hour))), now)
+	assert.False(t, expiring)
+
+	bundle := append(newTestCA(t, "root", now.Add(365*24*time.Hour)), newTestCA(t, "old", now.Add(10*24*time.Hour))...)
+	reason, message, expiring := caExpiry(parse(bundle), now)
+	assert.True(t, expiring)
+	assert.Equal(t, ReasonCAExpiring, reason)
+	assert.Contains(t, message, `"old"`)
+
+	reason, _, expiring = caExpiry(parse(newTestCA(t, "root", now.Add(-time.Hour))), now)
+	assert.True(t, expiring)
+	assert.Equal(t, ReasonCAExpired, reason)
+}
+
+func TestReconcileCABundle_Conditions(t *testing.T) {
+	now := time.Now()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-ca", Namespace: "vault"},
+		Data:       map[string][]byte{"ca.crt": newTestCA(t, "root", now.Add(7*24*time.Hour))},
+	}
+	r := newFakeReconciler(t, secret)
+	vu := newFinalizerTestUnsealer().WithCABundleSecret("vault-ca", "ca.crt")
+
+	conditionFor := func(conditionType string) *opsv1alpha1.Condition {
+		for i := range vu.Status.Conditions {
+			if vu.Status.Conditions[i].Type == conditionType {
+				return &vu.Status.Conditions[i]
+			}
+		}
+		return nil
+	}
+
+	r.reconcileCABundle(context.Background(), vu, now)
+	require.NotNil(t, conditionFor(ConditionTypeCAExpiring))
+	assert.Equal(t, ReasonCAExpiring, conditionFor(ConditionTypeCAExpiring).Reason)
+	assert.Nil(t, conditionFor(ConditionTypeCABundleInvalid))
+
+	vu.Spec.Vault.CABundleSecretRef.Key = "missing"
+	r.reconcileCABundle(context.Background(), vu, now)
+	require.NotNil(t, conditionFor(ConditionTypeCABundleInvalid))
+	assert.Contains(t, conditionFor(ConditionTypeCABundleInvalid).Message, "missing")
+	assert.Nil(t, conditionFor(ConditionTypeCAExpiring))
+
+	vu.Spec.Vault.CABundleSecretRef = nil
+	r.reconcileCABundle(context.Background(), vu, now)
+	assert.Empty(t, vu.Status.Conditions)
+}
+
+func TestReconcileCABundle_CrossNamespaceNeedsGrant(t *testing.T) {
+	now := time.Now()
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "pki"}}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-ca", Namespace: "pki"},
+		Data:       map[string][]byte{"ca.crt": newTestCA(t, "root", now.Add(365*24*time.Hour))},
+	}
+	r := newFakeReconciler(t, namespace, secret)
+	r.SecretsLoader = secrets.NewLoader(r.Client).RequireGrants()
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+	vu := newFinalizerTestUnsealer().WithCABundleSecret("vault-ca", "ca.crt")
+	vu.Spec.Vault.CABundleSecretRef.Namespace = "pki"
+
+	r.reconcileCABundle(context.Background(), vu, now)
+	condition := findCondition(vu, ConditionTypeCABundleInvalid)
+	require.NotNil(t, condition)
+	assert.Equal(t, ReasonSecretAccessDenied, condition.Reason)
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, ReasonSecretAccessDenied)
+	_, err := r.getTLSConfig(context.Background(), vu)
+	assert.ErrorIs(t, err, secrets.ErrAccessNotGranted)
+
+	namespace.Annotations = map[string]string{opsv1alpha1.AnnotationAllowedUnsealerNamespaces: "vault"}
+	require.NoError(t, r.Update(context.Background(), namespace))
+	r.reconcileCABundle(context.Background(), vu, now)
+	assert.Nil(t, findCondition(vu, ConditionTypeCABundleInvalid))
+}
+
+func TestGetTLSConfig_MultipleBundles(t *testing.T) {
+	now := time.Now()
+	oldCA, newCA := newTestCA(t, "old", now.Add(time.Hour)), newTestCA(t, "new", now.Add(time.Hour))
+	r := newFakeReconciler(t,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "vault-ca-old", Namespace: "vault"},
+			Data:       map[string][]byte{"ca.crt": oldCA},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "vault-ca-new", Namespace: "vault"},
+			Data:       map[string][]byte{"ca.crt": newCA},
+		},
+	)
+	vu := newFinalizerTestUnsealer().
+		WithCABundleSecret("vault-ca-old", "ca.crt").
+		WithCABundleSecrets(opsv1alpha1.SecretRef{Name: "vault-ca-new", Key: "ca.crt"})
+
+	tlsConfig, err := r.getTLSConfig(context.Background(), vu)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	for _, bundle := range [][]byte{oldCA, newCA} {
+		require.True(t, pool.AppendCertsFromPEM(bundle))
+	}
+	assert.True(t, pool.Equal(tlsConfig.RootCAs))
+
+	vu.WithCABundleSecrets(opsv1alpha1.SecretRef{Name: "vault-ca-missing", Key: "ca.crt"})
+	_, err = r.getTLSConfig(context.Background(), vu)
+	assert.ErrorContains(t, err, "vault/vault-ca-missing")
+}
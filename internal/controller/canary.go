@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// canaryGate holds back keys from most sealed pods while spec.canary is
+// enabled and fewer than podCount pods have soaked. The canaries are kept in
+// status.canaryPods, so a canary that fails to unseal keeps its place and
+// blocks the rest instead of handing its slot to the next pod.
+type canaryGate struct {
+	// cohort holds the canaries that have not soaked yet, including pods
+	// found unsealed by someone else.
+	cohort map[string]bool
+	// slots is how many more sealed pods may become canaries.
+	slots int
+	// soaked is how many pods have been unsealed and Ready for soakTime.
+	soaked, podCount int
+	soakTime         time.Duration
+}
+
+// newCanaryGate returns the gate for this reconcile, or nil when canary
+// unsealing is disabled or enough pods have soaked to unseal the rest.
+func (r *VaultUnsealerReconciler) newCanaryGate(vaultUnsealer *opsv1alpha1.VaultUnsealer, pods []corev1.Pod,
+	previousPods map[string]opsv1alpha1.PodStatus, now time.Time) *canaryGate {
+	canary := vaultUnsealer.Spec.Canary
+	if canary == nil || !canary.Enabled || vaultUnsealer.Spec.Mode.MonitorOnly {
+		return nil
+	}
+
+	gate := &canaryGate{cohort: make(map[string]bool), podCount: canary.Pods(), soakTime: canary.Soak()}
+	for i := range pods {
+		previous := previousPods[pods[i].Name]
+		unsealed := previous.State == opsv1alpha1.PodStateUnsealed
+		switch {
+		case unsealed && r.isPodReady(&pods[i]) &&
+			(previous.LastUnsealTime == nil || now.Sub(previous.LastUnsealTime.Time) >= gate.soakTime):
+			gate.soaked++
+		case unsealed || slices.Contains(vaultUnsealer.Status.CanaryPods, pods[i].Name):
+			gate.cohort[pods[i].Name] = true
+		}
+	}
+	if gate.soaked >= gate.podCount {
+		return nil
+	}
+	gate.slots = max(0, gate.podCount-gate.soaked-len(gate.cohort))
+	return gate
+}
+
+// allows reports whether keys may be submitted to pod. A nil gate allows
+// every pod.
+func (g *canaryGate) allows(pod string) bool {
+	return g == nil || g.cohort[pod] || g.slots > 0
+}
+
+// join makes pod a canary once it turns out to need keys, so pods found
+// already unsealed do not use up a slot.
+func (g *canaryGate) join(pod string) {
+	if g == nil || g.cohort[pod] {
+		return
+	}
+	g.slots--
+	g.cohort[pod] = true
+}
+
+// message describes what the held pods are waiting for.
+func (g *canaryGate) message() string {
+	return fmt.Sprintf("Waiting for %d canary pods to stay unsealed and Ready for %s, %d have", g.podCount, g.soakTime, g.soaked)
+}
+
+// reconcileCanary records the canaries in status.canaryPods and reports the
+// pods held back in the CanaryInProgress condition.
+func (r *VaultUnsealerReconciler) reconcileCanary(vaultUnsealer *opsv1alpha1.VaultUnsealer, outcome unsealOutcome) {
+	vaultUnsealer.Status.CanaryPods = nil
+	if outcome.canary != nil {
+		for pod := range outcome.canary.cohort {
+			vaultUnsealer.Status.CanaryPods = append(vaultUnsealer.Status.CanaryPods, pod)
+		}
+		slices.Sort(vaultUnsealer.Status.CanaryPods)
+	}
+
+	if outcome.canaryHeld == 0 {
+		r.clearCondition(vaultUnsealer, ConditionTypeCanary)
+		return
+	}
+	r.setCondition(vaultUnsealer, ConditionTypeCanary, ConditionStatusTrue, ReasonCanarySoaking,
+		fmt.Sprintf("%s; %d sealed pods are held back", outcome.canary.message(), outcome.canaryHeld))
+}
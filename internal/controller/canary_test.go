@@ -0,0 +1,103 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+func TestReconcile_CanaryUnsealsOnePodFirst(t *testing.T) {
+	keys := []string{"key-1", "key-2"}
+	objs := []client.Object{&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": []byte("key-1\nkey-2\n")},
+	}}
+	var fakes []*vaultfake.Server
+	for i := range 3 {
+		fake := vaultfake.NewServer(vaultfake.WithKeys(keys, 2))
+		defer fake.Close()
+		fakes = append(fakes, fake)
+		objs = append(objs, newRolloutPod(fake, fmt.Sprintf("vault-%d", i), "v1"))
+	}
+	vu := newFinalizerTestUnsealer().WithVaultURL("http://vault").
+		WithReplicaScope(opsv1alpha1.ReplicaScopeCluster).
+		WithCanary(1, 5*time.Minute)
+	r := newFakeReconciler(t, append(objs, vu)...)
+
+	got := reconcileAndGet(t, r)
+	assert.False(t, fakes[0].Sealed(), "the canary is unsealed")
+	for _, fake := range fakes[1:] {
+		assert.True(t, fake.Sealed())
+		assert.Zero(t, fake.UnsealRequests(), "held pods get no keys")
+	}
+	assertCondition(t, got, ConditionTypeCanary, ConditionStatusTrue, ReasonCanarySoaking)
+	assert.Contains(t, got.Status.Pods[1].Message, "Waiting for 1 canary pods")
+	assert.Equal(t, []string{"vault-0"}, got.Status.CanaryPods)
+
+	// The canary has not soaked yet, so the other pods stay held.
+	got = reconcileAndGet(t, r)
+	assert.True(t, fakes[1].Sealed())
+	assert.Zero(t, fakes[1].UnsealRequests())
+
+	// Once it has been unsealed for the soak time, the rest follow.
+	got.Status.Pods[0].LastUnsealTime = &metav1.Time{Time: time.Now().Add(-10 * time.Minute)}
+	require.NoError(t, r.Status().Update(t.Context(), got))
+	got = reconcileAndGet(t, r)
+	for _, fake := range fakes {
+		assert.False(t, fake.Sealed())
+	}
+	assert.Nil(t, findCondition(got, ConditionTypeCanary))
+	assert.Empty(t, got.Status.CanaryPods)
+}
+
+func TestReconcile_CanaryFailureBlocksRollout(t *testing.T) {
+	fakes := []*vaultfake.Server{
+		vaultfake.NewServer(vaultfake.WithKeys([]string{"other-1", "other-2"}, 2)),
+		vaultfake.NewServer(vaultfake.WithKeys([]string{"key-1", "key-2"}, 2)),
+	}
+	objs := []client.Object{&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": []byte("key-1\nkey-2\n")},
+	}}
+	for i, fake := range fakes {
+		defer fake.Close()
+		objs = append(objs, newRolloutPod(fake, fmt.Sprintf("vault-%d", i), "v1"))
+	}
+	vu := newFinalizerTestUnsealer().WithVaultURL("http://vault").
+		WithReplicaScope(opsv1alpha1.ReplicaScopeCluster).
+		WithCanary(1, time.Minute)
+	r := newFakeReconciler(t, append(objs, vu)...)
+
+	for range 2 {
+		got := reconcileAndGet(t, r)
+		assertCondition(t, got, ConditionTypeCanary, ConditionStatusTrue, ReasonCanarySoaking)
+		assert.Equal(t, []string{"vault-0"}, got.Status.CanaryPods)
+	}
+	assert.True(t, fakes[0].Sealed(), "the canary rejects the keys")
+	assert.Zero(t, fakes[1].UnsealRequests(), "the failing canary keeps its slot")
+}
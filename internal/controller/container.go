@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// containerStatus returns the status of the named container in pod.
+func containerStatus(pod *corev1.Pod, name string) (corev1.ContainerStatus, bool) {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == name {
+			return status, true
+		}
+	}
+	return corev1.ContainerStatus{}, false
+}
+
+// isContainerRunning reports whether the pod is reachable by IP and the named
+// container is running, whatever the state of its sidecars.
+func isContainerRunning(pod *corev1.Pod, name string) bool {
+	status, ok := containerStatus(pod, name)
+	return ok && isPodRunning(pod) && status.State.Running != nil
+}
+
+// isContainerReady reports whether the named container is running and passes
+// its readiness probe.
+func isContainerReady(pod *corev1.Pod, name string) bool {
+	status, _ := containerStatus(pod, name)
+	return isContainerRunning(pod, name) && status.Ready
+}
+
+// containerAPIPort returns the port of the named container's Vault API: the
+// one named http or https, or else the first it declares.
+func containerAPIPort(pod *corev1.Pod, name string) (int32, bool) {
+	for _, container := range pod.Spec.Containers {
+		if container.Name != name || len(container.Ports) == 0 {
+			continue
+		}
+		for _, port := range container.Ports {
+			if port.Name == "http" || port.Name == "https" {
+				return port.ContainerPort, true
+			}
+		}
+		return container.Ports[0].ContainerPort, true
+	}
+	return 0, false
+}
@@ -0,0 +1,44 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+)
+
+// minReconcileBudget is the shortest time a reconcile is given, so that a
+// break-glass expiry or a tiny interval cannot starve it.
+const minReconcileBudget = 10 * time.Second
+
+// withReconcileBudget bounds the Vault and Kubernetes calls of one reconcile
+// to the effective interval, so a slow target cannot push the reconcile past
+// its next scheduled run and pile up in the workqueue.
+func withReconcileBudget(ctx context.Context, interval time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, max(interval, minReconcileBudget))
+}
+
+// podContext gives the next pod an equal share of what is left of the
+// reconcile budget among the podsLeft pods still to be checked. Time a pod
+// does not use carries over to the ones after it.
+func podContext(ctx context.Context, podsLeft int) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok || podsLeft <= 1 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, time.Until(deadline)/time.Duration(podsLeft))
+}
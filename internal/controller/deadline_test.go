@@ -0,0 +1,92 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+func TestWithReconcileBudget(t *testing.T) {
+	ctx, cancel := withReconcileBudget(context.Background(), time.Minute)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+
+	short, cancelShort := withReconcileBudget(context.Background(), time.Second)
+	defer cancelShort()
+	deadline, _ = short.Deadline()
+	assert.WithinDuration(t, time.Now().Add(minReconcileBudget), deadline, time.Second, "budget has a floor")
+}
+
+func TestPodContext_SharesRemainingBudget(t *testing.T) {
+	budget, cancel := context.WithTimeout(context.Background(), 40*time.Second)
+	defer cancel()
+
+	podCtx, cancelPod := podContext(budget, 4)
+	defer cancelPod()
+	deadline, ok := podCtx.Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(10*time.Second), deadline, time.Second)
+
+	last, cancelLast := podContext(budget, 1)
+	defer cancelLast()
+	lastDeadline, _ := last.Deadline()
+	budgetDeadline, _ := budget.Deadline()
+	assert.Equal(t, budgetDeadline, lastDeadline, "the last pod gets everything that is left")
+
+	unbounded, cancelUnbounded := podContext(context.Background(), 3)
+	defer cancelUnbounded()
+	_, ok = unbounded.Deadline()
+	assert.False(t, ok)
+}
+
+func TestCheckAndUnsealPod_HonoursDeadline(t *testing.T) {
+	release := make(chan struct{})
+	hanging := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		<-release
+	}))
+	defer hanging.Close()
+	defer close(release)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-0", Namespace: "vault"},
+		Status:     corev1.PodStatus{PodIP: strings.TrimPrefix(hanging.URL, "http://")},
+	}
+	vu := opsv1alpha1.NewVaultUnsealer("vault", "main").WithVaultURL("http://vault")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	r := &VaultUnsealerReconciler{}
+	result, err := r.checkAndUnsealPod(ctx, pod, vu, newKeys("key-1"), nil)
+	require.Error(t, err)
+	assert.True(t, result.sealed)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
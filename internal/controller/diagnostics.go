@@ -0,0 +1,87 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// PodDiagnostic is a point-in-time view of a single Vault pod targeted by a VaultUnsealer.
+type PodDiagnostic struct {
+	Name     string `json:"name"`
+	PodIP    string `json:"podIP,omitempty"`
+	Phase    string `json:"phase"`
+	Ready    bool   `json:"ready"`
+	Sealed   *bool  `json:"sealed,omitempty"`
+	Progress int    `json:"progress,omitempty"`
+	T        int    `json:"t,omitempty"`
+	N        int    `json:"n,omitempty"`
+	Version  string `json:"version,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Diagnose queries the live seal status of every pod matched by the VaultUnsealer
+// without submitting any keys.
+func (r *VaultUnsealerReconciler) Diagnose(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) ([]PodDiagnostic, error) {
+	pods, err := r.getVaultPods(ctx, vaultUnsealer)
+	if err != nil {
+		return nil, err
+	}
+
+	diagnostics := make([]PodDiagnostic, 0, len(pods))
+	for i := range pods {
+		pod := &pods[i]
+		diag := PodDiagnostic{
+			Name:  pod.Name,
+			PodIP: pod.Status.PodIP,
+			Phase: string(pod.Status.Phase),
+			Ready: r.isPodReady(pod),
+		}
+
+		if pod.Status.PodIP == "" {
+			diag.Error = "pod has no IP address"
+			diagnostics = append(diagnostics, diag)
+			continue
+		}
+
+		vaultClient, err := r.createVaultClient(ctx, pod, vaultUnsealer)
+		if err != nil {
+			diag.Error = err.Error()
+			diagnostics = append(diagnostics, diag)
+			continue
+		}
+
+		status, err := vaultClient.GetSealStatus(ctx)
+		if err != nil {
+			diag.Error = err.Error()
+			diagnostics = append(diagnostics, diag)
+			continue
+		}
+
+		sealed := status.Sealed
+		diag.Sealed = &sealed
+		diag.Progress = status.Progress
+		diag.T = status.T
+		diag.N = status.N
+		diag.Version = status.Version
+		diagnostics = append(diagnostics, diag)
+	}
+
+	return diagnostics, nil
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/package controller
+
+import (
+	"context"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/vault"
+)
+
+type resolverKey struct{}
+
+// withResolver stores the resolver for spec.vault.dns in ctx, so every Vault
+// client created while handling the reconcile shares its cached lookups.
+func withResolver(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) context.Context {
+	resolver := newResolver(vaultUnsealer)
+	if resolver == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, resolverKey{}, resolver)
+}
+
+// resolverFor returns the resolver stored in ctx, or a new one outside a
+// reconcile. It is nil when spec.vault.dns leaves resolution to the system.
+func resolverFor(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) *vault.Resolver {
+	if resolver, ok := ctx.Value(resolverKey{}).(*vault.Resolver); ok {
+		return resolver
+	}
+	return newResolver(vaultUnsealer)
+}
+
+func newResolver(vaultUnsealer *opsv1alpha1.VaultUnsealer) *vault.Resolver {
+	dns := vaultUnsealer.Spec.Vault.DNS
+	if dns == nil {
+		return nil
+	}
+	perReconcile := dns.Resolution == opsv1alpha1.DNSResolutionPerReconcile
+	if dns.Resolver == "" && !perReconcile {
+		return nil
+	}
+	return vault.NewResolver(dns.Resolver, perReconcile)
+}
@@ -0,0 +1,42 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+func TestResolverFor(t *testing.T) {
+	vu := newFinalizerTestUnsealer()
+	assert.Nil(t, resolverFor(withResolver(context.Background(), vu), vu), "the system resolver is used by default")
+
+	vu.Spec.Vault.DNS = &opsv1alpha1.DNSSpec{Resolution: opsv1alpha1.DNSResolutionPerAttempt}
+	assert.Nil(t, resolverFor(context.Background(), vu))
+
+	vu.Spec.Vault.DNS = &opsv1alpha1.DNSSpec{Resolution: opsv1alpha1.DNSResolutionPerReconcile}
+	ctx := withResolver(context.Background(), vu)
+	resolver := resolverFor(ctx, vu)
+	assert.NotNil(t, resolver)
+	assert.Same(t, resolver, resolverFor(ctx, vu), "clients of one reconcile share the resolver")
+	assert.NotSame(t, resolver, resolverFor(context.Background(), vu))
+
+	vu.Spec.Vault.DNS = &opsv1alpha1.DNSSpec{Resolver: "10.96.0.10"}
+	assert.NotNil(t, resolverFor(context.Background(), vu))
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// effectiveConfig resolves the settings the controller applies to
+// vaultUnsealer. keyThreshold is the number of keys prepared for submission,
+// which is only known once the keys are loaded.
+func effectiveConfig(vaultUnsealer *opsv1alpha1.VaultUnsealer, interval, fastInterval time.Duration, keyThreshold int) *opsv1alpha1.EffectiveConfig {
+	config := &opsv1alpha1.EffectiveConfig{
+		Interval:        metav1.Duration{Duration: interval},
+		FastInterval:    metav1.Duration{Duration: fastInterval},
+		KeyThreshold:    keyThreshold,
+		Strategy:        opsv1alpha1.StrategyHA,
+		ReplicaScope:    vaultUnsealer.Spec.Mode.ReplicaScope(),
+		ReadinessPolicy: readinessPolicy(vaultUnsealer),
+		AddressingMode:  opsv1alpha1.AddressingModePodIP,
+		RequirePodReady: vaultUnsealer.Spec.RequirePodReady == nil || *vaultUnsealer.Spec.RequirePodReady,
+		ErrorPolicy:     errorPolicy(vaultUnsealer),
+		Priority:        opsv1alpha1.PriorityNormal,
+	}
+	if vaultUnsealer.Spec.Priority != "" {
+		config.Priority = vaultUnsealer.Spec.Priority
+	}
+	if vaultUnsealer.Spec.Mode.StopsAfterFirstUnseal() {
+		config.Strategy = opsv1alpha1.StrategySingle
+	}
+	if vaultUnsealer.Spec.Mode.MonitorOnly {
+		config.Strategy = opsv1alpha1.StrategyMonitorOnly
+	}
+	if vaultUnsealer.Spec.Vault.PerPodHostTemplate != "" {
+		config.AddressingMode = opsv1alpha1.AddressingModePerPodHost
+	}
+	if vaultUnsealer.Spec.Vault.MeshSidecar != nil {
+		config.AddressingMode = opsv1alpha1.AddressingModeMeshSidecar
+	}
+	if vaultUnsealer.Spec.Vault.APIServerProxy {
+		config.AddressingMode = opsv1alpha1.AddressingModeAPIServerProxy
+	}
+	return config
+}
+
+// previousKeyThreshold is the key threshold last recorded in status, so
+// reconciles that stop before loading keys do not reset it. It falls back to
+// spec.keyThreshold.
+func previousKeyThreshold(vaultUnsealer *opsv1alpha1.VaultUnsealer, original *opsv1alpha1.VaultUnsealerStatus) int {
+	if original.EffectiveConfig != nil && original.EffectiveConfig.KeyThreshold > 0 {
+		return original.EffectiveConfig.KeyThreshold
+	}
+	return vaultUnsealer.Spec.KeyThreshold
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+func TestEffectiveConfig(t *testing.T) {
+	vu := newFinalizerTestUnsealer()
+	config := effectiveConfig(vu, time.Minute, time.Minute, 0)
+	assert.Equal(t, &opsv1alpha1.EffectiveConfig{
+		Interval:        metav1.Duration{Duration: time.Minute},
+		FastInterval:    metav1.Duration{Duration: time.Minute},
+		Strategy:        opsv1alpha1.StrategyHA,
+		ReplicaScope:    opsv1alpha1.ReplicaScopeCluster,
+		ReadinessPolicy: opsv1alpha1.ReadinessPolicyAnyPod,
+		AddressingMode:  opsv1alpha1.AddressingModePodIP,
+		RequirePodReady: true,
+		ErrorPolicy:     opsv1alpha1.ErrorPolicyContinueOtherPods,
+		Priority:        opsv1alpha1.PriorityNormal,
+	}, config)
+
+	vu.WithHA(true).WithRequirePodReady(false)
+	vu.Spec.ReadinessPolicy = opsv1alpha1.ReadinessPolicyQuorum
+	vu.Spec.Vault.PerPodHostTemplate = "vault-{{ .Ordinal }}.example.com"
+	config = effectiveConfig(vu, time.Minute, 15*time.Second, 3)
+	assert.Equal(t, opsv1alpha1.StrategyHA, config.Strategy)
+	assert.Equal(t, opsv1alpha1.ReadinessPolicyQuorum, config.ReadinessPolicy)
+	assert.Equal(t, opsv1alpha1.AddressingModePerPodHost, config.AddressingMode)
+
+	vu.Spec.Vault.MeshSidecar = &opsv1alpha1.MeshSidecarSpec{Port: 15001}
+	config = effectiveConfig(vu, time.Minute, 15*time.Second, 3)
+	assert.Equal(t, opsv1alpha1.AddressingModeMeshSidecar, config.AddressingMode)
+	assert.False(t, config.RequirePodReady)
+	assert.Equal(t, 3, config.KeyThreshold)
+	assert.Equal(t, 15*time.Second, config.FastInterval.Duration)
+
+	vu.Spec.Vault.APIServerProxy = true
+	config = effectiveConfig(vu, time.Minute, 15*time.Second, 3)
+	assert.Equal(t, opsv1alpha1.AddressingModeAPIServerProxy, config.AddressingMode)
+}
+
+func TestEffectiveConfig_ModeConversion(t *testing.T) {
+	tests := []struct {
+		name         string
+		mode         opsv1alpha1.ModeSpec
+		wantScope    string
+		wantStrategy string
+	}{
+		{"legacy ha", opsv1alpha1.ModeSpec{HA: ptr.To(true)}, opsv1alpha1.ReplicaScopeCluster, opsv1alpha1.StrategyHA},
+		{"legacy non-ha", opsv1alpha1.ModeSpec{HA: ptr.To(false)}, opsv1alpha1.ReplicaScopeSingle, opsv1alpha1.StrategySingle},
+		{"empty mode", opsv1alpha1.ModeSpec{}, opsv1alpha1.ReplicaScopeCluster, opsv1alpha1.StrategyHA},
+		{"scope alone never stops early", opsv1alpha1.ModeSpec{Scope: opsv1alpha1.ReplicaScopeSingle}, opsv1alpha1.ReplicaScopeSingle, opsv1alpha1.StrategyHA},
+		{"explicit stop with cluster scope", opsv1alpha1.ModeSpec{Scope: opsv1alpha1.ReplicaScopeCluster, StopAfterFirstUnseal: ptr.To(true)},
+			opsv1alpha1.ReplicaScopeCluster, opsv1alpha1.StrategySingle},
+		{"explicit stop overrides ha", opsv1alpha1.ModeSpec{HA: ptr.To(true), StopAfterFirstUnseal: ptr.To(true)}, opsv1alpha1.ReplicaScopeCluster, opsv1alpha1.StrategySingle},
+		{"explicit no stop overrides non-ha", opsv1alpha1.ModeSpec{HA: ptr.To(false), StopAfterFirstUnseal: ptr.To(false)}, opsv1alpha1.ReplicaScopeSingle, opsv1alpha1.StrategyHA},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vu := newFinalizerTestUnsealer()
+			vu.Spec.Mode = tt.mode
+			config := effectiveConfig(vu, time.Minute, time.Minute, 0)
+			assert.Equal(t, tt.wantScope, config.ReplicaScope)
+			assert.Equal(t, tt.wantStrategy, config.Strategy)
+		})
+	}
+}
+
+func TestReconcile_RecordsEffectiveConfig(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "vault-0", Namespace: "vault", Labels: map[string]string{"app": "vault"}}}
+	keysJSON, err := json.Marshal([]string{"key-1", "key-2", "key-3"})
+	require.NoError(t, err)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": keysJSON},
+	}
+	vu := newFinalizerTestUnsealer().WithInterval(time.Second)
+	r := newFakeReconciler(t, vu, pod, secret)
+
+	got := reconcileAndGet(t, r)
+	require.NotNil(t, got.Status.EffectiveConfig)
+	assert.Equal(t, opsv1alpha1.DefaultMinInterval, got.Status.EffectiveConfig.Interval.Duration, "interval is clamped")
+	assert.Equal(t, 3, got.Status.EffectiveConfig.KeyThreshold, "every loaded key without spec.keyThreshold")
+
+	// A paused reconcile keeps the threshold derived from the loaded keys.
+	got.Annotations = map[string]string{opsv1alpha1.AnnotationPaused: "true"}
+	require.NoError(t, r.Update(t.Context(), got))
+	got = reconcileAndGet(t, r)
+	assert.Equal(t, 3, got.Status.EffectiveConfig.KeyThreshold)
+}
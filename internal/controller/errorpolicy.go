@@ -0,0 +1,47 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// errorPolicy returns the effective error policy.
+func errorPolicy(vaultUnsealer *opsv1alpha1.VaultUnsealer) string {
+	if vaultUnsealer.Spec.ErrorPolicy == "" {
+		return opsv1alpha1.ErrorPolicyContinueOtherPods
+	}
+	return vaultUnsealer.Spec.ErrorPolicy
+}
+
+// uncheckedPodStatuses carries over the last known status of pods a
+// reconcile did not get to, so they do not disappear from status. message
+// says why they were not checked.
+func uncheckedPodStatuses(pods []corev1.Pod, previousPods map[string]opsv1alpha1.PodStatus, message string) []opsv1alpha1.PodStatus {
+	statuses := make([]opsv1alpha1.PodStatus, 0, len(pods))
+	for _, pod := range pods {
+		status, ok := previousPods[pod.Name]
+		if !ok {
+			status = opsv1alpha1.PodStatus{Name: pod.Name, State: opsv1alpha1.PodStateUnknown}
+		}
+		status.Message = message
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+func TestReconcile_ErrorPolicy(t *testing.T) {
+	for _, tt := range []struct {
+		policy       string
+		wantUnsealed bool
+	}{
+		{policy: "", wantUnsealed: true},
+		{policy: opsv1alpha1.ErrorPolicyAbortReconcile, wantUnsealed: false},
+	} {
+		t.Run(errorPolicy(&opsv1alpha1.VaultUnsealer{Spec: opsv1alpha1.VaultUnsealerSpec{ErrorPolicy: tt.policy}}), func(t *testing.T) {
+			keys := []string{"key-1"}
+			fake := vaultfake.NewServer(vaultfake.WithKeys(keys, 1))
+			defer fake.Close()
+
+			// vault-0 refuses connections, vault-1 is sealed and reachable.
+			newPod := func(name, address string) *corev1.Pod {
+				return &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "vault", Labels: map[string]string{"app": "vault"}},
+					Status: corev1.PodStatus{
+						PodIP:      address,
+						Phase:      corev1.PodRunning,
+						Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+					},
+				}
+			}
+			keysJSON, err := json.Marshal(keys)
+			require.NoError(t, err)
+			objs := []client.Object{
+				newPod("vault-0", "127.0.0.1:1"),
+				newPod("vault-1", strings.TrimPrefix(fake.URL(), "http://")),
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+					Data:       map[string][]byte{"keys.json": keysJSON},
+				},
+				newFinalizerTestUnsealer().WithVaultURL("http://vault").WithHA(true).WithErrorPolicy(tt.policy),
+			}
+
+			got := reconcileAndGet(t, newFakeReconciler(t, objs...))
+			assert.Equal(t, !tt.wantUnsealed, fake.Sealed())
+			require.Len(t, got.Status.Pods, 2)
+			if !tt.wantUnsealed {
+				assert.Equal(t, []string{"vault-0"}, got.Status.PodsChecked)
+				assert.Contains(t, got.Status.Pods[1].Message, "reconcile aborted")
+			}
+		})
+	}
+}
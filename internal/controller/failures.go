@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"regexp"
+	"strings"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// maxLastErrorLength bounds status.lastError, since Vault API errors can
+// carry a whole response body.
+const maxLastErrorLength = 512
+
+// urlUserinfo matches the userinfo of a URL, which may hold credentials.
+var urlUserinfo = regexp.MustCompile(`://[^/@\s]+@`)
+
+// reconcileFailure returns the error a reconcile that ran its phases ended
+// in, or "" when it completed without one. Pod discovery and pod failures
+// are retried without returning an error, so they are read from conditions.
+func reconcileFailure(vaultUnsealer *opsv1alpha1.VaultUnsealer, reconcileErr error) string {
+	if reconcileErr != nil {
+		return reconcileErr.Error()
+	}
+	if condition := findCondition(vaultUnsealer, ConditionTypeTargetsDiscovered); condition != nil && condition.Reason == ReasonPodDiscoveryFailed {
+		return condition.Message
+	}
+	if condition := findCondition(vaultUnsealer, ConditionTypeVaultAPIFailure); condition != nil && condition.Status == ConditionStatusTrue {
+		return condition.Message
+	}
+	return ""
+}
+
+// recordReconcileFailure updates status.consecutiveFailures and
+// status.lastError with the outcome of a reconcile.
+func recordReconcileFailure(status *opsv1alpha1.VaultUnsealerStatus, failure string) {
+	if failure == "" {
+		status.ConsecutiveFailures = 0
+		return
+	}
+	status.ConsecutiveFailures++
+	status.LastError = sanitizeError(failure)
+}
+
+// sanitizeError puts message on one line, removes credentials embedded in
+// URLs and truncates it to maxLastErrorLength.
+func sanitizeError(message string) string {
+	message = strings.Join(strings.Fields(message), " ")
+	message = urlUserinfo.ReplaceAllString(message, "://REDACTED@")
+	if len(message) > maxLastErrorLength {
+		message = strings.ToValidUTF8(message[:maxLastErrorLength-3], "") + "..."
+	}
+	return message
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/metrics"
+)
+
+func newFakeReconciler(t *testing.T, objs ...client.Object) *VaultUnsealerReconciler {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, opsv1alpha1.AddToScheme(scheme))
+	return &VaultUnsealerReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+			WithStatusSubresource(&opsv1alpha1.VaultUnsealer{}).Build(),
+		Scheme: scheme,
+	}
+}
+
+func newFinalizerTestUnsealer() *opsv1alpha1.VaultUnsealer {
+	return opsv1alpha1.NewVaultUnsealer("vault", "main").
+		WithVaultURL("http://vault:8200").
+		WithUnsealKeysSecret("vault-keys", "keys.json").
+		WithLabelSelector("app=vault")
+}
+
+func reconcileAndGet(t *testing.T, r *VaultUnsealerReconciler) *opsv1alpha1.VaultUnsealer {
+	key := types.NamespacedName{Namespace: "vault", Name: "main"}
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+
+	vu := &opsv1alpha1.VaultUnsealer{}
+	require.NoError(t, r.Get(context.Background(), key, vu))
+	return vu
+}
+
+func TestReconcile_FinalizerDisabledByDefault(t *testing.T) {
+	r := newFakeReconciler(t, newFinalizerTestUnsealer())
+	vu := reconcileAndGet(t, r)
+	assert.NotContains(t, vu.Finalizers, VaultUnsealerFinalizer)
+}
+
+func TestReconcile_FinalizerEnabled(t *testing.T) {
+	r := newFakeReconciler(t, newFinalizerTestUnsealer())
+	r.EnableFinalizer = true
+	vu := reconcileAndGet(t, r)
+	assert.Contains(t, vu.Finalizers, VaultUnsealerFinalizer)
+}
+
+func TestReconcile_RemovesLeftoverFinalizer(t *testing.T) {
+	vu := newFinalizerTestUnsealer()
+	vu.Finalizers = []string{VaultUnsealerFinalizer}
+	r := newFakeReconciler(t, vu)
+
+	vu = reconcileAndGet(t, r)
+	assert.NotContains(t, vu.Finalizers, VaultUnsealerFinalizer)
+}
+
+func TestOnDelete_CleansUpMetrics(t *testing.T) {
+	vu := opsv1alpha1.NewVaultUnsealer("vault", "deleted")
+	vu.Status.PodsChecked = []string{"vault-0"}
+	before := testutil.CollectAndCount(metrics.PodsChecked) + testutil.CollectAndCount(metrics.VaultConnectionStatus)
+	metrics.PodsChecked.WithLabelValues("deleted", "vault").Set(1)
+	metrics.VaultConnectionStatus.WithLabelValues("deleted", "vault", "vault-0").Set(1)
+
+	(&VaultUnsealerReconciler{}).onDelete(context.Background(), event.DeleteEvent{Object: vu}, nil)
+
+	after := testutil.CollectAndCount(metrics.PodsChecked) + testutil.CollectAndCount(metrics.VaultConnectionStatus)
+	assert.Equal(t, before, after)
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/metrics"
+)
+
+// GlobalPauseKey is the entry of the operator ConfigMap that pauses key
+// submission for every VaultUnsealer while it is "true".
+const GlobalPauseKey = "globalPause"
+
+// GlobalPause is the operator-wide kill switch for emergency response. While
+// it is engaged no key share is submitted to any Vault pod, including by
+// reconciles already in progress, which stop before their next submission.
+// Reconciles keep running and report the Paused condition.
+type GlobalPause struct {
+	// Forced engages the switch for the life of the process, as
+	// --global-pause does.
+	Forced bool
+
+	// Reader reads ConfigMap. The API reader is used so that no cluster-wide
+	// ConfigMap informer is needed.
+	Reader client.Reader
+	// ConfigMap holds GlobalPauseKey. An empty name disables it.
+	ConfigMap types.NamespacedName
+	// Interval is how often ConfigMap is read. Defaults to 5s.
+	Interval time.Duration
+
+	// Reconciler, when set, has every VaultUnsealer requeued when the switch
+	// flips, so their status follows at once.
+	Reconciler *VaultUnsealerReconciler
+
+	engaged atomic.Bool
+}
+
+// Engaged reports whether key submission is paused operator-wide.
+func (g *GlobalPause) Engaged() bool {
+	if g == nil {
+		return false
+	}
+	return g.Forced || g.engaged.Load()
+}
+
+// NeedLeaderElection keeps the switch current on every replica, so a new
+// leader honours it from its first reconcile.
+func (g *GlobalPause) NeedLeaderElection() bool {
+	return false
+}
+
+// Start reads ConfigMap until ctx is cancelled.
+func (g *GlobalPause) Start(ctx context.Context) error {
+	metrics.GlobalPause.Set(boolToFloat(g.Engaged()))
+	if g.ConfigMap.Name == "" {
+		return nil
+	}
+	interval := g.Interval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	g.refresh(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			g.refresh(ctx)
+		}
+	}
+}
+
+// refresh reads ConfigMap and requeues every VaultUnsealer if the switch
+// flipped. A read error keeps the last known state.
+func (g *GlobalPause) refresh(ctx context.Context) {
+	log := logf.FromContext(ctx).WithName("global-pause").WithValues("configmap", g.ConfigMap.String())
+
+	engaged := false
+	configMap := &corev1.ConfigMap{}
+	if err := g.Reader.Get(ctx, g.ConfigMap, configMap); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "Failed to read global pause ConfigMap, keeping the current state", "engaged", g.engaged.Load())
+			return
+		}
+	} else if value, ok := configMap.Data[GlobalPauseKey]; ok {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			// A kill switch that silently fails to engage is worse than one
+			// that engages on a typo.
+			log.Error(err, "Invalid global pause value, pausing", "value", value)
+			parsed = true
+		}
+		engaged = parsed
+	}
+
+	if g.engaged.Swap(engaged) == engaged {
+		return
+	}
+	metrics.GlobalPause.Set(boolToFloat(g.Engaged()))
+	if engaged {
+		log.Info("Global pause engaged, no keys will be submitted")
+	} else {
+		log.Info("Global pause lifted")
+	}
+	// The wakeup channel is only drained once this replica leads, so do not
+	// hold up the next read waiting for it.
+	go g.requeueAll(ctx)
+}
+
+// requeueAll queues a reconcile of every VaultUnsealer.
+func (g *GlobalPause) requeueAll(ctx context.Context) {
+	if g.Reconciler == nil {
+		return
+	}
+	list := &opsv1alpha1.VaultUnsealerList{}
+	if err := g.Reconciler.List(ctx, list); err != nil {
+		logf.FromContext(ctx).WithName("global-pause").Error(err, "Failed to list VaultUnsealers")
+		return
+	}
+	for i := range list.Items {
+		g.Reconciler.requestReconcile(ctx, &list.Items[i])
+	}
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+func TestGlobalPause_ReadsConfigMap(t *testing.T) {
+	ctx := context.Background()
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "vault-unsealer-system", Name: "vault-unsealer-config"},
+		Data:       map[string]string{GlobalPauseKey: "true"},
+	}
+	r := newFakeReconciler(t, configMap)
+	pause := &GlobalPause{Reader: r.Client, ConfigMap: types.NamespacedName{Namespace: "vault-unsealer-system", Name: "vault-unsealer-config"}}
+
+	pause.refresh(ctx)
+	assert.True(t, pause.Engaged())
+
+	configMap.Data[GlobalPauseKey] = "false"
+	require.NoError(t, r.Update(ctx, configMap))
+	pause.refresh(ctx)
+	assert.False(t, pause.Engaged())
+
+	// A value that does not parse pauses rather than being ignored.
+	configMap.Data[GlobalPauseKey] = "yes please"
+	require.NoError(t, r.Update(ctx, configMap))
+	pause.refresh(ctx)
+	assert.True(t, pause.Engaged())
+
+	require.NoError(t, r.Delete(ctx, configMap))
+	pause.refresh(ctx)
+	assert.False(t, pause.Engaged())
+
+	pause.Forced = true
+	assert.True(t, pause.Engaged())
+	assert.False(t, (*GlobalPause)(nil).Engaged())
+}
+
+func TestReconcile_GlobalPauseStopsKeySubmission(t *testing.T) {
+	fake := vaultfake.NewServer(vaultfake.WithKeys([]string{"key-1"}, 1))
+	defer fake.Close()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": []byte("key-1\n")},
+	}
+	r := newFakeReconciler(t, newFinalizerTestUnsealer().WithVaultURL("http://vault"), newRolloutPod(fake, "vault-0", "v1"), secret)
+	r.GlobalPause = &GlobalPause{Forced: true}
+
+	got := reconcileAndGet(t, r)
+	assertCondition(t, got, ConditionTypePaused, ConditionStatusTrue, ReasonPausedGlobally)
+	assert.Zero(t, fake.UnsealRequests())
+	assert.True(t, fake.Sealed())
+
+	// A reconcile already past the pause check still submits nothing.
+	pod, vu := newFakeVaultPod(fake)
+	result, err := r.checkAndUnsealPod(context.Background(), pod, vu, newKeys("key-1"), nil)
+	require.NoError(t, err)
+	assert.True(t, result.sealed)
+	assert.Zero(t, fake.UnsealRequests())
+
+	r.GlobalPause.Forced = false
+	got = reconcileAndGet(t, r)
+	assert.Nil(t, findCondition(got, ConditionTypePaused))
+	assert.False(t, fake.Sealed())
+}
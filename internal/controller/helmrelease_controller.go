@@ -0,0 +1,238 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// Labels and layout of the HashiCorp Vault Helm chart.
+const (
+	helmLabelChart     = "helm.sh/chart"
+	helmLabelInstance  = "app.kubernetes.io/instance"
+	helmLabelManagedBy = "app.kubernetes.io/managed-by"
+	helmLabelComponent = "component"
+	helmLabelInternal  = "vault-internal"
+
+	// helmConfigKey holds the server configuration rendered from the
+	// chart's server.standalone.config or server.ha.*.config values.
+	helmConfigKey = "extraconfig-from-values.hcl"
+	// helmUserConfigDir is where the chart mounts server.extraVolumes.
+	helmUserConfigDir = "/vault/userconfig/"
+	// helmVaultContainer is the name of the chart's server container.
+	helmVaultContainer   = "vault"
+	defaultHelmVaultPort = "8200"
+)
+
+var tlsDisabledPattern = regexp.MustCompile(`tls_disable\s*=\s*"?(1|true)"?`)
+
+// HelmReleaseDiscoveryReconciler provisions a VaultUnsealer for every Vault
+// server StatefulSet installed by the HashiCorp Vault Helm chart, inferring
+// the Vault address and TLS settings from the release.
+type HelmReleaseDiscoveryReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Shard, when set, restricts discovery to the StatefulSets assigned to
+	// one shard, so only one replica provisions each VaultUnsealer.
+	Shard *Shard
+}
+
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch
+// The ConfigMap and Service markers are in internal/rbac/helmdiscovery.
+
+func (r *HelmReleaseDiscoveryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	var statefulSet appsv1.StatefulSet
+	if err := r.Get(ctx, req.NamespacedName, &statefulSet); err != nil {
+		// Owner references garbage-collect the VaultUnsealer on deletion.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !statefulSet.DeletionTimestamp.IsZero() || !isVaultHelmRelease(&statefulSet) || !ownedBy(r.Shard, &statefulSet) {
+		return ctrl.Result{}, nil
+	}
+
+	spec, err := discoveredSpec(&statefulSet)
+	if err != nil {
+		log.Error(err, "Invalid discovery annotations on StatefulSet")
+		return ctrl.Result{}, nil
+	}
+	if _, ok := statefulSet.Annotations[opsv1alpha1.AnnotationVaultURL]; !ok {
+		connection, err := r.helmConnection(ctx, &statefulSet)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		spec.Vault = connection
+	}
+	return ctrl.Result{}, provisionDiscovered(ctx, r.Client, r.Scheme, &statefulSet, spec)
+}
+
+// isVaultHelmRelease reports whether obj is a Vault server StatefulSet
+// installed by the Vault Helm chart. The chart sets the release labels on the
+// StatefulSet itself, but helm.sh/chart and the server component only on its
+// pod template. StatefulSets that opted into label discovery are left to
+// VaultDiscoveryReconciler.
+func isVaultHelmRelease(obj client.Object) bool {
+	statefulSet, ok := obj.(*appsv1.StatefulSet)
+	if !ok {
+		return false
+	}
+	podLabels := statefulSet.Spec.Template.Labels
+	return statefulSet.Labels[helmLabelManagedBy] == "Helm" &&
+		statefulSet.Labels[helmLabelInstance] != "" &&
+		strings.HasPrefix(podLabels[helmLabelChart], "vault-") &&
+		podLabels[helmLabelComponent] == "server" &&
+		!discoveryEnabled(obj)
+}
+
+// helmConnection infers how to reach the release's Vault pods: the scheme
+// from the chart's listener configuration, the CA from the VAULT_CACERT
+// Secret mount and per-pod addresses through the internal headless Service.
+func (r *HelmReleaseDiscoveryReconciler) helmConnection(ctx context.Context, statefulSet *appsv1.StatefulSet) (opsv1alpha1.VaultConnectionSpec, error) {
+	var connection opsv1alpha1.VaultConnectionSpec
+
+	tlsEnabled, err := r.helmTLSEnabled(ctx, statefulSet)
+	if err != nil {
+		return connection, err
+	}
+	scheme := "http"
+	if tlsEnabled {
+		scheme = "https"
+		connection.CABundleSecretRef = helmCABundleRef(statefulSet)
+	}
+
+	service, err := r.helmInternalService(ctx, statefulSet)
+	if err != nil {
+		return connection, err
+	}
+	if service == nil {
+		connection.Address = scheme + "://vault:" + defaultHelmVaultPort
+		return connection, nil
+	}
+	port := defaultHelmVaultPort
+	for _, servicePort := range service.Spec.Ports {
+		if servicePort.Name == "http" || servicePort.Name == "https" {
+			port = fmt.Sprint(servicePort.Port)
+			break
+		}
+	}
+	connection.Address = fmt.Sprintf("%s://%s.%s.svc:%s", scheme, service.Name, service.Namespace, port)
+	connection.PerPodHostTemplate = fmt.Sprintf("{{.PodName}}.%s.{{.Namespace}}.svc", service.Name)
+	return connection, nil
+}
+
+// helmTLSEnabled reads the listener configuration from the chart's config
+// ConfigMap, falling back on the scheme of the container's VAULT_ADDR.
+func (r *HelmReleaseDiscoveryReconciler) helmTLSEnabled(ctx context.Context, statefulSet *appsv1.StatefulSet) (bool, error) {
+	configMap := &corev1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: statefulSet.Namespace, Name: statefulSet.Name + "-config"}, configMap)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get Vault config ConfigMap: %w", err)
+	}
+	if config, ok := configMap.Data[helmConfigKey]; err == nil && ok {
+		return !tlsDisabledPattern.MatchString(config), nil
+	}
+
+	if address := helmContainerEnv(statefulSet, "VAULT_ADDR"); address != "" {
+		if u, err := url.Parse(address); err == nil {
+			return u.Scheme == "https", nil
+		}
+	}
+	// The chart disables TLS by default.
+	return false, nil
+}
+
+// helmInternalService returns the release's internal headless Service, or nil
+// when the chart did not create one.
+func (r *HelmReleaseDiscoveryReconciler) helmInternalService(ctx context.Context, statefulSet *appsv1.StatefulSet) (*corev1.Service, error) {
+	services := &corev1.ServiceList{}
+	if err := r.List(ctx, services, client.InNamespace(statefulSet.Namespace), client.MatchingLabels{
+		helmLabelInstance: statefulSet.Labels[helmLabelInstance],
+		helmLabelInternal: "true",
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list Vault Services: %w", err)
+	}
+	if len(services.Items) == 0 {
+		return nil, nil
+	}
+	return &services.Items[0], nil
+}
+
+// helmCABundleRef maps VAULT_CACERT to the Secret mounted at that path
+// through server.extraVolumes, which the chart mounts under
+// /vault/userconfig/<name>.
+func helmCABundleRef(statefulSet *appsv1.StatefulSet) *opsv1alpha1.SecretRef {
+	path, ok := strings.CutPrefix(helmContainerEnv(statefulSet, "VAULT_CACERT"), helmUserConfigDir)
+	if !ok {
+		return nil
+	}
+	name, key, ok := strings.Cut(path, "/")
+	if !ok || key == "" {
+		return nil
+	}
+	for _, volume := range statefulSet.Spec.Template.Spec.Volumes {
+		if volume.Name == "userconfig-"+name && volume.Secret != nil {
+			return &opsv1alpha1.SecretRef{Name: volume.Secret.SecretName, Key: key}
+		}
+	}
+	return nil
+}
+
+// helmContainerEnv returns a literal environment variable of the Vault
+// server container.
+func helmContainerEnv(statefulSet *appsv1.StatefulSet, name string) string {
+	for _, container := range statefulSet.Spec.Template.Spec.Containers {
+		if container.Name != helmVaultContainer {
+			continue
+		}
+		for _, env := range container.Env {
+			if env.Name == name {
+				return env.Value
+			}
+		}
+	}
+	return ""
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *HelmReleaseDiscoveryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1.StatefulSet{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return isVaultHelmRelease(obj) && ownedBy(r.Shard, obj)
+		}))).
+		Owns(&opsv1alpha1.VaultUnsealer{}).
+		Named("helmreleasediscovery").
+		Complete(r)
+}
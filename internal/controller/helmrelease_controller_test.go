@@ -0,0 +1,160 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+func newHelmReleaseReconciler(t *testing.T, objs ...client.Object) *HelmReleaseDiscoveryReconciler {
+	discovery := newDiscoveryReconciler(t, objs...)
+	return &HelmReleaseDiscoveryReconciler{Client: discovery.Client, Scheme: discovery.Scheme}
+}
+
+// newHelmVaultStatefulSet mirrors the server StatefulSet rendered by the
+// Vault Helm chart for a release named "vault": helm.sh/chart and component
+// are only set on the pod template.
+func newHelmVaultStatefulSet(env ...corev1.EnvVar) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vault",
+			Namespace: "vault",
+			UID:       "sts-uid",
+			Labels: map[string]string{
+				"app.kubernetes.io/name": "vault",
+				helmLabelInstance:        "vault",
+				helmLabelManagedBy:       "Helm",
+			},
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: ptr.To(int32(3)),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{
+				"app.kubernetes.io/name": "vault", helmLabelInstance: "vault", helmLabelComponent: "server",
+			}},
+			Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{
+				helmLabelChart:           "vault-0.28.0",
+				"app.kubernetes.io/name": "vault",
+				helmLabelInstance:        "vault",
+				helmLabelComponent:       "server",
+			}}, Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "vault", Env: env}},
+				Volumes: []corev1.Volume{{
+					Name:         "userconfig-vault-tls",
+					VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "vault-tls"}},
+				}},
+			}},
+		},
+	}
+}
+
+func newHelmConfigMap(config string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-config", Namespace: "vault"},
+		Data:       map[string]string{helmConfigKey: config},
+	}
+}
+
+func newHelmInternalService() *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vault-internal",
+			Namespace: "vault",
+			Labels:    map[string]string{helmLabelInstance: "vault", helmLabelInternal: "true"},
+		},
+		Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "https", Port: 8200}, {Name: "https-internal", Port: 8201}}},
+	}
+}
+
+func reconcileHelmRelease(t *testing.T, r *HelmReleaseDiscoveryReconciler) *opsv1alpha1.VaultUnsealer {
+	key := types.NamespacedName{Namespace: "vault", Name: "vault"}
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+
+	vu := &opsv1alpha1.VaultUnsealer{}
+	require.NoError(t, r.Get(context.Background(), key, vu))
+	return vu
+}
+
+func TestHelmReleaseDiscovery_TLS(t *testing.T) {
+	statefulSet := newHelmVaultStatefulSet(corev1.EnvVar{Name: "VAULT_CACERT", Value: "/vault/userconfig/vault-tls/ca.crt"})
+	config := `listener "tcp" {
+  address = "[::]:8200"
+  tls_cert_file = "/vault/userconfig/vault-tls/tls.crt"
+  tls_key_file  = "/vault/userconfig/vault-tls/tls.key"
+}`
+	r := newHelmReleaseReconciler(t, statefulSet, newHelmConfigMap(config), newHelmInternalService())
+	vu := reconcileHelmRelease(t, r)
+
+	assert.Equal(t, "https://vault-internal.vault.svc:8200", vu.Spec.Vault.Address)
+	assert.Equal(t, "{{.PodName}}.vault-internal.{{.Namespace}}.svc", vu.Spec.Vault.PerPodHostTemplate)
+	assert.Equal(t, &opsv1alpha1.SecretRef{Name: "vault-tls", Key: "ca.crt"}, vu.Spec.Vault.CABundleSecretRef)
+	assert.Equal(t, "app.kubernetes.io/instance=vault,app.kubernetes.io/name=vault,component=server", vu.Spec.VaultLabelSelector)
+	assert.Equal(t, "vault", vu.Labels[opsv1alpha1.LabelDiscoveredFrom])
+	assert.Equal(t, opsv1alpha1.ReplicaScopeCluster, vu.Spec.Mode.Scope)
+}
+
+func TestHelmReleaseDiscovery_TLSDisabled(t *testing.T) {
+	config := `listener "tcp" {
+  tls_disable = 1
+  address = "[::]:8200"
+}`
+	r := newHelmReleaseReconciler(t, newHelmVaultStatefulSet(), newHelmConfigMap(config))
+	vu := reconcileHelmRelease(t, r)
+
+	assert.Equal(t, "http://vault:8200", vu.Spec.Vault.Address)
+	assert.Empty(t, vu.Spec.Vault.PerPodHostTemplate)
+	assert.Nil(t, vu.Spec.Vault.CABundleSecretRef)
+}
+
+func TestHelmReleaseDiscovery_FallsBackOnVaultAddr(t *testing.T) {
+	r := newHelmReleaseReconciler(t, newHelmVaultStatefulSet(corev1.EnvVar{Name: "VAULT_ADDR", Value: "https://127.0.0.1:8200"}))
+	assert.Equal(t, "https://vault:8200", reconcileHelmRelease(t, r).Spec.Vault.Address)
+}
+
+func TestIsVaultHelmRelease(t *testing.T) {
+	statefulSet := newHelmVaultStatefulSet()
+	assert.True(t, isVaultHelmRelease(statefulSet))
+
+	statefulSet.Labels[opsv1alpha1.LabelDiscover] = "true"
+	assert.False(t, isVaultHelmRelease(statefulSet), "label discovery takes precedence")
+
+	other := newHelmVaultStatefulSet()
+	other.Spec.Template.Labels[helmLabelChart] = "consul-1.0.0"
+	assert.False(t, isVaultHelmRelease(other))
+
+	labelledOnObject := newHelmVaultStatefulSet()
+	labelledOnObject.Labels[helmLabelChart] = labelledOnObject.Spec.Template.Labels[helmLabelChart]
+	delete(labelledOnObject.Spec.Template.Labels, helmLabelChart)
+	assert.False(t, isVaultHelmRelease(labelledOnObject), "the chart labels the pod template")
+
+	notServer := newHelmVaultStatefulSet()
+	notServer.Spec.Template.Labels[helmLabelComponent] = "injector"
+	assert.False(t, isVaultHelmRelease(notServer))
+}
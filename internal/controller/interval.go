@@ -0,0 +1,73 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// reconcileInterval returns the interval between reconciles for vaultUnsealer,
+// clamped to the reconciler's bounds, and whether spec.interval was clamped.
+func (r *VaultUnsealerReconciler) reconcileInterval(vaultUnsealer *opsv1alpha1.VaultUnsealer) (time.Duration, bool) {
+	if vaultUnsealer.Spec.Interval == nil {
+		return opsv1alpha1.DefaultInterval, false
+	}
+	return r.clampInterval(vaultUnsealer.Spec.Interval.Duration)
+}
+
+// fastInterval returns the interval used while pods need attention: the
+// clamped spec.fastInterval, but never longer than interval.
+func (r *VaultUnsealerReconciler) fastInterval(vaultUnsealer *opsv1alpha1.VaultUnsealer, interval time.Duration) time.Duration {
+	if vaultUnsealer.Spec.FastInterval == nil {
+		return interval
+	}
+	fast, _ := r.clampInterval(vaultUnsealer.Spec.FastInterval.Duration)
+	return min(fast, interval)
+}
+
+// clampInterval clamps interval to the reconciler's bounds and reports
+// whether it had to.
+func (r *VaultUnsealerReconciler) clampInterval(interval time.Duration) (time.Duration, bool) {
+	minInterval, maxInterval := r.MinInterval, r.MaxInterval
+	if minInterval <= 0 {
+		minInterval = opsv1alpha1.DefaultMinInterval
+	}
+	if maxInterval <= 0 {
+		maxInterval = opsv1alpha1.DefaultMaxInterval
+	}
+
+	switch {
+	case interval < minInterval:
+		return minInterval, true
+	case interval > maxInterval:
+		return maxInterval, true
+	}
+	return interval, false
+}
+
+// needsAttention reports whether any checked pod is not unsealed, so the
+// next reconcile should come at the fast interval.
+func needsAttention(podStatuses []opsv1alpha1.PodStatus) bool {
+	for _, podStatus := range podStatuses {
+		if podStatus.State != opsv1alpha1.PodStateUnsealed {
+			return true
+		}
+	}
+	return false
+}
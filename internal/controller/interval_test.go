@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+func TestReconcileInterval(t *testing.T) {
+	r := &VaultUnsealerReconciler{}
+	vu := opsv1alpha1.NewVaultUnsealer("vault", "main")
+
+	interval, clamped := r.reconcileInterval(vu)
+	assert.Equal(t, opsv1alpha1.DefaultInterval, interval)
+	assert.False(t, clamped)
+
+	tests := []struct {
+		requested time.Duration
+		want      time.Duration
+		clamped   bool
+	}{
+		{requested: 30 * time.Second, want: 30 * time.Second},
+		{requested: time.Second, want: opsv1alpha1.DefaultMinInterval, clamped: true},
+		{requested: 0, want: opsv1alpha1.DefaultMinInterval, clamped: true},
+		{requested: 48 * time.Hour, want: opsv1alpha1.DefaultMaxInterval, clamped: true},
+	}
+	for _, tt := range tests {
+		interval, clamped := r.reconcileInterval(vu.WithInterval(tt.requested))
+		assert.Equal(t, tt.want, interval, tt.requested.String())
+		assert.Equal(t, tt.clamped, clamped, tt.requested.String())
+	}
+
+	configured := &VaultUnsealerReconciler{MinInterval: time.Minute, MaxInterval: time.Hour}
+	interval, clamped = configured.reconcileInterval(vu.WithInterval(30 * time.Second))
+	assert.Equal(t, time.Minute, interval)
+	assert.True(t, clamped)
+}
+
+func TestFastInterval(t *testing.T) {
+	r := &VaultUnsealerReconciler{}
+	vu := opsv1alpha1.NewVaultUnsealer("vault", "main")
+	assert.Equal(t, time.Minute, r.fastInterval(vu, time.Minute), "defaults to the interval")
+
+	assert.Equal(t, 15*time.Second, r.fastInterval(vu.WithFastInterval(15*time.Second), time.Minute))
+	assert.Equal(t, opsv1alpha1.DefaultMinInterval, r.fastInterval(vu.WithFastInterval(time.Second), time.Minute), "clamped")
+	assert.Equal(t, time.Minute, r.fastInterval(vu.WithFastInterval(time.Hour), time.Minute), "never longer than the interval")
+}
+
+func TestNeedsAttention(t *testing.T) {
+	unsealed := opsv1alpha1.PodStatus{Name: "vault-0", State: opsv1alpha1.PodStateUnsealed}
+	assert.False(t, needsAttention(nil))
+	assert.False(t, needsAttention([]opsv1alpha1.PodStatus{unsealed}))
+	assert.True(t, needsAttention([]opsv1alpha1.PodStatus{unsealed, {Name: "vault-1", State: opsv1alpha1.PodStateSealed}}))
+	assert.True(t, needsAttention([]opsv1alpha1.PodStatus{{Name: "vault-1", State: opsv1alpha1.PodStateUnknown}}))
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/metrics"
+	"github.com/panteparak/vault-unsealer/internal/secrets"
+)
+
+// KeyResidencyReporter publishes how many bytes of unseal key data the
+// manager's Secret cache holds for each key Secret and for how long, so
+// security reviews can bound how long plaintext keys stay in memory. A copy
+// is resident from when it is first seen until the Secret changes or is
+// deleted.
+type KeyResidencyReporter struct {
+	Reconciler *VaultUnsealerReconciler
+
+	// Interval is how often residency is measured. Defaults to 30s.
+	Interval time.Duration
+
+	since map[types.NamespacedName]residentCopy
+}
+
+// residentCopy records when a version of a key Secret was first seen.
+type residentCopy struct {
+	resourceVersion string
+	since           time.Time
+}
+
+// NeedLeaderElection reports on every replica, since each one caches the
+// Secrets itself.
+func (k *KeyResidencyReporter) NeedLeaderElection() bool {
+	return false
+}
+
+// Start measures residency until ctx is cancelled.
+func (k *KeyResidencyReporter) Start(ctx context.Context) error {
+	interval := k.Interval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	k.report(ctx, time.Now())
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			k.report(ctx, now)
+		}
+	}
+}
+
+// report refreshes the residency gauges of every key Secret referenced by a
+// VaultUnsealer and drops those of Secrets no longer referenced or cached.
+func (k *KeyResidencyReporter) report(ctx context.Context, now time.Time) {
+	log := logf.FromContext(ctx).WithName("key-residency")
+	if k.since == nil {
+		k.since = make(map[types.NamespacedName]residentCopy)
+	}
+	r := k.Reconciler
+	loader := r.SecretsLoader
+	if loader == nil {
+		loader = secrets.NewLoader(r.Client)
+	}
+
+	list := &opsv1alpha1.VaultUnsealerList{}
+	if err := r.List(ctx, list); err != nil {
+		log.Error(err, "Failed to list VaultUnsealers")
+		return
+	}
+
+	// A field referenced by several VaultUnsealers is held only once.
+	fields := make(map[types.NamespacedName]map[string]int)
+	complete := true
+	for i := range list.Items {
+		vaultUnsealer := &list.Items[i]
+		resident, err := loader.ResidentKeysFor(ctx, vaultUnsealer)
+		if err != nil {
+			log.Error(err, "Failed to measure resident unseal keys", "vaultunsealer", client.ObjectKeyFromObject(vaultUnsealer).String())
+			complete = false
+			continue
+		}
+		for _, key := range resident {
+			if fields[key.Secret] == nil {
+				fields[key.Secret] = make(map[string]int)
+			}
+			fields[key.Secret][key.Key] = key.Bytes
+			if held, ok := k.since[key.Secret]; !ok || held.resourceVersion != key.ResourceVersion {
+				k.since[key.Secret] = residentCopy{resourceVersion: key.ResourceVersion, since: now}
+			}
+		}
+	}
+
+	for secret, held := range k.since {
+		keys, ok := fields[secret]
+		if !ok {
+			if !complete {
+				// The Secret may belong to the VaultUnsealer that failed.
+				continue
+			}
+			delete(k.since, secret)
+			metrics.ResidentKeyBytes.DeleteLabelValues(secret.Namespace, secret.Name)
+			metrics.ResidentKeySeconds.DeleteLabelValues(secret.Namespace, secret.Name)
+			continue
+		}
+		size := 0
+		for _, n := range keys {
+			size += n
+		}
+		metrics.ResidentKeyBytes.WithLabelValues(secret.Namespace, secret.Name).Set(float64(size))
+		metrics.ResidentKeySeconds.WithLabelValues(secret.Namespace, secret.Name).Set(now.Sub(held.since).Seconds())
+	}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/panteparak/vault-unsealer/internal/metrics"
+)
+
+func TestKeyResidencyReporter(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "resident-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": []byte(`["key-1"]`)},
+	}
+	first := newFinalizerTestUnsealer()
+	first.Spec.UnsealKeysSecretRefs[0].Name = "resident-keys"
+	second := first.DeepCopy()
+	second.Name = "second"
+	r := newFakeReconciler(t, secret, first, second)
+	reporter := &KeyResidencyReporter{Reconciler: r}
+	bytes := metrics.ResidentKeyBytes.WithLabelValues("vault", "resident-keys")
+	seconds := metrics.ResidentKeySeconds.WithLabelValues("vault", "resident-keys")
+
+	reporter.report(ctx, now)
+	assert.Equal(t, 9.0, testutil.ToFloat64(bytes), "a field referenced twice is counted once")
+	assert.Equal(t, 0.0, testutil.ToFloat64(seconds))
+
+	reporter.report(ctx, now.Add(time.Minute))
+	assert.Equal(t, 60.0, testutil.ToFloat64(seconds))
+
+	// A changed Secret replaces the cached copy.
+	secret.Data["keys.json"] = []byte(`["key-1","key-2"]`)
+	require.NoError(t, r.Update(ctx, secret))
+	reporter.report(ctx, now.Add(2*time.Minute))
+	assert.Equal(t, 17.0, testutil.ToFloat64(bytes))
+	assert.Equal(t, 0.0, testutil.ToFloat64(seconds))
+
+	before := testutil.CollectAndCount(metrics.ResidentKeyBytes)
+	require.NoError(t, r.Delete(ctx, secret))
+	reporter.report(ctx, now.Add(3*time.Minute))
+	assert.Equal(t, before-1, testutil.CollectAndCount(metrics.ResidentKeyBytes))
+	assert.Empty(t, reporter.since)
+}
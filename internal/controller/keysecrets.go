@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// recordKeySecrets records who created and last changed each key Secret in
+// status, and emits a KeySecretChanged Event for every Secret whose keys
+// changed since the previous reconcile, so a seal incident can be matched
+// with the rotation that preceded it. Failing to read them never fails the
+// reconcile: the keys themselves already loaded.
+func (r *VaultUnsealerReconciler) recordKeySecrets(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) {
+	origins, err := r.SecretsLoader.KeySecretOriginsFor(ctx, vaultUnsealer)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to read unseal key secret origins")
+		return
+	}
+
+	previous := make(map[string]opsv1alpha1.KeySecretStatus, len(vaultUnsealer.Status.KeySecrets))
+	for _, status := range vaultUnsealer.Status.KeySecrets {
+		previous[status.Namespace+"/"+status.Name] = status
+	}
+
+	var keySecrets []opsv1alpha1.KeySecretStatus
+	for _, origin := range origins {
+		status := opsv1alpha1.KeySecretStatus{
+			Namespace:    origin.Secret.Namespace,
+			Name:         origin.Secret.Name,
+			CreationTime: metav1.NewTime(origin.CreatedAt),
+			CreatedBy:    origin.CreatedBy,
+			ModifiedTime: metav1.NewTime(origin.ModifiedAt),
+			ModifiedBy:   origin.ModifiedBy,
+
+			ResourceVersion: origin.ResourceVersion,
+		}
+		last, ok := previous[origin.Secret.String()]
+		status.LastUnsealResourceVersion, status.LastUnsealTime = last.LastUnsealResourceVersion, last.LastUnsealTime
+		keySecrets = append(keySecrets, status)
+
+		if !ok || (last.ModifiedTime.Equal(&status.ModifiedTime) && last.CreationTime.Equal(&status.CreationTime)) {
+			continue
+		}
+		modifiedBy := status.ModifiedBy
+		if modifiedBy == "" {
+			modifiedBy = "an unknown field manager"
+		}
+		r.event(ctx, vaultUnsealer, corev1.EventTypeNormal, ReasonKeySecretChanged,
+			fmt.Sprintf("Unseal keys Secret %s was changed by %s at %s", origin.Secret, modifiedBy, origin.ModifiedAt.UTC().Format(time.RFC3339)))
+	}
+	vaultUnsealer.Status.KeySecrets = keySecrets
+}
+
+// recordUnsealedKeySecrets marks the key Secret versions loaded by this
+// reconcile as the ones that last unsealed a pod.
+func recordUnsealedKeySecrets(vaultUnsealer *opsv1alpha1.VaultUnsealer, now time.Time) {
+	for i := range vaultUnsealer.Status.KeySecrets {
+		keySecret := &vaultUnsealer.Status.KeySecrets[i]
+		keySecret.LastUnsealResourceVersion = keySecret.ResourceVersion
+		keySecret.LastUnsealTime = &metav1.Time{Time: now}
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+func keyDataWrite(manager string, at time.Time) metav1.ManagedFieldsEntry {
+	return metav1.ManagedFieldsEntry{
+		Manager:    manager,
+		Operation:  metav1.ManagedFieldsOperationUpdate,
+		Time:       &metav1.Time{Time: at},
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:keys.json":{}}}`)},
+	}
+}
+
+func TestReconcile_RecordsKeySecretChanges(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	fake := vaultfake.NewServer(vaultfake.WithKeys([]string{"key-1"}, 1))
+	defer fake.Close()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "vault-keys",
+			Namespace:         "vault",
+			CreationTimestamp: metav1.Time{Time: created},
+			ManagedFields:     []metav1.ManagedFieldsEntry{keyDataWrite("kubectl-create", created)},
+		},
+		Data: map[string][]byte{"keys.json": []byte("key-1\n")},
+	}
+	r := newFakeReconciler(t, newFinalizerTestUnsealer().WithVaultURL("http://vault"), newRolloutPod(fake, "vault-0", "v1"), secret)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+
+	got := reconcileAndGet(t, r)
+	require.Len(t, got.Status.KeySecrets, 1)
+	keySecret := got.Status.KeySecrets[0]
+	assert.Equal(t, "vault-keys", keySecret.Name)
+	assert.Equal(t, "kubectl-create", keySecret.CreatedBy)
+	assert.Equal(t, "kubectl-create", keySecret.ModifiedBy)
+	assert.True(t, keySecret.ModifiedTime.Time.Equal(created))
+	for len(recorder.Events) > 0 {
+		assert.NotContains(t, <-recorder.Events, ReasonKeySecretChanged, "the first reconcile only records the origin")
+	}
+
+	// A rotation through a shared field manager that names its user.
+	rotated := created.Add(24 * time.Hour)
+	require.NoError(t, r.Get(t.Context(), types.NamespacedName{Namespace: "vault", Name: "vault-keys"}, secret))
+	secret.Annotations = map[string]string{opsv1alpha1.AnnotationModifiedBy: "alice@example.com"}
+	secret.ManagedFields = append(secret.ManagedFields, keyDataWrite("argocd-controller", rotated))
+	require.NoError(t, r.Update(t.Context(), secret))
+
+	got = reconcileAndGet(t, r)
+	require.Len(t, got.Status.KeySecrets, 1)
+	assert.Equal(t, "kubectl-create", got.Status.KeySecrets[0].CreatedBy)
+	assert.Equal(t, "alice@example.com", got.Status.KeySecrets[0].ModifiedBy)
+	assert.True(t, got.Status.KeySecrets[0].ModifiedTime.Time.Equal(rotated))
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "Normal KeySecretChanged Unseal keys Secret vault/vault-keys was changed by alice@example.com at 2026-01-02T00:00:00Z")
+}
+
+func TestReconcile_RecordsKeySecretVersionOfLastUnseal(t *testing.T) {
+	fake := vaultfake.NewServer(vaultfake.WithKeys([]string{"key-1"}, 1))
+	defer fake.Close()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": []byte("key-1\n")},
+	}
+	r := newFakeReconciler(t, newFinalizerTestUnsealer().WithVaultURL("http://vault"), newRolloutPod(fake, "vault-0", "v1"), secret)
+
+	got := reconcileAndGet(t, r)
+	require.Len(t, got.Status.KeySecrets, 1)
+	unsealedWith := got.Status.KeySecrets[0].ResourceVersion
+	assert.NotEmpty(t, unsealedWith)
+	assert.Equal(t, unsealedWith, got.Status.KeySecrets[0].LastUnsealResourceVersion)
+	require.NotNil(t, got.Status.KeySecrets[0].LastUnsealTime)
+
+	// A rotation is recorded, but the version that last unsealed is kept
+	// until the new keys unseal a pod.
+	require.NoError(t, r.Get(t.Context(), types.NamespacedName{Namespace: "vault", Name: "vault-keys"}, secret))
+	secret.Data["keys.json"] = []byte("key-1\n\n")
+	require.NoError(t, r.Update(t.Context(), secret))
+	got = reconcileAndGet(t, r)
+	rotated := got.Status.KeySecrets[0].ResourceVersion
+	assert.NotEqual(t, unsealedWith, rotated)
+	assert.Equal(t, unsealedWith, got.Status.KeySecrets[0].LastUnsealResourceVersion)
+
+	fake.Seal()
+	got = reconcileAndGet(t, r)
+	assert.Equal(t, rotated, got.Status.KeySecrets[0].LastUnsealResourceVersion)
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/metrics"
+)
+
+// recordKeysetAge exports how long ago the oldest key Secret changed, so
+// keys that have not been rotated within policy can be alerted on. Failing
+// to read it never fails the reconcile: the keys themselves already loaded.
+func (r *VaultUnsealerReconciler) recordKeysetAge(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer, now time.Time) {
+	modifiedAt, ok, err := r.SecretsLoader.KeysetModifiedAt(ctx, vaultUnsealer)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to read unseal key secret modification time")
+		return
+	}
+	if !ok {
+		metrics.UnsealKeysAge.DeleteLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace)
+		return
+	}
+	metrics.UnsealKeysAge.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace).Set(now.Sub(modifiedAt).Seconds())
+}
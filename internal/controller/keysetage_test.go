@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/panteparak/vault-unsealer/internal/metrics"
+	"github.com/panteparak/vault-unsealer/internal/secrets"
+)
+
+func TestRecordKeysetAge(t *testing.T) {
+	now := time.Now()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vault-keys",
+			Namespace: "vault",
+			ManagedFields: []metav1.ManagedFieldsEntry{{
+				Manager:    "kubectl",
+				Operation:  metav1.ManagedFieldsOperationUpdate,
+				Time:       &metav1.Time{Time: now.Add(-48 * time.Hour)},
+				FieldsType: "FieldsV1",
+				FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:keys.json":{}}}`)},
+			}},
+		},
+		Data: map[string][]byte{"keys.json": []byte(`["key-1"]`)},
+	}
+	r := newFakeReconciler(t, secret)
+	r.SecretsLoader = secrets.NewLoader(r.Client)
+	vu := newFinalizerTestUnsealer()
+	vu.Name = "keyset-age"
+	before := testutil.CollectAndCount(metrics.UnsealKeysAge)
+
+	r.recordKeysetAge(context.Background(), vu, now)
+	assert.InDelta(t, (48 * time.Hour).Seconds(), testutil.ToFloat64(metrics.UnsealKeysAge.WithLabelValues("keyset-age", "vault")), 1)
+
+	vu.Spec.UnsealKeysSecretRefs = nil
+	r.recordKeysetAge(context.Background(), vu, now)
+	assert.Equal(t, before, testutil.CollectAndCount(metrics.UnsealKeysAge))
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/secrets"
+	"github.com/panteparak/vault-unsealer/internal/vault"
+)
+
+// Outcomes of submitting a single unseal key share.
+const (
+	keyResultAdvanced   = "advanced"
+	keyResultNoProgress = "no_progress"
+	keyResultRejected   = "rejected"
+)
+
+// keyQuarantineThreshold is how many consecutive rejections by a pod mark a
+// share as suspect, so it stops being submitted instead of failing every pass.
+const keyQuarantineThreshold = 3
+
+// keySubmission records what happened to one share during an unseal pass.
+type keySubmission struct {
+	// index is the 1-based position of the share in the loaded key set.
+	index       int
+	fingerprint string
+	result      string
+}
+
+// keyFingerprint identifies a share in status, logs and metrics without
+// revealing it. Eight bytes of SHA-256 are enough to tell shares apart.
+func keyFingerprint(key secrets.SecretString) string {
+	sum := sha256.Sum256([]byte(key.Reveal()))
+	return hex.EncodeToString(sum[:8])
+}
+
+// keyResult classifies a share submission given the progress observed before it.
+func keyResult(progressBefore int, resp *vault.UnsealResponse) string {
+	if !resp.Sealed || resp.Progress > progressBefore {
+		return keyResultAdvanced
+	}
+	return keyResultNoProgress
+}
+
+// mergeKeyStats adds this pass's submissions to the counts carried over from
+// the previous status. Counts for shares no longer in the key set are dropped.
+func mergeKeyStats(previous []opsv1alpha1.KeyStat, unsealKeys []secrets.SecretString, submissions []keySubmission) []opsv1alpha1.KeyStat {
+	if len(previous) == 0 && len(submissions) == 0 {
+		return nil
+	}
+
+	byFingerprint := make(map[string]opsv1alpha1.KeyStat, len(previous))
+	for _, stat := range previous {
+		byFingerprint[stat.Fingerprint] = stat
+	}
+	for _, submission := range submissions {
+		stat := byFingerprint[submission.fingerprint]
+		switch submission.result {
+		case keyResultAdvanced:
+			stat.Advanced++
+			stat.ConsecutiveRejections = 0
+		case keyResultNoProgress:
+			stat.NoProgress++
+		case keyResultRejected:
+			stat.Rejected++
+			stat.ConsecutiveRejections++
+			if stat.ConsecutiveRejections >= keyQuarantineThreshold {
+				stat.Quarantined = true
+			}
+		}
+		byFingerprint[submission.fingerprint] = stat
+	}
+
+	var stats []opsv1alpha1.KeyStat
+	for i, key := range unsealKeys {
+		fingerprint := keyFingerprint(key)
+		stat, ok := byFingerprint[fingerprint]
+		if !ok {
+			continue
+		}
+		stat.Index = int32(i + 1)
+		stat.Fingerprint = fingerprint
+		stats = append(stats, stat)
+	}
+	return stats
+}
+
+// quarantinedKeys returns the fingerprints of the shares that must not be
+// submitted to a pod.
+func quarantinedKeys(stats []opsv1alpha1.KeyStat) map[string]bool {
+	quarantined := make(map[string]bool)
+	for _, stat := range stats {
+		if stat.Quarantined {
+			quarantined[stat.Fingerprint] = true
+		}
+	}
+	return quarantined
+}
+
+// newlyQuarantined returns the shares quarantined in current but not in previous.
+func newlyQuarantined(previous, current []opsv1alpha1.KeyStat) []opsv1alpha1.KeyStat {
+	already := quarantinedKeys(previous)
+	var stats []opsv1alpha1.KeyStat
+	for _, stat := range current {
+		if stat.Quarantined && !already[stat.Fingerprint] {
+			stats = append(stats, stat)
+		}
+	}
+	return stats
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/secrets"
+	"github.com/panteparak/vault-unsealer/internal/vault"
+)
+
+func TestKeyFingerprint(t *testing.T) {
+	assert.Len(t, fingerprint("key-1"), 16)
+	assert.Equal(t, fingerprint("key-1"), fingerprint("key-1"))
+	assert.NotEqual(t, fingerprint("key-1"), fingerprint("key-2"))
+	assert.NotContains(t, fingerprint("key-1"), "key-1")
+}
+
+func TestKeyResult(t *testing.T) {
+	assert.Equal(t, keyResultAdvanced, keyResult(0, &vault.UnsealResponse{Sealed: true, Progress: 1}))
+	assert.Equal(t, keyResultAdvanced, keyResult(2, &vault.UnsealResponse{Sealed: false, Progress: 0}))
+	assert.Equal(t, keyResultNoProgress, keyResult(1, &vault.UnsealResponse{Sealed: true, Progress: 1}))
+}
+
+func TestMergeKeyStats(t *testing.T) {
+	keys := newKeys("key-1", "key-2")
+	first := mergeKeyStats(nil, keys, []keySubmission{
+		{index: 1, fingerprint: fingerprint("key-1"), result: keyResultAdvanced},
+		{index: 2, fingerprint: fingerprint("key-2"), result: keyResultRejected},
+	})
+	assert.Equal(t, []opsv1alpha1.KeyStat{
+		{Index: 1, Fingerprint: fingerprint("key-1"), Advanced: 1},
+		{Index: 2, Fingerprint: fingerprint("key-2"), Rejected: 1, ConsecutiveRejections: 1},
+	}, first)
+
+	// Counts follow the share when the key set is reordered, and shares that
+	// were removed from the set are dropped.
+	second := mergeKeyStats(first, newKeys("key-3", "key-2"), []keySubmission{
+		{index: 2, fingerprint: fingerprint("key-2"), result: keyResultNoProgress},
+	})
+	assert.Equal(t, []opsv1alpha1.KeyStat{
+		{Index: 2, Fingerprint: fingerprint("key-2"), NoProgress: 1, Rejected: 1, ConsecutiveRejections: 1},
+	}, second)
+
+	assert.Nil(t, mergeKeyStats(nil, keys, nil))
+}
+
+func TestMergeKeyStats_Quarantine(t *testing.T) {
+	keys := newKeys("stale")
+	rejected := []keySubmission{{index: 1, fingerprint: fingerprint("stale"), result: keyResultRejected}}
+
+	var stats []opsv1alpha1.KeyStat
+	for i := 1; i < keyQuarantineThreshold; i++ {
+		stats = mergeKeyStats(stats, keys, rejected)
+	}
+	require.Len(t, stats, 1)
+	assert.False(t, stats[0].Quarantined)
+	assert.Empty(t, quarantinedKeys(stats))
+
+	// Advancing progress resets the consecutive count.
+	reset := mergeKeyStats(stats, keys, []keySubmission{{index: 1, fingerprint: fingerprint("stale"), result: keyResultAdvanced}})
+	assert.Zero(t, reset[0].ConsecutiveRejections)
+
+	quarantined := mergeKeyStats(stats, keys, rejected)
+	assert.True(t, quarantined[0].Quarantined)
+	assert.Equal(t, map[string]bool{fingerprint("stale"): true}, quarantinedKeys(quarantined))
+	assert.Equal(t, quarantined, newlyQuarantined(stats, quarantined))
+	assert.Empty(t, newlyQuarantined(quarantined, quarantined))
+}
+
+func newKeys(values ...string) []secrets.SecretString {
+	keys := make([]secrets.SecretString, 0, len(values))
+	for _, value := range values {
+		keys = append(keys, secrets.NewSecretString(value))
+	}
+	return keys
+}
+
+func fingerprint(value string) string {
+	return keyFingerprint(secrets.NewSecretString(value))
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/schedule"
+)
+
+// maintenanceRecheckInterval caps the requeue while a maintenance window
+// blocks unsealing, so unsealing resumes soon after the window changes.
+const maintenanceRecheckInterval = time.Minute
+
+// windowActive reports whether the maintenance window is open at now.
+func windowActive(window opsv1alpha1.MaintenanceWindow, now time.Time) (bool, error) {
+	s, err := schedule.Parse(window.Schedule)
+	if err != nil {
+		return false, err
+	}
+	loc := time.UTC
+	if window.TimeZone != "" {
+		if loc, err = time.LoadLocation(window.TimeZone); err != nil {
+			return false, fmt.Errorf("invalid time zone %q: %w", window.TimeZone, err)
+		}
+	}
+	return s.Active(now.In(loc), window.Duration.Duration), nil
+}
+
+// maintenanceBlocked reports whether maintenance windows forbid unsealing at
+// now, with a message explaining why. Windows that fail to parse are skipped
+// and returned as errors.
+func maintenanceBlocked(windows []opsv1alpha1.MaintenanceWindow, now time.Time) (bool, string, []error) {
+	var errs []error
+	hasAllow, allowed := false, false
+	for i, window := range windows {
+		active, err := windowActive(window, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("maintenanceWindows[%d]: %w", i, err))
+			continue
+		}
+		if window.Mode == opsv1alpha1.MaintenanceWindowAllow {
+			hasAllow = true
+			allowed = allowed || active
+			continue
+		}
+		if active {
+			return true, fmt.Sprintf("Unsealing suppressed by maintenance window %q", window.Schedule), errs
+		}
+	}
+	if hasAllow && !allowed {
+		return true, "Unsealing only allowed during Allow maintenance windows", errs
+	}
+	return false, "", errs
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+func TestMaintenanceBlocked(t *testing.T) {
+	// 02:30 UTC on a Wednesday.
+	now := time.Date(2025, 6, 4, 2, 30, 0, 0, time.UTC)
+	hour := metav1.Duration{Duration: time.Hour}
+
+	tests := []struct {
+		name    string
+		windows []opsv1alpha1.MaintenanceWindow
+		blocked bool
+	}{
+		{name: "no windows"},
+		{
+			name:    "inside suppress window",
+			windows: []opsv1alpha1.MaintenanceWindow{{Schedule: "0 2 * * *", Duration: hour}},
+			blocked: true,
+		},
+		{
+			name:    "outside suppress window",
+			windows: []opsv1alpha1.MaintenanceWindow{{Schedule: "0 4 * * *", Duration: hour}},
+		},
+		{
+			name:    "time zone shifts the window",
+			windows: []opsv1alpha1.MaintenanceWindow{{Schedule: "0 2 * * *", Duration: hour, TimeZone: "Asia/Tokyo"}},
+		},
+		{
+			name:    "outside every allow window",
+			windows: []opsv1alpha1.MaintenanceWindow{{Schedule: "0 4 * * *", Duration: hour, Mode: opsv1alpha1.MaintenanceWindowAllow}},
+			blocked: true,
+		},
+		{
+			name:    "inside allow window",
+			windows: []opsv1alpha1.MaintenanceWindow{{Schedule: "0 2 * * *", Duration: hour, Mode: opsv1alpha1.MaintenanceWindowAllow}},
+		},
+		{
+			name: "suppress takes precedence over allow",
+			windows: []opsv1alpha1.MaintenanceWindow{
+				{Schedule: "0 2 * * *", Duration: hour, Mode: opsv1alpha1.MaintenanceWindowAllow},
+				{Schedule: "15 2 * * *", Duration: hour},
+			},
+			blocked: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blocked, _, errs := maintenanceBlocked(tt.windows, now)
+			assert.Empty(t, errs)
+			assert.Equal(t, tt.blocked, blocked)
+		})
+	}
+}
+
+func TestMaintenanceBlocked_IgnoresInvalidWindows(t *testing.T) {
+	windows := []opsv1alpha1.MaintenanceWindow{
+		{Schedule: "not a schedule", Duration: metav1.Duration{Duration: time.Hour}},
+		{Schedule: "* * * * *", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Nowhere/Special"},
+	}
+
+	blocked, _, errs := maintenanceBlocked(windows, time.Now())
+	assert.False(t, blocked)
+	require.Len(t, errs, 2)
+	assert.Contains(t, errs[0].Error(), "maintenanceWindows[0]")
+}
+
+func TestReconcile_MaintenanceWindowSkipsUnseal(t *testing.T) {
+	vu := newFinalizerTestUnsealer()
+	vu.Spec.MaintenanceWindows = []opsv1alpha1.MaintenanceWindow{
+		{Schedule: "* * * * *", Duration: metav1.Duration{Duration: time.Hour}},
+	}
+	r := newFakeReconciler(t, vu)
+
+	got := reconcileAndGet(t, r)
+
+	var reason string
+	for _, cond := range got.Status.Conditions {
+		if cond.Type == ConditionTypeMaintenance {
+			reason = cond.Reason
+		}
+	}
+	assert.Equal(t, ReasonMaintenanceWindow, reason)
+	assert.Empty(t, got.Status.PodsChecked)
+}
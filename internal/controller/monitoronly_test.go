@@ -0,0 +1,56 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+func TestReconcile_MonitorOnly(t *testing.T) {
+	keys := []string{"key-1", "key-2"}
+	unsealed := vaultfake.NewServer(vaultfake.WithKeys(keys, 2), vaultfake.WithUnsealed())
+	defer unsealed.Close()
+	sealed := vaultfake.NewServer(vaultfake.WithKeys(keys, 2))
+	defer sealed.Close()
+
+	// No keys Secret exists, and legacy ha: false would normally stop
+	// after the first unsealed pod.
+	vu := newFinalizerTestUnsealer().WithVaultURL("http://vault").WithMonitorOnly(true)
+	r := newFakeReconciler(t, vu, newRolloutPod(unsealed, "vault-0", "v1"), newRolloutPod(sealed, "vault-1", "v1"))
+
+	got := reconcileAndGet(t, r)
+	assert.True(t, sealed.Sealed())
+	assert.Zero(t, sealed.UnsealRequests(), "no key is submitted")
+	require.Len(t, got.Status.Pods, 2, "every pod is checked")
+	assert.Equal(t, opsv1alpha1.PodStateUnsealed, got.Status.Pods[0].State)
+	assert.Equal(t, opsv1alpha1.PodStateSealed, got.Status.Pods[1].State)
+	assert.Equal(t, "Sealed, keys are not submitted in monitor-only mode", got.Status.Pods[1].Message)
+	assert.Equal(t, opsv1alpha1.StrategyMonitorOnly, got.Status.EffectiveConfig.Strategy)
+	assert.Nil(t, findCondition(got, ConditionTypeKeysLoaded), "keys are never loaded")
+	assertCondition(t, got, ConditionTypeReconciling, ConditionStatusFalse, ReasonMonitorOnly)
+	assertCondition(t, got, ConditionTypeReady, ConditionStatusTrue, ReasonMonitorOnly)
+
+	sealed.Seal()
+	unsealed.Seal()
+	got = reconcileAndGet(t, r)
+	assertCondition(t, got, ConditionTypeReady, ConditionStatusFalse, ReasonVaultSealed)
+	assert.Zero(t, unsealed.UnsealRequests())
+}
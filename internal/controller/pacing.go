@@ -0,0 +1,48 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/vault"
+)
+
+// keySubmissionDelay returns the pause between key submissions to one pod,
+// or zero to submit them back to back.
+func keySubmissionDelay(vaultUnsealer *opsv1alpha1.VaultUnsealer) time.Duration {
+	if vaultUnsealer.Spec.KeySubmissionDelay == nil || vaultUnsealer.Spec.KeySubmissionDelay.Duration < 0 {
+		return 0
+	}
+	return vaultUnsealer.Spec.KeySubmissionDelay.Duration
+}
+
+// pacedSealStatus waits for delay and then reads the seal status, so the
+// caller sees shares submitted by anyone else in the meantime. The wait ends
+// early with the context's error when the reconcile budget runs out.
+func pacedSealStatus(ctx context.Context, vaultClient *vault.Client, delay time.Duration) (*vault.SealStatus, error) {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-timer.C:
+	}
+	return vaultClient.GetSealStatus(ctx)
+}
@@ -0,0 +1,412 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/metrics"
+	"github.com/panteparak/vault-unsealer/internal/secrets"
+	"github.com/panteparak/vault-unsealer/internal/vault"
+)
+
+// A reconcile runs three phases in order: discovering the target pods,
+// loading the unseal keys and unsealing the pods. Each phase reports its
+// outcome through its own condition, and Reconciling names the phase a
+// reconcile stopped in, so status shows which stage failed.
+
+// discoverTargets lists the Vault pods to unseal. When done is true the
+// reconcile stops here and returns result.
+func (r *VaultUnsealerReconciler) discoverTargets(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer, original *opsv1alpha1.VaultUnsealerStatus, retryInterval time.Duration) (_ []corev1.Pod, result ctrl.Result, done bool) {
+	log := logf.FromContext(ctx)
+
+	pods, err := r.getVaultPods(ctx, vaultUnsealer)
+	if err != nil {
+		vaultUnsealer.Status.PodDiscoveryFailures++
+		backoff := podDiscoveryBackoff(vaultUnsealer.Status.PodDiscoveryFailures)
+		log.Error(err, "Failed to get Vault pods, backing off", "consecutiveFailures", vaultUnsealer.Status.PodDiscoveryFailures, "retryAfter", backoff)
+		metrics.ReconciliationErrors.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, podDiscoveryErrorType(err)).Inc()
+		r.setCondition(vaultUnsealer, ConditionTypeTargetsDiscovered, ConditionStatusFalse, ReasonPodDiscoveryFailed, err.Error())
+		r.setCondition(vaultUnsealer, ConditionTypeReconciling, ConditionStatusTrue, ReasonPhaseDiscovery, "Failed to list Vault pods")
+		r.setAllPodsUnsealed(vaultUnsealer, false)
+		// Returning the error would make controller-runtime ignore RequeueAfter
+		// and retry on its own rate limiter instead.
+		return nil, ctrl.Result{RequeueAfter: backoff}, true
+	}
+	vaultUnsealer.Status.PodDiscoveryFailures = 0
+
+	if pruned := pruneDeletedPods(vaultUnsealer, original, pods); len(pruned) > 0 {
+		log.Info("Pruning pods that no longer exist", "pods", pruned)
+	}
+
+	if len(pods) == 0 {
+		selectors := strings.Join(vaultUnsealer.Spec.LabelSelectors(), `" or "`)
+		log.Info("No Vault pods found matching label selector", "labelSelector", selectors)
+		r.setCondition(vaultUnsealer, ConditionTypeTargetsDiscovered, ConditionStatusFalse, ReasonNoPodsFound,
+			fmt.Sprintf("No pods match label selector \"%s\"", selectors))
+		r.setCondition(vaultUnsealer, ConditionTypeReconciling, ConditionStatusTrue, ReasonPhaseDiscovery, "Waiting for Vault pods")
+		r.setAllPodsUnsealed(vaultUnsealer, false)
+		return nil, ctrl.Result{RequeueAfter: retryInterval}, true
+	}
+
+	r.setCondition(vaultUnsealer, ConditionTypeTargetsDiscovered, ConditionStatusTrue, ReasonPodsDiscovered,
+		fmt.Sprintf("Found %d Vault pods", len(pods)))
+	r.clearCondition(vaultUnsealer, ConditionTypePodUnavailable)
+	return pods, ctrl.Result{}, false
+}
+
+// loadKeys reads the unseal keys for vaultUnsealer.
+func (r *VaultUnsealerReconciler) loadKeys(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) ([]secrets.SecretString, error) {
+	log := logf.FromContext(ctx)
+
+	var unsealKeys []secrets.SecretString
+	var skipped []secrets.SkippedKey
+	var err error
+	if len(vaultUnsealer.Spec.Custodians) > 0 {
+		var contributions []secrets.CustodianContribution
+		unsealKeys, skipped, contributions, err = r.SecretsLoader.LoadCustodianKeys(ctx, vaultUnsealer)
+		recordCustodians(vaultUnsealer, contributions)
+	} else {
+		unsealKeys, skipped, err = r.SecretsLoader.LoadUnsealKeysChecked(ctx, vaultUnsealer)
+		vaultUnsealer.Status.Custodians = nil
+	}
+	r.reportSkippedKeys(ctx, vaultUnsealer, skipped)
+	if err != nil {
+		log.Error(err, "Failed to load unseal keys")
+		metrics.ReconciliationErrors.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, "keys_loading").Inc()
+		if errors.Is(err, secrets.ErrAccessNotGranted) {
+			r.event(ctx, vaultUnsealer, corev1.EventTypeWarning, ReasonSecretAccessDenied, err.Error())
+		}
+		reason := ReasonKeysMissing
+		if errors.Is(err, secrets.ErrCustodianQuorum) {
+			reason = ReasonCustodianQuorum
+		}
+		r.setCondition(vaultUnsealer, ConditionTypeKeysLoaded, ConditionStatusFalse, reason, err.Error())
+		r.setCondition(vaultUnsealer, ConditionTypeReconciling, ConditionStatusTrue, ReasonPhaseKeys, "Failed to load unseal keys")
+		r.setAllPodsUnsealed(vaultUnsealer, false)
+		return nil, err
+	}
+
+	log.Info("Loaded unseal keys", "keyCount", len(unsealKeys))
+	vaultUnsealer.Status.EffectiveConfig.KeyThreshold = len(unsealKeys)
+	metrics.UnsealKeysLoaded.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace).Set(float64(len(unsealKeys)))
+	r.recordKeysetAge(ctx, vaultUnsealer, time.Now())
+	r.recordKeySecrets(ctx, vaultUnsealer)
+	r.setCondition(vaultUnsealer, ConditionTypeKeysLoaded, ConditionStatusTrue, ReasonKeysLoaded,
+		fmt.Sprintf("Loaded %d unseal keys", len(unsealKeys)))
+	r.clearCondition(vaultUnsealer, ConditionTypeKeysMissing)
+	return unsealKeys, nil
+}
+
+// skipKeys stands in for the keys phase in monitor-only mode. No Secret is
+// read, so the state left by an earlier keys phase is cleared.
+func (r *VaultUnsealerReconciler) skipKeys(vaultUnsealer *opsv1alpha1.VaultUnsealer) {
+	r.clearCondition(vaultUnsealer, ConditionTypeKeysLoaded)
+	r.clearCondition(vaultUnsealer, ConditionTypeKeysMissing)
+	vaultUnsealer.Status.Custodians = nil
+	vaultUnsealer.Status.KeySecrets = nil
+	metrics.UnsealKeysLoaded.DeleteLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace)
+}
+
+// recordCustodians records in status how many shares each custodian holds
+// and how many were used.
+func recordCustodians(vaultUnsealer *opsv1alpha1.VaultUnsealer, contributions []secrets.CustodianContribution) {
+	custodians := make([]opsv1alpha1.CustodianStatus, 0, len(contributions))
+	for _, contribution := range contributions {
+		custodians = append(custodians, opsv1alpha1.CustodianStatus{
+			Name:            contribution.Name,
+			SharesAvailable: int32(contribution.Available),
+			SharesUsed:      int32(contribution.Used),
+		})
+	}
+	vaultUnsealer.Status.Custodians = custodians
+}
+
+// reportSkippedKeys warns about key entries that were not submitted because
+// they cannot be key shares, so a stray line in a Secret does not go unnoticed.
+func (r *VaultUnsealerReconciler) reportSkippedKeys(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer, skipped []secrets.SkippedKey) {
+	if len(skipped) == 0 {
+		return
+	}
+	log := logf.FromContext(ctx)
+	entries := make([]string, 0, len(skipped))
+	for _, entry := range skipped {
+		log.Info("Skipping invalid unseal key entry", "source", entry.Source, "entry", entry.Entry, "reason", entry.Reason)
+		entries = append(entries, entry.String())
+	}
+	message := fmt.Sprintf("Skipped %d invalid unseal key entries: %s", len(skipped), strings.Join(entries, "; "))
+	r.event(ctx, vaultUnsealer, corev1.EventTypeWarning, ReasonInvalidKeysSkipped, message)
+	addWarning(vaultUnsealer, opsv1alpha1.WarningSourceRuntime, message)
+}
+
+// unsealOutcome summarises the unseal phase.
+type unsealOutcome struct {
+	podStatuses    []opsv1alpha1.PodStatus
+	unsealedCount  int
+	activeUnsealed bool
+	failures       podFailures
+	throttled      rateLimitedPods
+	// sealMigrationPods lists the pods that reported a pending seal migration.
+	sealMigrationPods []string
+	// canaryHeld counts sealed pods that got no keys while canaries soak.
+	canaryHeld int
+	canary     *canaryGate
+	// deferred lists the pods left for the next reconcile once
+	// spec.reconcileBudget ran out.
+	deferred []string
+	// seal is the seal configuration reported by the first pod that answered.
+	seal sealConfig
+	// unsealedNow is true when the loaded keys unsealed at least one pod.
+	unsealedNow bool
+}
+
+// unsealTargets checks every discovered pod and submits keys to the sealed
+// ones, honouring per-pod backoff and the error policy. Pods not yet reached
+// when budgetDeadline, if set, has passed are deferred to the next reconcile.
+// When requested is not empty only those pods are checked, without backoff,
+// and the others keep their last status.
+func (r *VaultUnsealerReconciler) unsealTargets(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer, pods []corev1.Pod, unsealKeys []secrets.SecretString, progress *unsealProgress, budgetDeadline time.Time, requested map[string]string) unsealOutcome {
+	log := logf.FromContext(ctx)
+
+	previousPods := make(map[string]opsv1alpha1.PodStatus, len(vaultUnsealer.Status.Pods))
+	for _, podStatus := range vaultUnsealer.Status.Pods {
+		previousPods[podStatus.Name] = podStatus
+	}
+	podStatuses := make([]opsv1alpha1.PodStatus, 0, len(pods))
+
+	unsealedCount := 0
+	activeUnsealed := false
+	var failures podFailures
+	var throttled rateLimitedPods
+	var sealMigrationPods []string
+	now := time.Now()
+	canary := r.newCanaryGate(vaultUnsealer, pods, previousPods, now)
+	canaryHeld := 0
+	attempted := false
+	var deferred []string
+	var seal sealConfig
+	unsealedNow := false
+	for i, pod := range pods {
+		if attempted {
+			// Keys went to the previous pod; show how far the reconcile got
+			// before moving on to the next one.
+			progress.report(ctx, r, unsealedCount)
+			attempted = false
+		}
+		if i > 0 && !budgetDeadline.IsZero() && !time.Now().Before(budgetDeadline) {
+			unchecked := pods[i:]
+			deferred = podNames(unchecked)
+			log.Info("Reconcile budget used up, deferring the remaining pods", "podsDeferred", deferred)
+			for _, podStatus := range uncheckedPodStatuses(unchecked, previousPods, "Not checked yet: spec.reconcileBudget ran out, next reconcile continues here") {
+				if podStatus.State == opsv1alpha1.PodStateUnsealed {
+					// Count the last known state so Ready does not flap
+					// while the pass is split across reconciles.
+					unsealedCount++
+					activeUnsealed = activeUnsealed || podStatus.Role == opsv1alpha1.PodRoleActive
+				}
+				podStatuses = append(podStatuses, podStatus)
+			}
+			break
+		}
+		requestedAt, isRequested := requested[pod.Name]
+		if len(requested) > 0 && !isRequested {
+			podStatus := untouchedPodStatus(&pod, previousPods)
+			if podStatus.State == opsv1alpha1.PodStateUnsealed {
+				unsealedCount++
+				activeUnsealed = activeUnsealed || podStatus.Role == opsv1alpha1.PodRoleActive
+			}
+			podStatuses = append(podStatuses, podStatus)
+			continue
+		}
+		vaultUnsealer.Status.PodsChecked = append(vaultUnsealer.Status.PodsChecked, pod.Name)
+		previous := previousPods[pod.Name]
+		revision := podRevision(&pod)
+		if previous.Revision != "" && previous.Revision != revision {
+			// A pod replaced by a rollout starts without the old pod's backoff.
+			previous.ConsecutiveFailures = 0
+			previous.NextAttemptTime = nil
+		}
+		podStatus := opsv1alpha1.PodStatus{
+			Name:           pod.Name,
+			Revision:       revision,
+			State:          opsv1alpha1.PodStateUnknown,
+			LastUnsealTime: previous.LastUnsealTime,
+			Keys:           previous.Keys,
+			UnsealHistory:  previous.UnsealHistory,
+
+			ReconcileRequestedAt: previous.ReconcileRequestedAt,
+		}
+		if isRequested {
+			podStatus.ReconcileRequestedAt = requestedAt
+		}
+
+		if !r.isPodUnsealable(&pod, vaultUnsealer) {
+			log.Info("Pod is not ready, skipping", "pod", pod.Name)
+			podStatus.Message = "Pod is not ready"
+			podStatuses = append(podStatuses, podStatus)
+			continue
+		}
+
+		if inBackoff(previous, now) && !isRequested {
+			log.Info("Pod is backing off after failures, skipping", "pod", pod.Name,
+				"consecutiveFailures", previous.ConsecutiveFailures, "nextAttemptTime", previous.NextAttemptTime.Time)
+			podStatuses = append(podStatuses, previous)
+			continue
+		}
+
+		release, heldBy, locked := r.podLocks.tryLock(client.ObjectKeyFromObject(&pod), client.ObjectKeyFromObject(vaultUnsealer))
+		if !locked {
+			log.Info("Another reconcile is unsealing the pod, skipping", "pod", pod.Name, "heldBy", heldBy.String())
+			podStatus.Message = fmt.Sprintf("Skipped while VaultUnsealer %s is unsealing the pod", heldBy)
+			podStatuses = append(podStatuses, podStatus)
+			continue
+		}
+
+		// Pods held back by the canary gate only have their seal status read.
+		held := !canary.allows(pod.Name)
+		submitKeys := unsealKeys
+		if held {
+			submitKeys = nil
+		}
+		podCtx, cancelPod := podContext(ctx, len(pods)-i)
+		started := time.Now()
+		result, err := func() (podUnsealResult, error) {
+			defer release()
+			return r.checkAndUnsealPod(podCtx, &pod, vaultUnsealer, submitKeys, quarantinedKeys(previous.Keys))
+		}()
+		cancelPod()
+		if seal.threshold == 0 {
+			seal = result.seal
+		}
+		if !held && (err != nil || result.sealed || result.unsealedNow) {
+			canary.join(pod.Name)
+		}
+		if result.sealed {
+			podStatus.UnsealNonce = result.round.nonce
+			podStatus.UnsealProgress = int32(result.round.progress)
+			podStatus.UnsealThreshold = int32(result.round.threshold)
+		}
+		attemptResult := unsealAttemptResult(result, err)
+		attempted = attemptResult != ""
+		podStatus.UnsealHistory = appendUnsealEvent(previous.UnsealHistory, attemptResult, started, time.Since(started))
+		podStatus.Keys = mergeKeyStats(previous.Keys, unsealKeys, result.submissions)
+		for _, stat := range newlyQuarantined(previous.Keys, podStatus.Keys) {
+			log.Info("Quarantining repeatedly rejected unseal key", "pod", pod.Name,
+				"keyIndex", stat.Index, "keyFingerprint", stat.Fingerprint, "rejections", stat.ConsecutiveRejections)
+			r.event(ctx, vaultUnsealer, corev1.EventTypeWarning, ReasonKeyQuarantined,
+				fmt.Sprintf("Unseal key #%d (fingerprint %s) was rejected by pod %s %d times in a row and will no longer be submitted to it",
+					stat.Index, stat.Fingerprint, pod.Name, stat.ConsecutiveRejections))
+		}
+		if retryAfter, rateLimited := vault.IsRateLimited(err); rateLimited {
+			wait := rateLimitBackoff(retryAfter)
+			log.Info("Vault rate limit quota exceeded, backing off", "pod", pod.Name, "retryAfter", retryAfter, "nextAttemptIn", wait)
+			metrics.VaultRateLimited.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, pod.Name).Inc()
+			metrics.VaultConnectionStatus.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, pod.Name).Set(1)
+			podStatus.Message = fmt.Sprintf("Throttled by a Vault rate limit quota, next attempt in %s", wait)
+			recordRateLimited(&podStatus, previous, wait, now)
+			throttled.add(pod.Name, wait)
+			podStatuses = append(podStatuses, podStatus)
+			continue
+		}
+		if err != nil {
+			log.Error(err, "Failed to check/unseal pod", "pod", pod.Name)
+			metrics.ReconciliationErrors.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, failures.add(pod.Name, err)).Inc()
+			metrics.UnsealAttempts.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, pod.Name, "failed").Inc()
+			metrics.VaultConnectionStatus.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, pod.Name).Set(0)
+			podStatus.Message = err.Error()
+			if vault.IsNotVaultEndpoint(err) {
+				podStatus.State = opsv1alpha1.PodStateNotAVaultEndpoint
+			}
+			recordPodFailure(&podStatus, previous, now)
+			podStatuses = append(podStatuses, podStatus)
+			if errorPolicy(vaultUnsealer) == opsv1alpha1.ErrorPolicyAbortReconcile {
+				unchecked := pods[i+1:]
+				podStatuses = append(podStatuses, uncheckedPodStatuses(unchecked, previousPods, "Not checked: reconcile aborted by errorPolicy")...)
+				log.Info("Aborting reconcile after pod failure", "pod", pod.Name, "podsNotChecked", len(unchecked))
+				r.event(ctx, vaultUnsealer, corev1.EventTypeWarning, ReasonReconcileAborted,
+					fmt.Sprintf("Stopped after pod %s failed, %d pods were not checked: %v", pod.Name, len(unchecked), err))
+				break
+			}
+			continue
+		}
+
+		if !result.sealed {
+			vaultUnsealer.Status.UnsealedPods = append(vaultUnsealer.Status.UnsealedPods, pod.Name)
+			unsealedCount++
+			metrics.UnsealAttempts.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, pod.Name, "success").Inc()
+			metrics.VaultConnectionStatus.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, pod.Name).Set(1)
+
+			podStatus.State = opsv1alpha1.PodStateUnsealed
+			podStatus.Role = result.role
+			if result.role == opsv1alpha1.PodRoleActive {
+				activeUnsealed = true
+			}
+			if result.unsealedNow {
+				podStatus.LastUnsealTime = &metav1.Time{Time: time.Now()}
+				unsealedNow = true
+			}
+			podStatus.Stable = unsealStable(podStatus.LastUnsealTime, time.Now())
+			podStatuses = append(podStatuses, podStatus)
+
+			if vaultUnsealer.Spec.Mode.StopsAfterFirstUnseal() && len(requested) == 0 {
+				log.Info("Stopping after first successful unseal", "pod", pod.Name)
+				break
+			}
+		} else {
+			metrics.VaultConnectionStatus.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, pod.Name).Set(1)
+			podStatus.State = opsv1alpha1.PodStateSealed
+			switch {
+			case vaultUnsealer.Spec.Mode.MonitorOnly:
+				podStatus.Message = "Sealed, keys are not submitted in monitor-only mode"
+			case result.sealMigration && !vaultUnsealer.Spec.SealMigration:
+				podStatus.Message = "Seal migration in progress, keys are not submitted without spec.sealMigration"
+			case held:
+				podStatus.Message = canary.message()
+				canaryHeld++
+			}
+			podStatuses = append(podStatuses, podStatus)
+		}
+		if result.sealMigration {
+			sealMigrationPods = append(sealMigrationPods, pod.Name)
+		}
+	}
+	return unsealOutcome{
+		podStatuses:    podStatuses,
+		unsealedCount:  unsealedCount,
+		activeUnsealed: activeUnsealed,
+		failures:       failures,
+		throttled:      throttled,
+
+		sealMigrationPods: sealMigrationPods,
+		canaryHeld:        canaryHeld,
+		canary:            canary,
+		deferred:          deferred,
+		seal:              seal,
+		unsealedNow:       unsealedNow,
+	}
+}
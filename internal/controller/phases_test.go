@@ -0,0 +1,173 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/metrics"
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+func assertCondition(t *testing.T, vu *opsv1alpha1.VaultUnsealer, conditionType, status, reason string) {
+	t.Helper()
+	condition := findCondition(vu, conditionType)
+	require.NotNil(t, condition, "condition %s", conditionType)
+	assert.Equal(t, status, condition.Status, "condition %s", conditionType)
+	assert.Equal(t, reason, condition.Reason, "condition %s", conditionType)
+}
+
+func TestReconcile_PhaseConditions(t *testing.T) {
+	keys := []string{"key-1", "key-2"}
+	fake := vaultfake.NewServer(vaultfake.WithKeys(keys, 2))
+	defer fake.Close()
+
+	// Discovery stops the reconcile when no pods match.
+	vu := newFinalizerTestUnsealer().WithVaultURL("http://vault")
+	vu.Status.Conditions = []opsv1alpha1.Condition{
+		{Type: ConditionTypeKeysMissing, Status: ConditionStatusTrue, Reason: ReasonKeysMissing},
+		{Type: ConditionTypePodUnavailable, Status: ConditionStatusTrue, Reason: ReasonPodNotReady},
+	}
+	r := newFakeReconciler(t, vu)
+	got := reconcileAndGet(t, r)
+	assertCondition(t, got, ConditionTypeTargetsDiscovered, ConditionStatusFalse, ReasonNoPodsFound)
+	assertCondition(t, got, ConditionTypeReconciling, ConditionStatusTrue, ReasonPhaseDiscovery)
+	assert.Nil(t, findCondition(got, ConditionTypeKeysLoaded), "keys phase did not run")
+
+	// With a pod but no Secret, the keys phase fails.
+	pod, _ := newFakeVaultPod(fake)
+	pod.Labels = map[string]string{"app": "vault"}
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	require.NoError(t, r.Create(context.Background(), pod))
+	key := types.NamespacedName{Namespace: "vault", Name: "main"}
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	require.Error(t, err)
+	got = &opsv1alpha1.VaultUnsealer{}
+	require.NoError(t, r.Get(context.Background(), key, got))
+	assertCondition(t, got, ConditionTypeTargetsDiscovered, ConditionStatusTrue, ReasonPodsDiscovered)
+	assertCondition(t, got, ConditionTypeKeysLoaded, ConditionStatusFalse, ReasonKeysMissing)
+	assertCondition(t, got, ConditionTypeReconciling, ConditionStatusTrue, ReasonPhaseKeys)
+	assert.Nil(t, findCondition(got, ConditionTypePodUnavailable), "legacy condition is removed")
+	assert.False(t, got.Status.AllPodsUnsealed)
+
+	// Once the keys exist every phase completes.
+	keysJSON, err := json.Marshal(keys)
+	require.NoError(t, err)
+	require.NoError(t, r.Create(context.Background(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": keysJSON},
+	}))
+	got = reconcileAndGet(t, r)
+	assertCondition(t, got, ConditionTypeKeysLoaded, ConditionStatusTrue, ReasonKeysLoaded)
+	assertCondition(t, got, ConditionTypeReconciling, ConditionStatusFalse, ReasonReconcileSuccess)
+	assertCondition(t, got, ConditionTypeReady, ConditionStatusTrue, ReasonReconcileSuccess)
+	assert.Nil(t, findCondition(got, ConditionTypeKeysMissing), "legacy condition is removed")
+	assert.True(t, got.Status.AllPodsUnsealed)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.AllPodsUnsealed.WithLabelValues("main", "vault")))
+}
+
+func TestReconcile_WarnsAboutSkippedKeyEntries(t *testing.T) {
+	keys := []string{"key-1", "key-2"}
+	fake := vaultfake.NewServer(vaultfake.WithKeys(keys, 2))
+	defer fake.Close()
+
+	pod, _ := newFakeVaultPod(fake)
+	pod.Labels = map[string]string{"app": "vault"}
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": []byte("# shares for vault-0\nkey-1\nkey-2\n")},
+	}
+	r := newFakeReconciler(t, newFinalizerTestUnsealer().WithVaultURL("http://vault"), pod, secret)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+
+	got := reconcileAndGet(t, r)
+	assertCondition(t, got, ConditionTypeKeysLoaded, ConditionStatusTrue, ReasonKeysLoaded)
+	assert.False(t, fake.Sealed())
+	assert.Equal(t, 2, fake.UnsealRequests(), "the comment line is never submitted")
+	require.NotEmpty(t, recorder.Events)
+	assert.Contains(t, <-recorder.Events, "Warning InvalidUnsealKeysSkipped Skipped 1 invalid unseal key entries: "+
+		"secret vault/vault-keys key keys.json entry 1: contains characters outside the hex and base64 alphabets")
+	require.Len(t, got.Status.Warnings, 1)
+	assert.Equal(t, opsv1alpha1.WarningSourceRuntime, got.Status.Warnings[0].Source)
+	assert.Contains(t, got.Status.Warnings[0].Message, "keys.json entry 1")
+}
+
+func TestReconcile_CustodianQuorum(t *testing.T) {
+	keys := []string{"key-1", "key-2", "key-3"}
+	fake := vaultfake.NewServer(vaultfake.WithKeys(keys, 2))
+	defer fake.Close()
+
+	pod, _ := newFakeVaultPod(fake)
+	pod.Labels = map[string]string{"app": "vault"}
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	alice := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "alice-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys": []byte("key-1\nkey-2\n")},
+	}
+	bob := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bob-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys": []byte("not a share\n")},
+	}
+	vu := newFinalizerTestUnsealer().WithVaultURL("http://vault").
+		WithCustodian("alice", "alice-keys", "keys", 0).
+		WithCustodian("bob", "bob-keys", "keys", 0).
+		WithMinCustodians(2).
+		WithKeyThreshold(2)
+	vu.Spec.UnsealKeysSecretRefs = nil
+	r := newFakeReconciler(t, vu, pod, alice, bob)
+
+	key := types.NamespacedName{Namespace: "vault", Name: "main"}
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	require.ErrorContains(t, err, "1 of 2 custodians contributed shares")
+	got := &opsv1alpha1.VaultUnsealer{}
+	require.NoError(t, r.Get(context.Background(), key, got))
+	assertCondition(t, got, ConditionTypeKeysLoaded, ConditionStatusFalse, ReasonCustodianQuorum)
+	assert.True(t, fake.Sealed())
+	assert.Zero(t, fake.UnsealRequests(), "no share is submitted below the quorum")
+	assert.Equal(t, []opsv1alpha1.CustodianStatus{
+		{Name: "alice", SharesAvailable: 2, SharesUsed: 2},
+		{Name: "bob"},
+	}, got.Status.Custodians)
+
+	bob.Data["keys"] = []byte("key-3\n")
+	require.NoError(t, r.Update(context.Background(), bob))
+
+	got = reconcileAndGet(t, r)
+	assertCondition(t, got, ConditionTypeKeysLoaded, ConditionStatusTrue, ReasonKeysLoaded)
+	assert.False(t, fake.Sealed())
+	assert.Equal(t, []opsv1alpha1.CustodianStatus{
+		{Name: "alice", SharesAvailable: 2, SharesUsed: 1},
+		{Name: "bob", SharesAvailable: 1, SharesUsed: 1},
+	}, got.Status.Custodians)
+}
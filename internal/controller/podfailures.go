@@ -0,0 +1,73 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/panteparak/vault-unsealer/internal/vault"
+)
+
+// podFailures collects the pods whose Vault API could not be used in one
+// reconcile, keeping CA bundle and TLS failures and pods that are not Vault
+// at all apart so a CA misconfiguration or an overly broad selector is not
+// mistaken for Vault being down.
+type podFailures struct {
+	caBundle []string
+	tls      []string
+	notVault []string
+	api      []string
+}
+
+// add records a failure of pod and returns its error_type metric label.
+func (f *podFailures) add(pod string, err error) string {
+	if errors.Is(err, errCABundleUnusable) {
+		f.caBundle = append(f.caBundle, pod)
+		return "ca_bundle"
+	}
+	if vault.IsTLSError(err) {
+		f.tls = append(f.tls, pod)
+		return "tls"
+	}
+	if vault.IsNotVaultEndpoint(err) {
+		f.notVault = append(f.notVault, pod)
+		return "not_vault_endpoint"
+	}
+	f.api = append(f.api, pod)
+	return "vault_api"
+}
+
+// condition returns the reason and message of the VaultAPIFailure condition,
+// or false when no pod failed. CA bundle and TLS failures and pods that are
+// not Vault take precedence since they will not resolve without a
+// configuration change.
+func (f *podFailures) condition() (string, string, bool) {
+	switch {
+	case len(f.caBundle) > 0:
+		return ReasonCABundleInvalid, fmt.Sprintf("CA bundle could not be loaded for pods %s; see the %s condition", strings.Join(f.caBundle, ", "), ConditionTypeCABundleInvalid), true
+	case len(f.tls) > 0:
+		return ReasonTLSVerificationFailed, fmt.Sprintf("TLS verification failed for pods %s; check spec.vault.caBundleSecretRef", strings.Join(f.tls, ", ")), true
+	case len(f.notVault) > 0:
+		return ReasonNotAVaultEndpoint, fmt.Sprintf("Pods %s do not serve the Vault API; check spec.vaultLabelSelector", strings.Join(f.notVault, ", ")), true
+	case len(f.api) > 0:
+		return ReasonVaultAPIError, fmt.Sprintf("Vault API requests failed for pods %s", strings.Join(f.api, ", ")), true
+	default:
+		return "", "", false
+	}
+}
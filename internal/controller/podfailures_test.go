@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/vault"
+)
+
+func TestPodFailures_Condition(t *testing.T) {
+	var failures podFailures
+	_, _, failed := failures.condition()
+	assert.False(t, failed)
+
+	assert.Equal(t, "vault_api", failures.add("vault-0", errors.New("connection refused")))
+	reason, _, failed := failures.condition()
+	assert.True(t, failed)
+	assert.Equal(t, ReasonVaultAPIError, reason)
+
+	assert.Equal(t, "tls", failures.add("vault-1", &tls.CertificateVerificationError{Err: errors.New("bad certificate")}))
+	reason, message, _ := failures.condition()
+	assert.Equal(t, ReasonTLSVerificationFailed, reason)
+	assert.Contains(t, message, "vault-1")
+
+	failures = podFailures{}
+	failures.add("vault-0", errors.New("connection refused"))
+	assert.Equal(t, "not_vault_endpoint", failures.add("exporter-0", fmt.Errorf("failed to get seal status: %w", vault.ErrNotVaultEndpoint)))
+	reason, message, _ = failures.condition()
+	assert.Equal(t, ReasonNotAVaultEndpoint, reason)
+	assert.Contains(t, message, "exporter-0")
+
+	failures.add("vault-1", &tls.CertificateVerificationError{Err: errors.New("bad certificate")})
+	assert.Equal(t, "ca_bundle", failures.add("vault-2", fmt.Errorf("failed to create vault client: %w", errCABundleUnusable)))
+	reason, message, _ = failures.condition()
+	assert.Equal(t, ReasonCABundleInvalid, reason)
+	assert.Contains(t, message, "vault-2")
+}
+
+func TestReconcile_NotAVaultEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "exporter-0", Namespace: "vault", Labels: map[string]string{"app": "vault"}},
+		Status: corev1.PodStatus{
+			PodIP:      strings.TrimPrefix(server.URL, "http://"),
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": []byte("key-1\n")},
+	}
+	vu := newFinalizerTestUnsealer().WithVaultURL("http://vault")
+
+	got := reconcileAndGet(t, newFakeReconciler(t, vu, pod, secret))
+	require.Len(t, got.Status.Pods, 1)
+	assert.Equal(t, opsv1alpha1.PodStateNotAVaultEndpoint, got.Status.Pods[0].State)
+	assertCondition(t, got, ConditionTypeVaultAPIFailure, ConditionStatusTrue, ReasonNotAVaultEndpoint)
+}
+
+func TestReconcile_TLSVerificationFailed(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-0", Namespace: "vault", Labels: map[string]string{"app": "vault"}},
+		Status: corev1.PodStatus{
+			PodIP:      strings.TrimPrefix(server.URL, "https://"),
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	keysJSON, err := json.Marshal([]string{"key-1"})
+	require.NoError(t, err)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": keysJSON},
+	}
+	vu := newFinalizerTestUnsealer().WithVaultURL("https://vault")
+
+	got := reconcileAndGet(t, newFakeReconciler(t, vu, pod, secret))
+	var condition *opsv1alpha1.Condition
+	for i := range got.Status.Conditions {
+		if got.Status.Conditions[i].Type == ConditionTypeVaultAPIFailure {
+			condition = &got.Status.Conditions[i]
+		}
+	}
+	require.NotNil(t, condition)
+	assert.Equal(t, ConditionStatusTrue, condition.Status)
+	assert.Equal(t, ReasonTLSVerificationFailed, condition.Reason)
+}
+
+func TestReconcile_InvalidCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-0", Namespace: "vault", Labels: map[string]string{"app": "vault"}},
+		Status: corev1.PodStatus{
+			PodIP:      strings.TrimPrefix(server.URL, "https://"),
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	keysJSON, err := json.Marshal([]string{"key-1"})
+	require.NoError(t, err)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": keysJSON},
+	}
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-ca", Namespace: "vault"},
+		Data:       map[string][]byte{"ca.crt": []byte("not a PEM bundle")},
+	}
+	vu := newFinalizerTestUnsealer().WithVaultURL("https://vault").WithCABundleSecret("vault-ca", "ca.crt")
+	r := newFakeReconciler(t, vu, pod, secret, caSecret)
+
+	_, err = r.directVaultClient(context.Background(), vu, server.URL)
+	require.ErrorIs(t, err, errCABundleUnusable)
+	assert.ErrorContains(t, err, "vault/vault-ca")
+
+	got := reconcileAndGet(t, r)
+	require.Len(t, got.Status.Pods, 1)
+	assert.Contains(t, got.Status.Pods[0].Message, "CA bundle cannot be used")
+	assertCondition(t, got, ConditionTypeVaultAPIFailure, ConditionStatusTrue, ReasonCABundleInvalid)
+	assertCondition(t, got, ConditionTypeCABundleInvalid, ConditionStatusTrue, ReasonCABundleInvalid)
+}
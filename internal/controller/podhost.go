@@ -0,0 +1,235 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	corev1 "k8s.io/api/core/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// PodHostData is what spec.vault.perPodHostTemplate is rendered with.
+type PodHostData struct {
+	// Ordinal is the StatefulSet ordinal parsed from the pod name. It is
+	// only parsed when the template refers to it.
+	Ordinal   int
+	PodName   string
+	Namespace string
+}
+
+// RenderPodHost renders a per-pod host template.
+func RenderPodHost(hostTemplate string, data PodHostData) (string, error) {
+	tmpl, err := template.New("perPodHost").Option("missingkey=error").Parse(hostTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid per-pod host template: %w", err)
+	}
+	var host strings.Builder
+	if err := tmpl.Execute(&host, data); err != nil {
+		return "", fmt.Errorf("failed to render per-pod host template: %w", err)
+	}
+	if host.Len() == 0 {
+		return "", fmt.Errorf("per-pod host template rendered an empty host")
+	}
+	return host.String(), nil
+}
+
+// StatefulSetOrdinal extracts the StatefulSet ordinal from a pod name such
+// as "vault-2".
+func StatefulSetOrdinal(podName string) (int, error) {
+	idx := strings.LastIndex(podName, "-")
+	if idx < 0 || idx == len(podName)-1 {
+		return 0, fmt.Errorf("pod name %q has no ordinal suffix", podName)
+	}
+	ordinal, err := strconv.Atoi(podName[idx+1:])
+	if err != nil || ordinal < 0 {
+		return 0, fmt.Errorf("pod name %q has no ordinal suffix", podName)
+	}
+	return ordinal, nil
+}
+
+// usesOrdinal reports whether a per-pod host template refers to .Ordinal.
+// A template that does not parse is reported as using it; rendering it
+// fails anyway.
+func usesOrdinal(hostTemplate string) bool {
+	tmpl, err := template.New("perPodHost").Parse(hostTemplate)
+	if err != nil {
+		return true
+	}
+	return nodeUsesOrdinal(tmpl.Root)
+}
+
+func nodeUsesOrdinal(node parse.Node) bool {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return false
+		}
+		for _, child := range n.Nodes {
+			if nodeUsesOrdinal(child) {
+				return true
+			}
+		}
+	case *parse.ActionNode:
+		return nodeUsesOrdinal(n.Pipe)
+	case *parse.PipeNode:
+		if n == nil {
+			return false
+		}
+		for _, cmd := range n.Cmds {
+			if nodeUsesOrdinal(cmd) {
+				return true
+			}
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			if nodeUsesOrdinal(arg) {
+				return true
+			}
+		}
+	case *parse.FieldNode:
+		return n.Ident[0] == "Ordinal"
+	case *parse.VariableNode:
+		return len(n.Ident) > 1 && n.Ident[1] == "Ordinal"
+	case *parse.ChainNode:
+		return nodeUsesOrdinal(n.Node)
+	case *parse.IfNode:
+		return branchUsesOrdinal(&n.BranchNode)
+	case *parse.RangeNode:
+		return branchUsesOrdinal(&n.BranchNode)
+	case *parse.WithNode:
+		return branchUsesOrdinal(&n.BranchNode)
+	case *parse.TemplateNode:
+		return nodeUsesOrdinal(n.Pipe)
+	}
+	return false
+}
+
+func branchUsesOrdinal(n *parse.BranchNode) bool {
+	return nodeUsesOrdinal(n.Pipe) || nodeUsesOrdinal(n.List) || nodeUsesOrdinal(n.ElseList)
+}
+
+// podVaultURL returns the address of pod's Vault API: the pod's address
+// annotation when overrides are allowed, the rendered per-pod host when a
+// template is set, otherwise spec.vault.address with the pod IP substituted
+// for the service host.
+func podVaultURL(vaultUnsealer *opsv1alpha1.VaultUnsealer, pod *corev1.Pod) (string, error) {
+	if address, ok := podAddressOverride(vaultUnsealer, pod); ok {
+		return parsePodAddress(address)
+	}
+	if hostTemplate := vaultUnsealer.Spec.Vault.PerPodHostTemplate; hostTemplate != "" {
+		return perPodURL(vaultUnsealer.Spec.Vault.Endpoint(), hostTemplate, pod)
+	}
+
+	vaultURL := strings.Replace(vaultUnsealer.Spec.Vault.Endpoint(), "vault.vault.svc", pod.Status.PodIP, 1)
+	vaultURL = strings.Replace(vaultURL, "vault", pod.Status.PodIP, 1)
+
+	if !strings.HasPrefix(vaultURL, "http") {
+		vaultURL = "http://" + pod.Status.PodIP + ":8200"
+	}
+	if name := vaultUnsealer.Spec.Vault.ContainerName; name != "" {
+		if port, ok := containerAPIPort(pod, name); ok {
+			return withPort(vaultURL, port)
+		}
+	}
+	return vaultURL, nil
+}
+
+// meshSidecarURL points podURL at the mesh sidecar listener on localhost,
+// keeping its path, and returns the original host for the Host header the
+// sidecar routes by.
+func meshSidecarURL(podURL string, sidecar opsv1alpha1.MeshSidecarSpec) (string, string, error) {
+	u, err := url.Parse(podURL)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid vault URL: %w", err)
+	}
+	host := u.Host
+	u.Scheme = sidecar.Scheme
+	if u.Scheme == "" {
+		u.Scheme = opsv1alpha1.DefaultMeshSidecarScheme
+	}
+	u.Host = net.JoinHostPort("localhost", strconv.Itoa(int(sidecar.Port)))
+	return u.String(), host, nil
+}
+
+// withPort replaces the port of rawURL.
+func withPort(rawURL string, port int32) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid vault URL: %w", err)
+	}
+	u.Host = net.JoinHostPort(u.Hostname(), strconv.Itoa(int(port)))
+	return u.String(), nil
+}
+
+// podAddressOverride returns the pod's address annotation, if the
+// VaultUnsealer honours it.
+func podAddressOverride(vaultUnsealer *opsv1alpha1.VaultUnsealer, pod *corev1.Pod) (string, bool) {
+	if !vaultUnsealer.Spec.Vault.AllowPodAddressOverride {
+		return "", false
+	}
+	address, ok := pod.Annotations[opsv1alpha1.AnnotationPodAddress]
+	return address, ok
+}
+
+// parsePodAddress validates the value of a pod's address annotation, an
+// http or https URL with a host.
+func parsePodAddress(address string) (string, error) {
+	u, err := url.Parse(address)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s annotation: %w", opsv1alpha1.AnnotationPodAddress, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid %s annotation %q: must be an http or https URL with a host", opsv1alpha1.AnnotationPodAddress, address)
+	}
+	return address, nil
+}
+
+// perPodURL replaces the host of baseURL with the rendered template for pod,
+// keeping the scheme, port and path. Only templates using .Ordinal need the
+// pod to belong to a StatefulSet.
+func perPodURL(baseURL, hostTemplate string, pod *corev1.Pod) (string, error) {
+	data := PodHostData{PodName: pod.Name, Namespace: pod.Namespace}
+	if usesOrdinal(hostTemplate) {
+		ordinal, err := StatefulSetOrdinal(pod.Name)
+		if err != nil {
+			return "", err
+		}
+		data.Ordinal = ordinal
+	}
+	host, err := RenderPodHost(hostTemplate, data)
+	if err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid vault URL: %w", err)
+	}
+	if port := u.Port(); port != "" {
+		host = net.JoinHostPort(host, port)
+	}
+	u.Host = host
+	return u.String(), nil
+}
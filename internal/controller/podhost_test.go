@@ -0,0 +1,170 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+func newPodHostTestPod(name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "vault"},
+		Status:     corev1.PodStatus{PodIP: "10.0.0.7"},
+	}
+}
+
+func TestStatefulSetOrdinal(t *testing.T) {
+	ordinal, err := StatefulSetOrdinal("vault-unsealer-controller-manager-3")
+	require.NoError(t, err)
+	assert.Equal(t, 3, ordinal)
+
+	for _, name := range []string{"", "manager", "manager-", "manager-abc"} {
+		_, err := StatefulSetOrdinal(name)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestUsesOrdinal(t *testing.T) {
+	for hostTemplate, want := range map[string]bool{
+		"vault-{{ .Ordinal }}.example.com":                                 true,
+		"{{ if eq $.Ordinal 0 }}leader{{ else }}vault{{ end }}":            true,
+		"{{ with .PodName }}{{ . }}{{ end }}-{{ printf \"%d\" .Ordinal }}": true,
+		"{{ .PodName }}.vault-internal.{{ .Namespace }}.svc":               false,
+		"vault.example.com": false,
+	} {
+		assert.Equal(t, want, usesOrdinal(hostTemplate), hostTemplate)
+	}
+}
+
+func TestRenderPodHost(t *testing.T) {
+	host, err := RenderPodHost("{{ .PodName }}.{{ .Namespace }}.example.com", PodHostData{PodName: "vault-1", Namespace: "vault"})
+	require.NoError(t, err)
+	assert.Equal(t, "vault-1.vault.example.com", host)
+
+	_, err = RenderPodHost("vault-{{ .Ordinal", PodHostData{})
+	assert.Error(t, err, "unparseable template")
+
+	_, err = RenderPodHost("vault-{{ .Index }}", PodHostData{})
+	assert.Error(t, err, "unknown field")
+
+	_, err = RenderPodHost("{{ if false }}x{{ end }}", PodHostData{})
+	assert.Error(t, err, "empty host")
+}
+
+func TestPodVaultURL_PerPodHostTemplate(t *testing.T) {
+	vu := &opsv1alpha1.VaultUnsealer{Spec: opsv1alpha1.VaultUnsealerSpec{Vault: opsv1alpha1.VaultConnectionSpec{
+		URL:                "https://vault.example.com:8443/prefix",
+		PerPodHostTemplate: "vault-{{ .Ordinal }}.vault.example.com",
+	}}}
+
+	got, err := podVaultURL(vu, newPodHostTestPod("vault-2"))
+	require.NoError(t, err)
+	assert.Equal(t, "https://vault-2.vault.example.com:8443/prefix", got)
+
+	vu.Spec.Vault.URL = "https://vault.example.com"
+	got, err = podVaultURL(vu, newPodHostTestPod("vault-0"))
+	require.NoError(t, err)
+	assert.Equal(t, "https://vault-0.vault.example.com", got)
+
+	_, err = podVaultURL(vu, newPodHostTestPod("vault"))
+	assert.Error(t, err, "pod name without an ordinal")
+
+	vu.Spec.Vault.PerPodHostTemplate = "{{ .PodName }}.vault-internal.{{ .Namespace }}.svc"
+	got, err = podVaultURL(vu, newPodHostTestPod("vault"))
+	require.NoError(t, err, "the template does not use .Ordinal")
+	assert.Equal(t, "https://vault.vault-internal.vault.svc", got)
+}
+
+func TestPodVaultURL_PodIP(t *testing.T) {
+	vu := &opsv1alpha1.VaultUnsealer{Spec: opsv1alpha1.VaultUnsealerSpec{Vault: opsv1alpha1.VaultConnectionSpec{
+		URL: "http://vault.vault.svc:8200",
+	}}}
+
+	got, err := podVaultURL(vu, newPodHostTestPod("vault-0"))
+	require.NoError(t, err)
+	assert.Equal(t, "http://10.0.0.7:8200", got)
+}
+
+func TestMeshSidecarURL(t *testing.T) {
+	got, host, err := meshSidecarURL("https://10.0.0.7:8200/vault", opsv1alpha1.MeshSidecarSpec{Port: 15001})
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:15001/vault", got)
+	assert.Equal(t, "10.0.0.7:8200", host)
+
+	got, host, err = meshSidecarURL("http://vault-0.vault-internal.vault.svc:8200", opsv1alpha1.MeshSidecarSpec{Scheme: "https", Port: 15443})
+	require.NoError(t, err)
+	assert.Equal(t, "https://localhost:15443", got)
+	assert.Equal(t, "vault-0.vault-internal.vault.svc:8200", host)
+}
+
+func TestPodVaultURL_AddressAnnotation(t *testing.T) {
+	vu := &opsv1alpha1.VaultUnsealer{Spec: opsv1alpha1.VaultUnsealerSpec{Vault: opsv1alpha1.VaultConnectionSpec{
+		Address:            "https://vault.example.com:8443",
+		PerPodHostTemplate: "vault-{{ .Ordinal }}.vault.example.com",
+	}}}
+	pod := newPodHostTestPod("vault-0")
+	pod.Annotations = map[string]string{opsv1alpha1.AnnotationPodAddress: "https://10.1.2.3:8201"}
+
+	got, err := podVaultURL(vu, pod)
+	require.NoError(t, err)
+	assert.Equal(t, "https://vault-0.vault.example.com:8443", got, "the annotation is ignored unless allowed")
+
+	vu.Spec.Vault.AllowPodAddressOverride = true
+	got, err = podVaultURL(vu, pod)
+	require.NoError(t, err)
+	assert.Equal(t, "https://10.1.2.3:8201", got)
+
+	pod.Annotations[opsv1alpha1.AnnotationPodAddress] = "10.1.2.3:8201"
+	_, err = podVaultURL(vu, pod)
+	assert.ErrorContains(t, err, opsv1alpha1.AnnotationPodAddress)
+}
+
+func TestPodVaultURL_ContainerPort(t *testing.T) {
+	vu := &opsv1alpha1.VaultUnsealer{Spec: opsv1alpha1.VaultUnsealerSpec{Vault: opsv1alpha1.VaultConnectionSpec{
+		Address:       "https://vault.vault.svc:443",
+		ContainerName: "vault",
+	}}}
+	pod := newPodHostTestPod("vault-0")
+	pod.Spec.Containers = []corev1.Container{
+		{Name: "envoy", Ports: []corev1.ContainerPort{{Name: "https", ContainerPort: 443}}},
+		{Name: "vault", Ports: []corev1.ContainerPort{
+			{Name: "https-internal", ContainerPort: 8201},
+			{Name: "https", ContainerPort: 8200},
+		}},
+	}
+
+	got, err := podVaultURL(vu, pod)
+	require.NoError(t, err)
+	assert.Equal(t, "https://10.0.0.7:8200", got)
+
+	pod.Spec.Containers[1].Ports = []corev1.ContainerPort{{ContainerPort: 9200}}
+	got, err = podVaultURL(vu, pod)
+	require.NoError(t, err)
+	assert.Equal(t, "https://10.0.0.7:9200", got, "first declared port")
+
+	pod.Spec.Containers[1].Ports = nil
+	got, err = podVaultURL(vu, pod)
+	require.NoError(t, err)
+	assert.Equal(t, "https://10.0.0.7:443", got, "no declared port keeps the address port")
+}
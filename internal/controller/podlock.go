@@ -0,0 +1,52 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// podLocks serialises key submission to each Vault pod within the process.
+// Shares from two reconciles interleaved against one pod, for example from
+// VaultUnsealers with overlapping selectors, reset each other's progress.
+// The zero value is ready to use.
+type podLocks struct {
+	mu   sync.Mutex
+	held map[types.NamespacedName]types.NamespacedName
+}
+
+// tryLock takes the lock for pod on behalf of holder, the VaultUnsealer being
+// reconciled. When another reconcile holds it, ok is false and heldBy names
+// that VaultUnsealer; the caller should skip the pod rather than wait.
+func (l *podLocks) tryLock(pod, holder types.NamespacedName) (release func(), heldBy types.NamespacedName, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if owner, locked := l.held[pod]; locked {
+		return nil, owner, false
+	}
+	if l.held == nil {
+		l.held = make(map[types.NamespacedName]types.NamespacedName)
+	}
+	l.held[pod] = holder
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.held, pod)
+	}, holder, true
+}
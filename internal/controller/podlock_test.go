@@ -0,0 +1,83 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/package controller
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+func TestPodLocks_TryLock(t *testing.T) {
+	var locks podLocks
+	pod := types.NamespacedName{Namespace: "vault", Name: "vault-0"}
+	first := types.NamespacedName{Namespace: "vault", Name: "main"}
+	second := types.NamespacedName{Namespace: "vault", Name: "other"}
+
+	release, _, ok := locks.tryLock(pod, first)
+	require.True(t, ok)
+
+	_, heldBy, ok := locks.tryLock(pod, second)
+	assert.False(t, ok)
+	assert.Equal(t, first, heldBy)
+
+	otherRelease, _, ok := locks.tryLock(types.NamespacedName{Namespace: "vault", Name: "vault-1"}, second)
+	assert.True(t, ok, "other pods are not blocked")
+	otherRelease()
+
+	release()
+	release, _, ok = locks.tryLock(pod, second)
+	assert.True(t, ok, "released lock can be taken again")
+	release()
+}
+
+func TestReconcile_SkipsPodLockedByAnotherReconcile(t *testing.T) {
+	keys := []string{"key-1", "key-2"}
+	fake := vaultfake.NewServer(vaultfake.WithKeys(keys, 2))
+	defer fake.Close()
+
+	pod, _ := newFakeVaultPod(fake)
+	pod.Labels = map[string]string{"app": "vault"}
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	keysJSON, err := json.Marshal(keys)
+	require.NoError(t, err)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": keysJSON},
+	}
+	r := newFakeReconciler(t, newFinalizerTestUnsealer().WithVaultURL("http://vault"), pod, secret)
+
+	release, _, ok := r.podLocks.tryLock(types.NamespacedName{Namespace: "vault", Name: "vault-0"},
+		types.NamespacedName{Namespace: "vault", Name: "other"})
+	require.True(t, ok)
+
+	got := reconcileAndGet(t, r)
+	require.Len(t, got.Status.Pods, 1)
+	assert.Contains(t, got.Status.Pods[0].Message, "vault/other is unsealing the pod")
+	assert.Zero(t, fake.UnsealRequests(), "no keys are submitted while the pod is locked")
+	assert.True(t, fake.Sealed())
+
+	release()
+	reconcileAndGet(t, r)
+	assert.False(t, fake.Sealed(), "the next reconcile unseals once the lock is released")
+}
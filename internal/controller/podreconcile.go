@@ -0,0 +1,60 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/package controller
+
+import (
+	"maps"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// requestedPods returns the pods whose reconcile-requested-at annotation has
+// a value not acted on yet, mapped to that value. A reconcile that finds any
+// checks only these pods, as after replacing a single replica.
+func requestedPods(pods []corev1.Pod, previous []opsv1alpha1.PodStatus) map[string]string {
+	acted := make(map[string]string, len(previous))
+	for _, podStatus := range previous {
+		acted[podStatus.Name] = podStatus.ReconcileRequestedAt
+	}
+	var requested map[string]string
+	for _, pod := range pods {
+		value := pod.Annotations[opsv1alpha1.AnnotationReconcileRequestedAt]
+		if value == "" || value == acted[pod.Name] {
+			continue
+		}
+		if requested == nil {
+			requested = map[string]string{}
+		}
+		requested[pod.Name] = value
+	}
+	return requested
+}
+
+// requestedPodNames returns the names of requested in order.
+func requestedPodNames(requested map[string]string) []string {
+	return slices.Sorted(maps.Keys(requested))
+}
+
+// untouchedPodStatus returns the status a pod keeps while a targeted
+// reconcile checks other pods: the last one recorded, unchanged.
+func untouchedPodStatus(pod *corev1.Pod, previousPods map[string]opsv1alpha1.PodStatus) opsv1alpha1.PodStatus {
+	if podStatus, ok := previousPods[pod.Name]; ok {
+		return podStatus
+	}
+	return opsv1alpha1.PodStatus{Name: pod.Name, State: opsv1alpha1.PodStateUnknown, Message: "Not checked yet: a reconcile of other pods was requested"}
+}
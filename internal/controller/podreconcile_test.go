@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+func TestRequestedPods(t *testing.T) {
+	pod := func(name, requestedAt string) corev1.Pod {
+		p := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if requestedAt != "" {
+			p.Annotations = map[string]string{opsv1alpha1.AnnotationReconcileRequestedAt: requestedAt}
+		}
+		return p
+	}
+	previous := []opsv1alpha1.PodStatus{{Name: "vault-0", ReconcileRequestedAt: "t1"}}
+
+	assert.Nil(t, requestedPods([]corev1.Pod{pod("vault-0", "t1"), pod("vault-1", "")}, previous), "already acted on")
+	assert.Equal(t, map[string]string{"vault-0": "t2", "vault-1": "t1"},
+		requestedPods([]corev1.Pod{pod("vault-0", "t2"), pod("vault-1", "t1")}, previous))
+}
+
+func TestReconcile_RequestedPodOnly(t *testing.T) {
+	keys := []string{"key-1"}
+	fakes := []*vaultfake.Server{
+		vaultfake.NewServer(vaultfake.WithKeys(keys, 1), vaultfake.WithUnsealed()),
+		vaultfake.NewServer(vaultfake.WithKeys(keys, 1), vaultfake.WithUnsealed()),
+	}
+	var objs []client.Object
+	for i, fake := range fakes {
+		defer fake.Close()
+		objs = append(objs, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("vault-%d", i), Namespace: "vault", Labels: map[string]string{"app": "vault"}},
+			Status: corev1.PodStatus{
+				PodIP:      strings.TrimPrefix(fake.URL(), "http://"),
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		})
+	}
+	keysJSON, err := json.Marshal(keys)
+	require.NoError(t, err)
+	objs = append(objs,
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+			Data:       map[string][]byte{"keys.json": keysJSON},
+		},
+		newFinalizerTestUnsealer().WithVaultURL("http://vault").WithHA(true),
+	)
+	r := newFakeReconciler(t, objs...)
+	recorder := record.NewFakeRecorder(100)
+	r.Recorder = recorder
+
+	got := reconcileAndGet(t, r)
+	require.Len(t, got.Status.Pods, 2)
+
+	// vault-1 was replaced and comes up sealed; vault-0 sealed meanwhile.
+	fakes[0].Seal()
+	fakes[1].Seal()
+	ctx := context.Background()
+	pod := &corev1.Pod{}
+	require.NoError(t, r.Get(ctx, client.ObjectKey{Namespace: "vault", Name: "vault-1"}, pod))
+	pod.Annotations = map[string]string{opsv1alpha1.AnnotationReconcileRequestedAt: "2025-01-01T00:00:00Z"}
+	require.NoError(t, r.Update(ctx, pod))
+
+	got = reconcileAndGet(t, r)
+	assert.False(t, fakes[1].Sealed(), "the requested pod is unsealed")
+	assert.True(t, fakes[0].Sealed(), "the other pod is left untouched")
+	assert.Equal(t, []string{"vault-1"}, got.Status.PodsChecked)
+	require.Len(t, got.Status.Pods, 2)
+	assert.Equal(t, opsv1alpha1.PodStateUnsealed, got.Status.Pods[0].State, "last known state is kept")
+	assert.Equal(t, "2025-01-01T00:00:00Z", got.Status.Pods[1].ReconcileRequestedAt)
+
+	var requested []string
+	for len(recorder.Events) > 0 {
+		if event := <-recorder.Events; strings.Contains(event, ReasonPodReconcileRequested) {
+			requested = append(requested, event)
+		}
+	}
+	require.Len(t, requested, 1)
+	assert.Contains(t, requested[0], "Reconciling pods vault-1 on request")
+
+	// The request has been acted on, so the next reconcile checks every pod.
+	got = reconcileAndGet(t, r)
+	assert.False(t, fakes[0].Sealed())
+	assert.ElementsMatch(t, []string{"vault-0", "vault-1"}, got.Status.PodsChecked)
+	assert.Equal(t, "2025-01-01T00:00:00Z", got.Status.Pods[1].ReconcileRequestedAt)
+}
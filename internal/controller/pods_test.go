@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+func TestIsPodUnsealable(t *testing.T) {
+	running := &corev1.Pod{Status: corev1.PodStatus{
+		Phase:      corev1.PodRunning,
+		PodIP:      "10.0.0.1",
+		Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}},
+	}}
+	ready := running.DeepCopy()
+	ready.Status.Conditions[0].Status = corev1.ConditionTrue
+	pending := &corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodPending}}
+
+	r := &VaultUnsealerReconciler{}
+	vu := opsv1alpha1.NewVaultUnsealer("vault", "main")
+	assert.True(t, r.isPodUnsealable(ready, vu))
+	assert.False(t, r.isPodUnsealable(running, vu), "requirePodReady defaults to true")
+	assert.False(t, r.isPodUnsealable(running, vu.DeepCopy().WithRequirePodReady(true)))
+
+	notRequired := vu.DeepCopy().WithRequirePodReady(false)
+	assert.True(t, r.isPodUnsealable(running, notRequired))
+	assert.False(t, r.isPodUnsealable(pending, notRequired), "pod must still be running")
+}
+
+func TestIsPodUnsealable_ContainerName(t *testing.T) {
+	pod := &corev1.Pod{Status: corev1.PodStatus{
+		Phase:      corev1.PodRunning,
+		PodIP:      "10.0.0.1",
+		Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionFalse}},
+		ContainerStatuses: []corev1.ContainerStatus{
+			{Name: "vault", Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+			{Name: "envoy", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{}}},
+		},
+	}}
+
+	r := &VaultUnsealerReconciler{}
+	vu := opsv1alpha1.NewVaultUnsealer("vault", "main")
+	assert.False(t, r.isPodUnsealable(pod, vu), "the unready sidecar keeps the pod unready")
+	assert.True(t, r.isPodUnsealable(pod, vu.DeepCopy().WithContainerName("vault")))
+	assert.False(t, r.isPodUnsealable(pod, vu.DeepCopy().WithContainerName("envoy")))
+	assert.False(t, r.isPodUnsealable(pod, vu.DeepCopy().WithContainerName("missing")))
+
+	pod.Status.ContainerStatuses[0].Ready = false
+	assert.False(t, r.isPodUnsealable(pod, vu.DeepCopy().WithContainerName("vault")))
+	assert.True(t, r.isPodUnsealable(pod, vu.DeepCopy().WithContainerName("vault").WithRequirePodReady(false)),
+		"a running but unready Vault container is enough without requirePodReady")
+}
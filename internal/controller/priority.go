@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// Workqueue priorities for spec.priority. The controller's workers take the
+// highest priority request that is due, so a backlog of Low VaultUnsealers
+// never delays a High one.
+const (
+	queuePriorityHigh   = 1
+	queuePriorityNormal = 0
+	queuePriorityLow    = -1
+)
+
+// queuePriority maps spec.priority to a workqueue priority.
+func queuePriority(vaultUnsealer *opsv1alpha1.VaultUnsealer) int {
+	switch vaultUnsealer.Spec.Priority {
+	case opsv1alpha1.PriorityHigh:
+		return queuePriorityHigh
+	case opsv1alpha1.PriorityLow:
+		return queuePriorityLow
+	default:
+		return queuePriorityNormal
+	}
+}
+
+// prioritizedQueue is a priority workqueue that files every request under
+// the priority of the VaultUnsealer it names, whichever watch or requeue
+// added it.
+type prioritizedQueue struct {
+	priorityqueue.PriorityQueue[reconcile.Request]
+	reader client.Reader
+}
+
+// newPrioritizedQueue returns a controller NewQueue func building a
+// prioritizedQueue that reads VaultUnsealers through reader.
+func newPrioritizedQueue(reader client.Reader) func(string, workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+	return func(name string, rateLimiter workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+		return &prioritizedQueue{
+			PriorityQueue: priorityqueue.New(name, func(o *priorityqueue.Opts[reconcile.Request]) {
+				o.RateLimiter = rateLimiter
+			}),
+			reader: reader,
+		}
+	}
+}
+
+// priority looks up the priority of req. A VaultUnsealer that cannot be
+// read, such as one just deleted, gets Normal.
+func (q *prioritizedQueue) priority(req reconcile.Request) int {
+	vaultUnsealer := &opsv1alpha1.VaultUnsealer{}
+	if err := q.reader.Get(context.Background(), req.NamespacedName, vaultUnsealer); err != nil {
+		return queuePriorityNormal
+	}
+	return queuePriority(vaultUnsealer)
+}
+
+// AddWithOpts replaces the priority chosen by the caller, such as the low
+// priority event handlers give to resyncs, with the VaultUnsealer's own.
+func (q *prioritizedQueue) AddWithOpts(opts priorityqueue.AddOpts, reqs ...reconcile.Request) {
+	for _, req := range reqs {
+		opts.Priority = q.priority(req)
+		q.PriorityQueue.AddWithOpts(opts, req)
+	}
+}
+
+func (q *prioritizedQueue) Add(req reconcile.Request) {
+	q.AddWithOpts(priorityqueue.AddOpts{}, req)
+}
+
+func (q *prioritizedQueue) AddAfter(req reconcile.Request, duration time.Duration) {
+	q.AddWithOpts(priorityqueue.AddOpts{After: duration}, req)
+}
+
+func (q *prioritizedQueue) AddRateLimited(req reconcile.Request) {
+	q.AddWithOpts(priorityqueue.AddOpts{RateLimited: true}, req)
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+func TestPrioritizedQueue_OrdersBySpecPriority(t *testing.T) {
+	r := newFakeReconciler(t,
+		opsv1alpha1.NewVaultUnsealer("dev", "vault").WithPriority(opsv1alpha1.PriorityLow),
+		opsv1alpha1.NewVaultUnsealer("staging", "vault"),
+		opsv1alpha1.NewVaultUnsealer("prod", "vault").WithPriority(opsv1alpha1.PriorityHigh),
+	)
+	queue := newPrioritizedQueue(r.Client)("test", nil).(*prioritizedQueue)
+	defer queue.ShutDown()
+
+	request := func(namespace string) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: "vault"}}
+	}
+	queue.Add(request("dev"))
+	queue.Add(request("staging"))
+	// The priority handlers give unchanged objects does not demote a High
+	// VaultUnsealer.
+	queue.AddWithOpts(priorityqueue.AddOpts{Priority: handler.LowPriority}, request("prod"))
+	// Unknown VaultUnsealers are Normal.
+	queue.Add(request("gone"))
+
+	var order []string
+	for range 4 {
+		req, priority, _ := queue.GetWithPriority()
+		order = append(order, req.Namespace)
+		queue.Done(req)
+		if req.Namespace == "prod" {
+			assert.Equal(t, queuePriorityHigh, priority)
+		}
+	}
+	assert.Equal(t, "prod", order[0])
+	assert.ElementsMatch(t, []string{"staging", "gone"}, order[1:3])
+	assert.Equal(t, "dev", order[3])
+}
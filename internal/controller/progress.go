@@ -0,0 +1,61 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// unsealProgress publishes Progressing=True while the unseal phase works
+// through several pods, so watchers of a large cluster are not left without
+// feedback until the reconcile's single status write. Only the conditions
+// persisted before the reconcile are patched, plus Progressing; the final
+// status write leaves Progressing out again.
+type unsealProgress struct {
+	vaultUnsealer *opsv1alpha1.VaultUnsealer
+	// persisted is the status last written to the API server. It is updated
+	// after each report so the final write is not skipped as a no-op.
+	persisted *opsv1alpha1.VaultUnsealerStatus
+	total     int
+}
+
+// report patches "unsealed n/total pods" onto the VaultUnsealer. Failures
+// are logged and otherwise ignored, as the final status write still happens.
+func (p *unsealProgress) report(ctx context.Context, r *VaultUnsealerReconciler, unsealed int) {
+	if p == nil || p.total < 2 {
+		return
+	}
+	log := logf.FromContext(ctx)
+
+	patched := p.vaultUnsealer.DeepCopy()
+	p.persisted.DeepCopyInto(&patched.Status)
+	base := patched.DeepCopy()
+	r.setCondition(patched, ConditionTypeProgressing, ConditionStatusTrue, ReasonUnsealingPods,
+		fmt.Sprintf("unsealed %d/%d pods", unsealed, p.total))
+	if err := r.Status().Patch(ctx, patched, client.MergeFrom(base)); err != nil {
+		log.Error(err, "Failed to report unseal progress")
+		return
+	}
+	p.persisted.Conditions = patched.Status.Conditions
+	p.vaultUnsealer.ResourceVersion = patched.ResourceVersion
+}
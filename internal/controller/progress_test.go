@@ -0,0 +1,79 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/package controller
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+func TestReconcile_ReportsUnsealProgress(t *testing.T) {
+	keys := []string{"key-1", "key-2"}
+	fake := vaultfake.NewServer(vaultfake.WithKeys(keys, 2))
+	defer fake.Close()
+
+	keysJSON, err := json.Marshal(keys)
+	require.NoError(t, err)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": keysJSON},
+	}
+	vu := newFinalizerTestUnsealer().WithVaultURL("http://vault").WithReplicaScope(opsv1alpha1.ReplicaScopeCluster)
+	r := newFakeReconciler(t, vu, secret,
+		newRolloutPod(fake, "vault-0", "v1"), newRolloutPod(fake, "vault-1", "v1"), newRolloutPod(fake, "vault-2", "v1"))
+
+	var reported []string
+	r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+		SubResourcePatch: func(ctx context.Context, c client.Client, subResource string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+			if condition := findCondition(obj.(*opsv1alpha1.VaultUnsealer), ConditionTypeProgressing); condition != nil {
+				reported = append(reported, condition.Message)
+			}
+			return c.SubResource(subResource).Patch(ctx, obj, patch, opts...)
+		},
+	})
+
+	// All pods share one fake Vault, so only vault-0 receives keys.
+	got := reconcileAndGet(t, r)
+	assert.Equal(t, []string{"unsealed 1/3 pods"}, reported)
+	assert.Nil(t, findCondition(got, ConditionTypeProgressing), "cleared once the unseal phase is done")
+	assert.Len(t, got.Status.UnsealedPods, 3)
+
+	// Nothing is reported when no pod needs keys.
+	reported = nil
+	reconcileAndGet(t, r)
+	assert.Empty(t, reported)
+}
+
+func TestUnsealProgress_SinglePodNotReported(t *testing.T) {
+	vu := newFinalizerTestUnsealer()
+	r := newFakeReconciler(t, vu)
+	progress := &unsealProgress{vaultUnsealer: vu, persisted: vu.Status.DeepCopy(), total: 1}
+	progress.report(context.Background(), r, 0)
+
+	got := &opsv1alpha1.VaultUnsealer{}
+	require.NoError(t, r.Get(context.Background(), client.ObjectKeyFromObject(vu), got))
+	assert.Nil(t, findCondition(got, ConditionTypeProgressing))
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/package controller
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// rateLimitedPods collects the pods a Vault rate limit quota throttled in one
+// reconcile. Throttling says nothing about the pod's health or the keys, so
+// these pods are kept apart from podFailures.
+type rateLimitedPods struct {
+	pods []string
+	// wait is the longest backoff scheduled for any of them.
+	wait time.Duration
+}
+
+// add records that pod was throttled and will be left alone for wait.
+func (t *rateLimitedPods) add(pod string, wait time.Duration) {
+	t.pods = append(t.pods, pod)
+	t.wait = max(t.wait, wait)
+}
+
+// condition returns the message of the VaultRateLimited condition, or false
+// when no pod was throttled.
+func (t *rateLimitedPods) condition() (string, bool) {
+	if len(t.pods) == 0 {
+		return "", false
+	}
+	return fmt.Sprintf("Vault rate limit quotas throttled pods %s; retrying within %s", strings.Join(t.pods, ", "), t.wait), true
+}
+
+// rateLimitBackoff returns how long a throttled pod is left alone: the wait
+// Vault asked for, or podBackoffBase when it named none, capped at
+// podBackoffMax so a long quota interval cannot stall unsealing for hours.
+func rateLimitBackoff(retryAfter time.Duration) time.Duration {
+	if retryAfter <= 0 {
+		return podBackoffBase
+	}
+	return min(retryAfter, podBackoffMax)
+}
+
+// recordRateLimited schedules the next attempt on a throttled pod without
+// counting a failure, so the exponential backoff of real failures is neither
+// started nor advanced.
+func recordRateLimited(podStatus *opsv1alpha1.PodStatus, previous opsv1alpha1.PodStatus, wait time.Duration, now time.Time) {
+	podStatus.ConsecutiveFailures = previous.ConsecutiveFailures
+	podStatus.NextAttemptTime = &metav1.Time{Time: now.Add(wait)}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/package controller
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/panteparak/vault-unsealer/internal/metrics"
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+func TestRateLimitBackoff(t *testing.T) {
+	assert.Equal(t, podBackoffBase, rateLimitBackoff(0), "no hint from Vault")
+	assert.Equal(t, 30*time.Second, rateLimitBackoff(30*time.Second))
+	assert.Equal(t, podBackoffMax, rateLimitBackoff(time.Hour))
+}
+
+func TestReconcile_VaultRateLimited(t *testing.T) {
+	keys := []string{"key-1", "key-2"}
+	fake := vaultfake.NewServer(vaultfake.WithKeys(keys, 2))
+	defer fake.Close()
+
+	pod, _ := newFakeVaultPod(fake)
+	pod.Labels = map[string]string{"app": "vault"}
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	keysJSON, err := json.Marshal(keys)
+	require.NoError(t, err)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": keysJSON},
+	}
+	r := newFakeReconciler(t, newFinalizerTestUnsealer().WithVaultURL("http://vault"), pod, secret)
+
+	throttledBefore := testutil.ToFloat64(metrics.VaultRateLimited.WithLabelValues("main", "vault", "vault-0"))
+	failedBefore := testutil.ToFloat64(metrics.UnsealAttempts.WithLabelValues("main", "vault", "vault-0", "failed"))
+
+	fake.RateLimitNext(1, 30*time.Second)
+	started := time.Now()
+	got := reconcileAndGet(t, r)
+
+	require.Len(t, got.Status.Pods, 1)
+	podStatus := got.Status.Pods[0]
+	assert.Contains(t, podStatus.Message, "rate limit quota")
+	assert.Zero(t, podStatus.ConsecutiveFailures, "throttling is not a failure")
+	require.NotNil(t, podStatus.NextAttemptTime)
+	assert.WithinDuration(t, started.Add(30*time.Second), podStatus.NextAttemptTime.Time, 5*time.Second)
+	assert.Empty(t, podStatus.UnsealHistory)
+	assert.Zero(t, got.Status.ConsecutiveFailures)
+	assertCondition(t, got, ConditionTypeVaultRateLimited, ConditionStatusTrue, ReasonRateLimitQuota)
+	assert.Nil(t, findCondition(got, ConditionTypeVaultAPIFailure))
+	assert.Equal(t, throttledBefore+1, testutil.ToFloat64(metrics.VaultRateLimited.WithLabelValues("main", "vault", "vault-0")))
+	assert.Equal(t, failedBefore, testutil.ToFloat64(metrics.UnsealAttempts.WithLabelValues("main", "vault", "vault-0", "failed")))
+
+	// The pod is left alone until the wait Vault asked for has passed.
+	got = reconcileAndGet(t, r)
+	assert.True(t, fake.Sealed())
+	assert.Equal(t, 0, fake.UnsealRequests())
+
+	got.Status.Pods[0].NextAttemptTime = &metav1.Time{Time: time.Now().Add(-time.Second)}
+	require.NoError(t, r.Status().Update(context.Background(), got))
+	got = reconcileAndGet(t, r)
+	assert.False(t, fake.Sealed())
+	assert.Nil(t, findCondition(got, ConditionTypeVaultRateLimited))
+}
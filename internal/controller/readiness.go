@@ -0,0 +1,50 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// readinessPolicy returns the effective readiness policy. With a single
+// replica, or when passes stop after the first unseal, only one pod is ever
+// unsealed, so any stricter policy would never be satisfied.
+func readinessPolicy(vaultUnsealer *opsv1alpha1.VaultUnsealer) string {
+	mode := vaultUnsealer.Spec.Mode
+	if mode.ReplicaScope() == opsv1alpha1.ReplicaScopeSingle || mode.StopsAfterFirstUnseal() || vaultUnsealer.Spec.ReadinessPolicy == "" {
+		return opsv1alpha1.ReadinessPolicyAnyPod
+	}
+	return vaultUnsealer.Spec.ReadinessPolicy
+}
+
+// readinessSatisfied reports whether unsealed out of total pods meets policy.
+// activeUnsealed reports whether an unsealed pod has the Active role.
+func readinessSatisfied(policy string, unsealed, total int, activeUnsealed bool) bool {
+	if unsealed == 0 {
+		return false
+	}
+	switch policy {
+	case opsv1alpha1.ReadinessPolicyActivePod:
+		return activeUnsealed
+	case opsv1alpha1.ReadinessPolicyAllPods:
+		return unsealed >= total
+	case opsv1alpha1.ReadinessPolicyQuorum:
+		return unsealed > total/2
+	default:
+		return true
+	}
+}
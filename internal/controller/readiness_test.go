@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+func TestReadinessSatisfied(t *testing.T) {
+	tests := []struct {
+		policy          string
+		unsealed, total int
+		want            bool
+	}{
+		{opsv1alpha1.ReadinessPolicyAnyPod, 1, 5, true},
+		{opsv1alpha1.ReadinessPolicyAnyPod, 0, 5, false},
+		{opsv1alpha1.ReadinessPolicyQuorum, 2, 5, false},
+		{opsv1alpha1.ReadinessPolicyQuorum, 3, 5, true},
+		{opsv1alpha1.ReadinessPolicyQuorum, 1, 2, false},
+		{opsv1alpha1.ReadinessPolicyAllPods, 4, 5, false},
+		{opsv1alpha1.ReadinessPolicyAllPods, 5, 5, true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, readinessSatisfied(tt.policy, tt.unsealed, tt.total, false),
+			"%s %d/%d", tt.policy, tt.unsealed, tt.total)
+	}
+}
+
+func TestReadinessSatisfied_ActivePod(t *testing.T) {
+	policy := opsv1alpha1.ReadinessPolicyActivePod
+	assert.False(t, readinessSatisfied(policy, 3, 3, false), "every pod unsealed but none active")
+	assert.True(t, readinessSatisfied(policy, 1, 3, true))
+	assert.False(t, readinessSatisfied(policy, 0, 3, true))
+}
+
+func TestReadinessPolicy_IgnoredWithoutHA(t *testing.T) {
+	vu := opsv1alpha1.NewVaultUnsealer("ns", "vu").WithHA(false)
+	vu.Spec.ReadinessPolicy = opsv1alpha1.ReadinessPolicyAllPods
+	assert.Equal(t, opsv1alpha1.ReadinessPolicyAnyPod, readinessPolicy(vu))
+
+	vu.WithHA(true)
+	assert.Equal(t, opsv1alpha1.ReadinessPolicyAllPods, readinessPolicy(vu))
+
+	vu.Spec.ReadinessPolicy = ""
+	assert.Equal(t, opsv1alpha1.ReadinessPolicyAnyPod, readinessPolicy(vu))
+
+	vu.Spec.ReadinessPolicy = opsv1alpha1.ReadinessPolicyAllPods
+	vu.WithReplicaScope(opsv1alpha1.ReplicaScopeCluster).WithStopAfterFirstUnseal(true)
+	assert.Equal(t, opsv1alpha1.ReadinessPolicyAnyPod, readinessPolicy(vu), "only one pod is unsealed per pass")
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// Reasons of the readiness gate pod condition.
+const (
+	ReasonReadinessGateUnsealed = "Unsealed"
+	ReasonReadinessGateSealed   = "Sealed"
+)
+
+// podConditionTrue reports whether pod has condType set to True.
+func podConditionTrue(pod *corev1.Pod, condType corev1.PodConditionType) bool {
+	condition := podCondition(pod, condType)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
+// readinessGateCondition returns the readiness gate condition for a pod in
+// state, or false for states that say nothing about the seal.
+func readinessGateCondition(conditionType, state string, now time.Time) (corev1.PodCondition, bool) {
+	condition := corev1.PodCondition{
+		Type:               corev1.PodConditionType(conditionType),
+		LastTransitionTime: metav1.NewTime(now),
+	}
+	switch state {
+	case opsv1alpha1.PodStateUnsealed:
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = ReasonReadinessGateUnsealed
+		condition.Message = "Vault unsealer verified the pod is unsealed"
+	case opsv1alpha1.PodStateSealed:
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = ReasonReadinessGateSealed
+		condition.Message = "Vault unsealer found the pod sealed"
+	default:
+		return corev1.PodCondition{}, false
+	}
+	return condition, true
+}
+
+// reconcileReadinessGates sets the spec.readinessGate condition on each pod
+// checked in this reconcile whose seal status is known, patching only pods
+// whose condition changes. A failed patch is logged and reported in
+// status.warnings without failing the reconcile.
+func (r *VaultUnsealerReconciler) reconcileReadinessGates(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer, pods []corev1.Pod) {
+	gate := vaultUnsealer.Spec.ReadinessGate
+	if gate == nil {
+		return
+	}
+	log := logf.FromContext(ctx)
+	conditionType := gate.Type()
+
+	states := make(map[string]string, len(vaultUnsealer.Status.Pods))
+	for _, podStatus := range vaultUnsealer.Status.Pods {
+		states[podStatus.Name] = podStatus.State
+	}
+	now := time.Now()
+	for i := range pods {
+		pod := &pods[i]
+		if !slices.Contains(vaultUnsealer.Status.PodsChecked, pod.Name) {
+			continue
+		}
+		condition, ok := readinessGateCondition(conditionType, states[pod.Name], now)
+		if !ok {
+			continue
+		}
+		if current := podCondition(pod, condition.Type); current != nil && current.Status == condition.Status {
+			continue
+		}
+		if err := r.patchPodCondition(ctx, pod, condition); err != nil {
+			log.Error(err, "Failed to set readiness gate condition", "pod", pod.Name, "condition", conditionType)
+			addWarning(vaultUnsealer, opsv1alpha1.WarningSourceRuntime,
+				fmt.Sprintf("Readiness gate condition %s could not be set on pod %s: %v", conditionType, pod.Name, err))
+			continue
+		}
+		log.Info("Set readiness gate condition", "pod", pod.Name, "condition", conditionType, "status", condition.Status)
+	}
+}
+
+// podCondition returns the pod's condition of condType, or nil.
+func podCondition(pod *corev1.Pod, condType corev1.PodConditionType) *corev1.PodCondition {
+	for i := range pod.Status.Conditions {
+		if pod.Status.Conditions[i].Type == condType {
+			return &pod.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// patchPodCondition sets condition on the pod's status with a strategic
+// merge patch, which merges conditions by type so the kubelet's own
+// conditions are left alone.
+func (r *VaultUnsealerReconciler) patchPodCondition(ctx context.Context, pod *corev1.Pod, condition corev1.PodCondition) error {
+	patch, err := json.Marshal(map[string]any{
+		"status": map[string]any{"conditions": []corev1.PodCondition{condition}},
+	})
+	if err != nil {
+		return err
+	}
+	return r.Status().Patch(ctx, pod, client.RawPatch(types.StrategicMergePatchType, patch))
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+func TestReconcile_SetsReadinessGateCondition(t *testing.T) {
+	fake := vaultfake.NewServer(vaultfake.WithKeys([]string{"key-1"}, 1))
+	defer fake.Close()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": []byte("key-1\n")},
+	}
+	// The gate holds Ready back, so only the containers are ready.
+	pod := newRolloutPod(fake, "vault-0", "v1")
+	pod.Spec.ReadinessGates = []corev1.PodReadinessGate{{ConditionType: opsv1alpha1.DefaultReadinessGateConditionType}}
+	pod.Status.Conditions = []corev1.PodCondition{
+		{Type: corev1.PodReady, Status: corev1.ConditionFalse},
+		{Type: corev1.ContainersReady, Status: corev1.ConditionTrue},
+	}
+	vu := newFinalizerTestUnsealer().WithVaultURL("http://vault")
+	vu.Spec.ReadinessGate = &opsv1alpha1.ReadinessGateSpec{}
+	r := newFakeReconciler(t, vu, pod, secret)
+
+	getPod := func() *corev1.Pod {
+		got := &corev1.Pod{}
+		require.NoError(t, r.Get(t.Context(), types.NamespacedName{Namespace: "vault", Name: "vault-0"}, got))
+		return got
+	}
+
+	reconcileAndGet(t, r)
+	assert.False(t, fake.Sealed())
+	got := getPod()
+	condition := podCondition(got, opsv1alpha1.DefaultReadinessGateConditionType)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, ReasonReadinessGateUnsealed, condition.Reason)
+	assert.NotNil(t, podCondition(got, corev1.ContainersReady), "other conditions are kept")
+
+	// An unchanged condition is not patched again.
+	reconcileAndGet(t, r)
+	assert.Equal(t, got.ResourceVersion, getPod().ResourceVersion)
+
+	// A sealed pod that is not unsealed again has the condition cleared.
+	fake.Seal()
+	current := &opsv1alpha1.VaultUnsealer{}
+	require.NoError(t, r.Get(t.Context(), types.NamespacedName{Namespace: "vault", Name: "main"}, current))
+	current.Spec.Mode.MonitorOnly = true
+	require.NoError(t, r.Update(t.Context(), current))
+	reconcileAndGet(t, r)
+	condition = podCondition(getPod(), opsv1alpha1.DefaultReadinessGateConditionType)
+	require.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonReadinessGateSealed, condition.Reason)
+}
+
+func TestReconcile_NoReadinessGateLeavesPodsAlone(t *testing.T) {
+	fake := vaultfake.NewServer(vaultfake.WithKeys([]string{"key-1"}, 1))
+	defer fake.Close()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": []byte("key-1\n")},
+	}
+	r := newFakeReconciler(t, newFinalizerTestUnsealer().WithVaultURL("http://vault"), newRolloutPod(fake, "vault-0", "v1"), secret)
+
+	reconcileAndGet(t, r)
+	got := &corev1.Pod{}
+	require.NoError(t, r.Get(t.Context(), types.NamespacedName{Namespace: "vault", Name: "vault-0"}, got))
+	assert.Nil(t, podCondition(got, opsv1alpha1.DefaultReadinessGateConditionType))
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// deferredRequeueDelay requeues a reconcile that ran out of spec.reconcileBudget
+// straight away. It goes to the back of the workqueue, so VaultUnsealers that
+// were already waiting are reconciled first.
+const deferredRequeueDelay = time.Millisecond
+
+// reconcileBudgetDeadline returns when a reconcile started at start has used
+// up spec.reconcileBudget, or the zero time when no budget is set.
+func reconcileBudgetDeadline(vaultUnsealer *opsv1alpha1.VaultUnsealer, start time.Time) time.Time {
+	budget := vaultUnsealer.Spec.ReconcileBudget
+	if budget == nil || budget.Duration <= 0 {
+		return time.Time{}
+	}
+	return start.Add(budget.Duration)
+}
+
+// deferredFirst moves the pods the previous reconcile deferred to the front,
+// keeping the order of each group, so a pass split across reconciles
+// continues where it stopped.
+func deferredFirst(pods []corev1.Pod, deferred []string) {
+	if len(deferred) == 0 {
+		return
+	}
+	sort.SliceStable(pods, func(i, j int) bool {
+		return slices.Contains(deferred, pods[i].Name) && !slices.Contains(deferred, pods[j].Name)
+	})
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+func TestReconcile_ReconcileBudgetDefersRemainingPods(t *testing.T) {
+	fake := vaultfake.NewServer(vaultfake.WithKeys([]string{"key-1"}, 1))
+	defer fake.Close()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": []byte("key-1\n")},
+	}
+	vu := newFinalizerTestUnsealer().WithVaultURL("http://vault").WithReplicaScope(opsv1alpha1.ReplicaScopeCluster)
+	// Any budget is used up by the time the first pod is done.
+	vu.Spec.ReconcileBudget = &metav1.Duration{Duration: time.Nanosecond}
+	r := newFakeReconciler(t, vu, secret, newRolloutPod(fake, "vault-0", "v1"), newRolloutPod(fake, "vault-1", "v1"))
+	key := types.NamespacedName{Namespace: "vault", Name: "main"}
+
+	result, err := r.Reconcile(t.Context(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	assert.Equal(t, deferredRequeueDelay, result.RequeueAfter)
+	got := &opsv1alpha1.VaultUnsealer{}
+	require.NoError(t, r.Get(t.Context(), key, got))
+	assert.Equal(t, []string{"vault-1"}, got.Status.DeferredPods)
+	assert.Equal(t, []string{"vault-0"}, got.Status.PodsChecked)
+	require.Len(t, got.Status.Pods, 2)
+	assert.Equal(t, opsv1alpha1.PodStateUnknown, got.Status.Pods[1].State)
+	assertCondition(t, got, ConditionTypeReconciling, ConditionStatusTrue, ReasonReconcileBudget)
+
+	// The next reconcile continues with the deferred pod.
+	_, err = r.Reconcile(t.Context(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	require.NoError(t, r.Get(t.Context(), key, got))
+	assert.Equal(t, []string{"vault-1"}, got.Status.PodsChecked)
+	assert.Equal(t, []string{"vault-0"}, got.Status.DeferredPods)
+}
+
+func TestDeferredFirst(t *testing.T) {
+	pods := []corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "vault-0"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "vault-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "vault-2"}},
+	}
+	deferredFirst(pods, []string{"vault-2", "vault-1"})
+	assert.Equal(t, []string{"vault-1", "vault-2", "vault-0"}, podNames(pods))
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+)
+
+type reconcileIDKey struct{}
+
+// generateReconcileID creates a unique identifier for tracking reconciliation operations
+func generateReconcileID() (string, error) {
+	bytes := make([]byte, 4)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// withReconcileID stores the reconcile ID in ctx, so the Vault clients and
+// Events created while handling the reconcile can carry it.
+func withReconcileID(ctx context.Context, reconcileID string) context.Context {
+	return context.WithValue(ctx, reconcileIDKey{}, reconcileID)
+}
+
+// reconcileIDFrom returns the reconcile ID stored in ctx, or "" outside a
+// reconcile.
+func reconcileIDFrom(ctx context.Context) string {
+	reconcileID, _ := ctx.Value(reconcileIDKey{}).(string)
+	return reconcileID
+}
+
+// withReconcileIDSuffix appends the reconcile ID in ctx to an Event message.
+func withReconcileIDSuffix(ctx context.Context, message string) string {
+	if reconcileID := reconcileIDFrom(ctx); reconcileID != "" {
+		return fmt.Sprintf("%s (reconcileID %s)", message, reconcileID)
+	}
+	return message
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/vault"
+)
+
+func TestCreateVaultClient_SendsReconcileID(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get(vault.RequestIDHeader))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"sealed":false,"t":1,"n":1,"progress":0}`))
+	}))
+	defer server.Close()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-0", Namespace: "vault"},
+		Status:     corev1.PodStatus{PodIP: strings.TrimPrefix(server.URL, "http://")},
+	}
+	vu := opsv1alpha1.NewVaultUnsealer("vault", "main").WithVaultURL("http://vault")
+	r := &VaultUnsealerReconciler{}
+
+	vaultClient, err := r.createVaultClient(withReconcileID(context.Background(), "abc123"), pod, vu)
+	require.NoError(t, err)
+	_, err = vaultClient.GetSealStatus(context.Background())
+	require.NoError(t, err)
+
+	vaultClient, err = r.createVaultClient(context.Background(), pod, vu)
+	require.NoError(t, err)
+	_, err = vaultClient.GetSealStatus(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"abc123", ""}, seen, "only clients created during a reconcile carry an ID")
+}
+
+func TestEvent_IncludesReconcileID(t *testing.T) {
+	recorder := record.NewFakeRecorder(2)
+	r := &VaultUnsealerReconciler{Recorder: recorder}
+	vu := opsv1alpha1.NewVaultUnsealer("vault", "main")
+
+	r.event(withReconcileID(context.Background(), "abc123"), vu, corev1.EventTypeWarning, ReasonVaultSealed, "Vault pod vault-0 is sealed")
+	r.event(context.Background(), vu, corev1.EventTypeWarning, ReasonVaultSealed, "Vault pod vault-0 is sealed")
+
+	assert.Equal(t, "Warning VaultSealed Vault pod vault-0 is sealed (reconcileID abc123)", <-recorder.Events)
+	assert.Equal(t, "Warning VaultSealed Vault pod vault-0 is sealed", <-recorder.Events)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+// TestReconcile_KeyMaterialNeverLeaks unseals a pod with a mix of valid and
+// stale shares and checks that none of them appears in status, events or logs.
+func TestReconcile_KeyMaterialNeverLeaks(t *testing.T) {
+	shares := []string{"stale-share-value", "valid-share-one", "valid-share-two"}
+	fake := vaultfake.NewServer(vaultfake.WithKeys(shares[1:], 2))
+	defer fake.Close()
+
+	pod, _ := newFakeVaultPod(fake)
+	pod.Labels = map[string]string{"app": "vault"}
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	keysJSON, err := json.Marshal(shares)
+	require.NoError(t, err)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": keysJSON},
+	}
+	vu := newFinalizerTestUnsealer().WithVaultURL("http://vault")
+
+	r := newFakeReconciler(t, vu, pod, secret)
+	recorder := record.NewFakeRecorder(100)
+	r.Recorder = recorder
+
+	var logs strings.Builder
+	logger := funcr.New(func(prefix, args string) { logs.WriteString(prefix + args + "\n") }, funcr.Options{Verbosity: 10})
+	ctx := logf.IntoContext(context.Background(), logger)
+
+	key := types.NamespacedName{Namespace: "vault", Name: "main"}
+	for range 2 {
+		_, _ = r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	}
+	close(recorder.Events)
+
+	got := &opsv1alpha1.VaultUnsealer{}
+	require.NoError(t, r.Get(ctx, key, got))
+	status, err := json.Marshal(got.Status)
+	require.NoError(t, err)
+	var events strings.Builder
+	for event := range recorder.Events {
+		events.WriteString(event + "\n")
+	}
+
+	require.NotEmpty(t, got.Status.Pods, "the pod was processed")
+	for _, share := range shares {
+		assert.NotContains(t, string(status), share, "status")
+		assert.NotContains(t, events.String(), share, "events")
+		assert.NotContains(t, logs.String(), share, "logs")
+	}
+}
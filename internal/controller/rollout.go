@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// rollout is a rolling update of the StatefulSet that owns the Vault pods.
+type rollout struct {
+	statefulSet    string
+	updateRevision string
+	replicas       int32
+}
+
+// podRevision returns the StatefulSet revision a pod was created from.
+func podRevision(pod *corev1.Pod) string {
+	return pod.Labels[appsv1.ControllerRevisionHashLabelKey]
+}
+
+// ownerStatefulSet returns the name of the StatefulSet controlling every pod,
+// or "" when they are not all controlled by the same one.
+func ownerStatefulSet(pods []corev1.Pod) string {
+	name := ""
+	for i := range pods {
+		owner := metav1.GetControllerOf(&pods[i])
+		if owner == nil || owner.Kind != "StatefulSet" || owner.APIVersion != appsv1.SchemeGroupVersion.String() {
+			return ""
+		}
+		if name != "" && owner.Name != name {
+			return ""
+		}
+		name = owner.Name
+	}
+	return name
+}
+
+// observeRollout returns the rolling update in progress for the StatefulSet
+// owning pods, or nil when there is none or it cannot be determined.
+func (r *VaultUnsealerReconciler) observeRollout(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer, pods []corev1.Pod) *rollout {
+	name := ownerStatefulSet(pods)
+	if name == "" {
+		return nil
+	}
+	statefulSet := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: vaultUnsealer.Namespace, Name: name}, statefulSet); err != nil {
+		logf.FromContext(ctx).V(1).Info("Failed to get the Vault StatefulSet, not tracking rollouts", "statefulSet", name, "error", err.Error())
+		return nil
+	}
+
+	updateRevision := statefulSet.Status.UpdateRevision
+	if updateRevision == "" {
+		return nil
+	}
+	// With the OnDelete strategy the current revision only catches up once
+	// every pod was replaced, so old pods are checked as well.
+	pending := statefulSet.Status.CurrentRevision != updateRevision
+	for i := range pods {
+		if podRevision(&pods[i]) != updateRevision {
+			pending = true
+		}
+	}
+	if !pending {
+		return nil
+	}
+
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+	return &rollout{statefulSet: name, updateRevision: updateRevision, replicas: replicas}
+}
+
+// prioritize moves pods of the new revision to the front, so they are
+// unsealed first and the rollout is not held up by the remaining old pods.
+func (ro *rollout) prioritize(pods []corev1.Pod) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		return podRevision(&pods[i]) == ro.updateRevision && podRevision(&pods[j]) != ro.updateRevision
+	})
+}
+
+// progress summarises how many pods of the new revision are unsealed, or
+// returns "" when no rollout is in progress.
+func (ro *rollout) progress(podStatuses []opsv1alpha1.PodStatus) string {
+	if ro == nil {
+		return ""
+	}
+	unsealed := 0
+	for _, podStatus := range podStatuses {
+		if podStatus.Revision == ro.updateRevision && podStatus.State == opsv1alpha1.PodStateUnsealed {
+			unsealed++
+		}
+	}
+	return fmt.Sprintf("%d/%d new revision unsealed", unsealed, ro.replicas)
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+func newRolloutPod(fake *vaultfake.Server, name, revision string) *corev1.Pod {
+	pod, _ := newFakeVaultPod(fake)
+	pod.Name = name
+	pod.Labels = map[string]string{"app": "vault", appsv1.ControllerRevisionHashLabelKey: revision}
+	pod.OwnerReferences = []metav1.OwnerReference{{
+		APIVersion: "apps/v1", Kind: "StatefulSet", Name: "vault", UID: "sts-uid", Controller: ptr.To(true),
+	}}
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	return pod
+}
+
+func TestReconcile_TracksRollout(t *testing.T) {
+	keys := []string{"key-1", "key-2"}
+	fake := vaultfake.NewServer(vaultfake.WithKeys(keys, 2))
+	defer fake.Close()
+
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault", UID: "sts-uid"},
+		Spec:       appsv1.StatefulSetSpec{Replicas: ptr.To(int32(2))},
+		Status:     appsv1.StatefulSetStatus{CurrentRevision: "vault-v1", UpdateRevision: "vault-v2"},
+	}
+	keysJSON, err := json.Marshal(keys)
+	require.NoError(t, err)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": keysJSON},
+	}
+	// vault-1 was replaced by the rollout while the old pod was backing off.
+	vu := newFinalizerTestUnsealer().WithVaultURL("http://vault").WithReplicaScope(opsv1alpha1.ReplicaScopeCluster)
+	vu.Status.Pods = []opsv1alpha1.PodStatus{{
+		Name: "vault-1", Revision: "vault-v1", State: opsv1alpha1.PodStateSealed,
+		ConsecutiveFailures: 4, NextAttemptTime: &metav1.Time{Time: time.Now().Add(time.Hour)},
+	}}
+	r := newFakeReconciler(t, vu, statefulSet, secret,
+		newRolloutPod(fake, "vault-0", "vault-v1"), newRolloutPod(fake, "vault-1", "vault-v2"))
+
+	got := reconcileAndGet(t, r)
+	require.Len(t, got.Status.Pods, 2)
+	newPod := got.Status.Pods[0]
+	assert.Equal(t, "vault-1", newPod.Name, "new revision is unsealed first")
+	assert.Equal(t, "vault-v2", newPod.Revision)
+	assert.Equal(t, opsv1alpha1.PodStateUnsealed, newPod.State, "old backoff does not apply to the new pod")
+	assert.Zero(t, newPod.ConsecutiveFailures)
+	assert.Equal(t, "1/2 new revision unsealed", got.Status.Rollout)
+
+	// Once the StatefulSet reports the rollout done the progress is cleared.
+	require.NoError(t, r.Get(t.Context(), client.ObjectKeyFromObject(statefulSet), statefulSet))
+	statefulSet.Status.CurrentRevision = "vault-v2"
+	require.NoError(t, r.Status().Update(t.Context(), statefulSet))
+	pod := &corev1.Pod{}
+	require.NoError(t, r.Get(t.Context(), client.ObjectKey{Namespace: "vault", Name: "vault-0"}, pod))
+	pod.Labels[appsv1.ControllerRevisionHashLabelKey] = "vault-v2"
+	require.NoError(t, r.Update(t.Context(), pod))
+	got = reconcileAndGet(t, r)
+	assert.Empty(t, got.Status.Rollout)
+}
+
+func TestOwnerStatefulSet(t *testing.T) {
+	fake := vaultfake.NewServer()
+	defer fake.Close()
+	a, b := newRolloutPod(fake, "vault-0", "v1"), newRolloutPod(fake, "vault-1", "v1")
+	assert.Equal(t, "vault", ownerStatefulSet([]corev1.Pod{*a, *b}))
+
+	b.OwnerReferences[0].Name = "other"
+	assert.Empty(t, ownerStatefulSet([]corev1.Pod{*a, *b}), "pods of different StatefulSets")
+
+	b.OwnerReferences = nil
+	assert.Empty(t, ownerStatefulSet([]corev1.Pod{*a, *b}), "unowned pod")
+}
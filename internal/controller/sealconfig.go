@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// sealConfig is the number of key shares and the unseal threshold a pod
+// reported in its seal status.
+type sealConfig struct {
+	shares    int
+	threshold int
+}
+
+// recordSealConfig stores the seal configuration Vault reported in status and
+// warns when the configured keys no longer match it, as happens when Vault is
+// rekeyed but the VaultUnsealer and its Secrets are not updated. keyCount is
+// the number of keys the keys phase loaded, or -1 when no keys were loaded.
+func (r *VaultUnsealerReconciler) recordSealConfig(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer, seal sealConfig, keyCount int) {
+	if seal.threshold <= 0 {
+		// No pod answered; keep what was last observed.
+		return
+	}
+	observed := &opsv1alpha1.SealConfigStatus{Shares: int32(seal.shares), Threshold: int32(seal.threshold)}
+	if previous := vaultUnsealer.Status.SealConfig; previous != nil && *previous != *observed {
+		message := fmt.Sprintf("Vault now reports %d key shares with threshold %d, previously %d with threshold %d; update the unseal keys Secrets if Vault was rekeyed",
+			observed.Shares, observed.Threshold, previous.Shares, previous.Threshold)
+		logf.FromContext(ctx).Info("Vault seal configuration changed", "shares", observed.Shares, "threshold", observed.Threshold,
+			"previousShares", previous.Shares, "previousThreshold", previous.Threshold)
+		r.event(ctx, vaultUnsealer, corev1.EventTypeWarning, ReasonSealConfigChanged, message)
+	}
+	vaultUnsealer.Status.SealConfig = observed
+
+	for _, message := range sealConfigDrift(vaultUnsealer.Spec.KeyThreshold, keyCount, seal.threshold) {
+		addWarning(vaultUnsealer, opsv1alpha1.WarningSourceRuntime, message)
+	}
+}
+
+// sealConfigDrift lists the mismatches between the configured keys and the
+// unseal threshold Vault reports. A negative keyCount skips the key check.
+func sealConfigDrift(keyThreshold, keyCount, threshold int) []string {
+	var drift []string
+	if keyThreshold > threshold {
+		drift = append(drift, fmt.Sprintf("spec.keyThreshold %d exceeds the unseal threshold %d Vault reports", keyThreshold, threshold))
+	}
+	if keyCount >= 0 && keyCount < threshold {
+		drift = append(drift, fmt.Sprintf("Only %d unseal key shares are configured, but Vault needs %d to unseal", keyCount, threshold))
+	}
+	return drift
+}
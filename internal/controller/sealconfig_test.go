@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/package controller
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+func TestSealConfigDrift(t *testing.T) {
+	tests := []struct {
+		name         string
+		keyThreshold int
+		keyCount     int
+		threshold    int
+		want         int
+	}{
+		{name: "matches", keyCount: 3, threshold: 3},
+		{name: "more keys than needed", keyCount: 5, threshold: 3},
+		{name: "keyThreshold above threshold", keyThreshold: 4, keyCount: 4, threshold: 3, want: 1},
+		{name: "too few keys", keyCount: 2, threshold: 3, want: 1},
+		{name: "keyThreshold below threshold", keyThreshold: 2, keyCount: 2, threshold: 3, want: 1},
+		{name: "keys not loaded", keyThreshold: 2, keyCount: -1, threshold: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Len(t, sealConfigDrift(tt.keyThreshold, tt.keyCount, tt.threshold), tt.want)
+		})
+	}
+}
+
+func TestReconcile_RecordsSealConfig(t *testing.T) {
+	fake := vaultfake.NewServer(vaultfake.WithKeys([]string{"key-1", "key-2", "key-3"}, 3))
+	defer fake.Close()
+	keysJSON, err := json.Marshal([]string{"key-1", "key-2"})
+	require.NoError(t, err)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": keysJSON},
+	}
+	vu := newFinalizerTestUnsealer().WithVaultURL("http://vault")
+	r := newFakeReconciler(t, vu, newRolloutPod(fake, "vault-0", "v1"), secret)
+	recorder := record.NewFakeRecorder(100)
+	r.Recorder = recorder
+
+	got := reconcileAndGet(t, r)
+	assert.Equal(t, &opsv1alpha1.SealConfigStatus{Shares: 3, Threshold: 3}, got.Status.SealConfig)
+	assert.Contains(t, got.Status.Warnings, opsv1alpha1.StatusWarning{
+		Source:  opsv1alpha1.WarningSourceRuntime,
+		Message: "Only 2 unseal key shares are configured, but Vault needs 3 to unseal",
+	})
+	for len(recorder.Events) > 0 {
+		assert.NotContains(t, <-recorder.Events, ReasonSealConfigChanged, "the first observation is not a change")
+	}
+
+	// Vault rekeyed to 5 shares with threshold 2 since the last reconcile.
+	got.Status.SealConfig = &opsv1alpha1.SealConfigStatus{Shares: 5, Threshold: 2}
+	require.NoError(t, r.Status().Update(t.Context(), got))
+	got = reconcileAndGet(t, r)
+	assert.Equal(t, &opsv1alpha1.SealConfigStatus{Shares: 3, Threshold: 3}, got.Status.SealConfig)
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	assert.Contains(t, strings.Join(events, "\n"),
+		"Warning SealConfigChanged Vault now reports 3 key shares with threshold 3, previously 5 with threshold 2")
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// reconcileSealMigration reports the pods with a pending seal migration
+// through the SealMigrationInProgress condition, raising an Event whenever
+// the condition starts or changes reason.
+func (r *VaultUnsealerReconciler) reconcileSealMigration(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer, pods []string) {
+	if len(pods) == 0 {
+		r.clearCondition(vaultUnsealer, ConditionTypeSealMigration)
+		return
+	}
+
+	reason := ReasonSealMigrationPaused
+	message := fmt.Sprintf("Seal migration in progress on %s; keys are not submitted until spec.sealMigration is set", strings.Join(pods, ", "))
+	if vaultUnsealer.Spec.SealMigration {
+		reason = ReasonSealMigrationUnseal
+		message = fmt.Sprintf("Seal migration in progress on %s; keys are submitted in migration mode", strings.Join(pods, ", "))
+	}
+	if existing := findCondition(vaultUnsealer, ConditionTypeSealMigration); existing == nil || existing.Reason != reason {
+		r.event(ctx, vaultUnsealer, corev1.EventTypeWarning, reason, message)
+	}
+	r.setCondition(vaultUnsealer, ConditionTypeSealMigration, ConditionStatusTrue, reason, message)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+func TestCheckAndUnsealPod_SealMigration(t *testing.T) {
+	keys := []string{"key-1", "key-2"}
+	fake := vaultfake.NewServer(vaultfake.WithKeys(keys, 2))
+	defer fake.Close()
+	fake.SetMigration(true)
+	pod, vu := newFakeVaultPod(fake)
+	r := &VaultUnsealerReconciler{}
+
+	result, err := r.checkAndUnsealPod(context.Background(), pod, vu, newKeys(keys...), nil)
+	require.NoError(t, err)
+	assert.True(t, result.sealed)
+	assert.True(t, result.sealMigration)
+	assert.Zero(t, fake.UnsealRequests(), "no keys are submitted without spec.sealMigration")
+
+	vu.WithSealMigration(true)
+	result, err = r.checkAndUnsealPod(context.Background(), pod, vu, newKeys(keys...), nil)
+	require.NoError(t, err)
+	assert.False(t, result.sealed)
+	assert.False(t, fake.Sealed())
+}
+
+func TestReconcileSealMigration(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := &VaultUnsealerReconciler{Recorder: recorder}
+	vu := newFinalizerTestUnsealer()
+
+	r.reconcileSealMigration(context.Background(), vu, []string{"vault-0"})
+	r.reconcileSealMigration(context.Background(), vu, []string{"vault-0"})
+	assertCondition(t, vu, ConditionTypeSealMigration, ConditionStatusTrue, ReasonSealMigrationPaused)
+	assert.Len(t, recorder.Events, 1, "the Event is raised once")
+
+	vu.WithSealMigration(true)
+	r.reconcileSealMigration(context.Background(), vu, []string{"vault-0"})
+	assertCondition(t, vu, ConditionTypeSealMigration, ConditionStatusTrue, ReasonSealMigrationUnseal)
+	assert.Len(t, recorder.Events, 2)
+
+	r.reconcileSealMigration(context.Background(), vu, nil)
+	assert.Nil(t, findCondition(vu, ConditionTypeSealMigration))
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/metrics"
+)
+
+// sealCheckTimeout bounds a single seal-status request so one unreachable pod
+// does not delay polling of the others.
+const sealCheckTimeout = 5 * time.Second
+
+// SealWatcher polls the seal status of the pods of every VaultUnsealer with
+// spec.sealWatch set, more often than they are reconciled, and reports pods
+// that seal between reconciles. It never submits keys itself but queues a
+// reconcile of the VaultUnsealer, which does unless it is paused, so it also
+// suits teams that unseal manually and only want fast detection.
+type SealWatcher struct {
+	Reconciler *VaultUnsealerReconciler
+
+	// Resolution is how often due polls are looked for. Defaults to 1s.
+	Resolution time.Duration
+
+	lastPoll map[types.NamespacedName]time.Time
+	sealed   map[types.NamespacedName]map[string]bool
+}
+
+// Start polls until ctx is cancelled. It runs only on the leader.
+func (w *SealWatcher) Start(ctx context.Context) error {
+	resolution := w.Resolution
+	if resolution <= 0 {
+		resolution = time.Second
+	}
+
+	ticker := time.NewTicker(resolution)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case now := <-ticker.C:
+			w.poll(ctx, now)
+		}
+	}
+}
+
+// poll checks every watched VaultUnsealer whose watch interval has elapsed.
+func (w *SealWatcher) poll(ctx context.Context, now time.Time) {
+	log := logf.FromContext(ctx).WithName("seal-watcher")
+	if w.lastPoll == nil {
+		w.lastPoll = make(map[types.NamespacedName]time.Time)
+		w.sealed = make(map[types.NamespacedName]map[string]bool)
+	}
+
+	list := &opsv1alpha1.VaultUnsealerList{}
+	if err := w.Reconciler.List(ctx, list); err != nil {
+		log.Error(err, "Failed to list VaultUnsealers")
+		return
+	}
+
+	watched := make(map[types.NamespacedName]bool, len(list.Items))
+	for i := range list.Items {
+		vaultUnsealer := &list.Items[i]
+		if vaultUnsealer.Spec.SealWatch == nil || vaultUnsealer.Spec.SealWatch.Interval.Duration <= 0 {
+			continue
+		}
+		if w.Reconciler.Shard != nil && !w.Reconciler.Shard.Owns(vaultUnsealer) {
+			continue
+		}
+
+		key := types.NamespacedName{Namespace: vaultUnsealer.Namespace, Name: vaultUnsealer.Name}
+		watched[key] = true
+		if last, ok := w.lastPoll[key]; ok && now.Sub(last) < vaultUnsealer.Spec.SealWatch.Interval.Duration {
+			continue
+		}
+		w.lastPoll[key] = now
+		w.check(ctx, vaultUnsealer, key)
+	}
+
+	for key := range w.lastPoll {
+		if !watched[key] {
+			delete(w.lastPoll, key)
+			delete(w.sealed, key)
+		}
+	}
+}
+
+// check polls each running pod of vaultUnsealer and raises a Warning event
+// for pods that were not sealed when last seen.
+func (w *SealWatcher) check(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer, key types.NamespacedName) {
+	log := logf.FromContext(ctx).WithName("seal-watcher").WithValues("vaultunsealer", key.String())
+	r := w.Reconciler
+
+	pods, err := r.getVaultPods(ctx, vaultUnsealer)
+	if err != nil {
+		log.Error(err, "Failed to get Vault pods")
+		return
+	}
+
+	previous := w.sealed[key]
+	current := make(map[string]bool, len(pods))
+	for i := range pods {
+		pod := &pods[i]
+		if !isPodRunning(pod) {
+			continue
+		}
+
+		sealed, err := w.sealStatus(ctx, pod, vaultUnsealer)
+		if err != nil {
+			log.V(1).Info("Failed to get seal status", "pod", pod.Name, "error", err.Error())
+			if last, ok := previous[pod.Name]; ok {
+				current[pod.Name] = last
+			}
+			continue
+		}
+		current[pod.Name] = sealed
+
+		if sealed {
+			metrics.PodSealed.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, pod.Name).Set(1)
+		} else {
+			metrics.PodSealed.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, pod.Name).Set(0)
+		}
+		if sealed && !previous[pod.Name] {
+			log.Info("Vault pod is sealed", "pod", pod.Name)
+			r.event(ctx, vaultUnsealer, corev1.EventTypeWarning, ReasonVaultSealed,
+				fmt.Sprintf("Vault pod %s is sealed", pod.Name))
+			r.requestReconcile(ctx, vaultUnsealer)
+		}
+	}
+
+	for podName := range previous {
+		if _, ok := current[podName]; !ok {
+			metrics.PodSealed.DeleteLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, podName)
+		}
+	}
+	w.sealed[key] = current
+}
+
+func (w *SealWatcher) sealStatus(ctx context.Context, pod *corev1.Pod, vaultUnsealer *opsv1alpha1.VaultUnsealer) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, sealCheckTimeout)
+	defer cancel()
+
+	vaultClient, err := w.Reconciler.createVaultClient(ctx, pod, vaultUnsealer)
+	if err != nil {
+		return false, err
+	}
+	status, err := vaultClient.GetSealStatus(ctx)
+	if err != nil {
+		return false, err
+	}
+	return status.Sealed, nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+func TestSealWatcher_ReportsSealing(t *testing.T) {
+	keys := []string{"key-1"}
+	fake := vaultfake.NewServer(vaultfake.WithKeys(keys, 1), vaultfake.WithUnsealed())
+	defer fake.Close()
+
+	pod, vu := newFakeVaultPod(fake)
+	pod.Labels = map[string]string{"app": "vault"}
+	pod.Status.Phase = corev1.PodRunning
+	vu.WithLabelSelector("app=vault").WithSealWatch(10 * time.Second)
+
+	r := newFakeReconciler(t, vu, pod)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+	r.wakeups = make(chan event.GenericEvent, 1)
+	w := &SealWatcher{Reconciler: r}
+	ctx := context.Background()
+	now := time.Now()
+
+	w.poll(ctx, now)
+	assert.Empty(t, recorder.Events, "unsealed pod raises no event")
+
+	fake.Seal()
+	w.poll(ctx, now.Add(5*time.Second))
+	assert.Empty(t, recorder.Events, "polls are spaced by the watch interval")
+
+	w.poll(ctx, now.Add(10*time.Second))
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, ReasonVaultSealed)
+	require.Len(t, r.wakeups, 1, "a reconcile is queued to unseal the pod")
+	assert.Equal(t, vu.Name, (<-r.wakeups).Object.GetName())
+
+	w.poll(ctx, now.Add(20*time.Second))
+	assert.Empty(t, recorder.Events, "a pod that stays sealed is reported once")
+	assert.Equal(t, 0, fake.UnsealRequests(), "the watcher never submits keys")
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// vaultPodSelectors parses the label selectors of vaultUnsealer. A pod is a
+// Vault pod when it matches any of them.
+func vaultPodSelectors(vaultUnsealer *opsv1alpha1.VaultUnsealer) ([]labels.Selector, error) {
+	raw := vaultUnsealer.Spec.LabelSelectors()
+	selectors := make([]labels.Selector, 0, len(raw))
+	for _, value := range raw {
+		selector, err := labels.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector %q: %w", value, err)
+		}
+		selectors = append(selectors, selector)
+	}
+	return selectors, nil
+}
+
+// MatchesVaultPod reports whether podLabels match any of vaultUnsealer's
+// label selectors.
+func MatchesVaultPod(vaultUnsealer *opsv1alpha1.VaultUnsealer, podLabels map[string]string) bool {
+	selectors, err := vaultPodSelectors(vaultUnsealer)
+	if err != nil {
+		return false
+	}
+	for _, selector := range selectors {
+		if selector.Matches(labels.Set(podLabels)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+func TestGetVaultPods_LabelSelectorsAreORed(t *testing.T) {
+	newPod := func(name string, podLabels map[string]string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "vault", Labels: podLabels}}
+	}
+	legacy := newPod("vault-legacy", map[string]string{"app": "vault"})
+	migrated := newPod("vault-new", map[string]string{"app.kubernetes.io/name": "vault"})
+	both := newPod("vault-both", map[string]string{"app": "vault", "app.kubernetes.io/name": "vault"})
+	other := newPod("other", map[string]string{"app": "other"})
+
+	vu := newFinalizerTestUnsealer().WithLabelSelectors("app.kubernetes.io/name=vault")
+	r := newFakeReconciler(t, vu, legacy, migrated, both, other)
+
+	pods, err := r.getVaultPods(context.Background(), vu)
+	require.NoError(t, err)
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	assert.ElementsMatch(t, []string{"vault-legacy", "vault-new", "vault-both"}, names, "a pod matching both selectors is listed once")
+
+	assert.True(t, MatchesVaultPod(vu, migrated.Labels))
+	assert.False(t, MatchesVaultPod(vu, other.Labels))
+}
+
+func TestLabelSelectors(t *testing.T) {
+	spec := opsv1alpha1.VaultUnsealerSpec{VaultLabelSelector: "app=vault"}
+	assert.Equal(t, []string{"app=vault"}, spec.LabelSelectors())
+
+	spec.VaultLabelSelectors = []string{"app.kubernetes.io/name=vault"}
+	assert.Equal(t, []string{"app=vault", "app.kubernetes.io/name=vault"}, spec.LabelSelectors())
+
+	spec.VaultLabelSelector = ""
+	assert.Equal(t, []string{"app.kubernetes.io/name=vault"}, spec.LabelSelectors(), "an empty vaultLabelSelector does not select every pod")
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"hash/fnv"
+	"strconv"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// Shard identifies the subset of VaultUnsealer resources handled by one
+// manager replica when running several replicas side by side.
+type Shard struct {
+	// ID is this replica's shard, in the range [0, Count).
+	ID int
+	// Count is the total number of shards.
+	Count int
+}
+
+// Owns reports whether obj belongs to this shard. Resources carrying the
+// shard label are pinned to that shard; all others are placed by hashing
+// their namespace and name.
+func (s Shard) Owns(obj client.Object) bool {
+	if s.Count <= 1 {
+		return true
+	}
+	return ShardFor(obj, s.Count) == s.ID
+}
+
+// ownedBy reports whether obj belongs to shard, where a nil shard owns
+// everything.
+func ownedBy(shard *Shard, obj client.Object) bool {
+	return shard == nil || shard.Owns(obj)
+}
+
+// ShardFor returns the shard obj is assigned to out of count shards.
+func ShardFor(obj client.Object, count int) int {
+	if value, ok := obj.GetLabels()[opsv1alpha1.LabelShard]; ok {
+		if id, err := strconv.Atoi(value); err == nil && id >= 0 && id < count {
+			return id
+		}
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(obj.GetNamespace() + "/" + obj.GetName()))
+	return jumpHash(h.Sum64(), count)
+}
+
+// jumpHash is Lamping and Veach's jump consistent hash. Growing the shard
+// count from n to n+1 moves only 1/(n+1) of the resources to a new shard.
+func jumpHash(key uint64, buckets int) int {
+	var b, j int64 = -1, 0
+	for j < int64(buckets) {
+		b = j
+		key = key*2862933555777941757 + 1
+		j = int64(float64(b+1) * (float64(int64(1)<<31) / float64((key>>33)+1)))
+	}
+	return int(b)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+func newShardedUnsealer(name string, labels map[string]string) *opsv1alpha1.VaultUnsealer {
+	return &opsv1alpha1.VaultUnsealer{ObjectMeta: metav1.ObjectMeta{Namespace: "vault", Name: name, Labels: labels}}
+}
+
+func TestShard_OwnsExactlyOne(t *testing.T) {
+	const count = 4
+	for i := range 100 {
+		vu := newShardedUnsealer(fmt.Sprintf("vu-%d", i), nil)
+		owners := 0
+		for id := range count {
+			if (Shard{ID: id, Count: count}).Owns(vu) {
+				owners++
+			}
+		}
+		assert.Equal(t, 1, owners, vu.Name)
+	}
+}
+
+func TestShardFor_LabelOverride(t *testing.T) {
+	vu := newShardedUnsealer("pinned", map[string]string{opsv1alpha1.LabelShard: "2"})
+	assert.Equal(t, 2, ShardFor(vu, 3))
+
+	// Out-of-range labels fall back to hashing.
+	vu.Labels[opsv1alpha1.LabelShard] = "7"
+	assert.Less(t, ShardFor(vu, 3), 3)
+}
+
+func TestShardFor_MinimalMovement(t *testing.T) {
+	moved := 0
+	for i := range 1000 {
+		vu := newShardedUnsealer(fmt.Sprintf("vu-%d", i), nil)
+		if ShardFor(vu, 4) != ShardFor(vu, 5) {
+			moved++
+		}
+	}
+	// Consistent hashing moves roughly 1/5 of the resources.
+	assert.Less(t, moved, 300)
+}
+
+func TestShard_SingleShardOwnsEverything(t *testing.T) {
+	assert.True(t, Shard{ID: 0, Count: 1}.Owns(newShardedUnsealer("any", nil)))
+}
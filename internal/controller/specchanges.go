@@ -0,0 +1,164 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// maxChangeValueLength bounds each value quoted in a SpecChanged Event, so
+// a long list field cannot crowd out the others.
+const maxChangeValueLength = 64
+
+// specHistory remembers the spec last reconciled for each VaultUnsealer so
+// that a new generation can be reported as the fields it changed. It is kept
+// in memory only: the first reconcile after the operator starts records the
+// spec without reporting anything. The zero value is ready to use.
+type specHistory struct {
+	mu   sync.Mutex
+	seen map[types.NamespacedName]observedSpec
+}
+
+type observedSpec struct {
+	generation int64
+	spec       opsv1alpha1.VaultUnsealerSpec
+}
+
+// observe records the spec of vaultUnsealer and returns the one recorded
+// before it when the generation changed since.
+func (h *specHistory) observe(vaultUnsealer *opsv1alpha1.VaultUnsealer) (previous observedSpec, changed bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	key := client.ObjectKeyFromObject(vaultUnsealer)
+	last, ok := h.seen[key]
+	if ok && last.generation == vaultUnsealer.Generation {
+		return observedSpec{}, false
+	}
+	if h.seen == nil {
+		h.seen = make(map[types.NamespacedName]observedSpec)
+	}
+	h.seen[key] = observedSpec{generation: vaultUnsealer.Generation, spec: *vaultUnsealer.Spec.DeepCopy()}
+	return last, ok
+}
+
+// forget drops what is remembered about a deleted VaultUnsealer.
+func (h *specHistory) forget(key types.NamespacedName) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.seen, key)
+}
+
+// reportSpecChanges logs and emits a SpecChanged Event listing the spec
+// fields changed since the previous generation reconciled, since seal
+// regressions often coincide with an edit to the VaultUnsealer.
+func (r *VaultUnsealerReconciler) reportSpecChanges(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) {
+	previous, changed := r.specs.observe(vaultUnsealer)
+	if !changed {
+		return
+	}
+	changes := specChanges(previous.spec, vaultUnsealer.Spec)
+	if len(changes) == 0 {
+		return
+	}
+	logf.FromContext(ctx).Info("Spec changed", "previousGeneration", previous.generation, "changes", changes)
+	r.event(ctx, vaultUnsealer, corev1.EventTypeNormal, ReasonSpecChanged,
+		fmt.Sprintf("Generation %d changed %s", vaultUnsealer.Generation, strings.Join(changes, "; ")))
+}
+
+// specChanges describes each field that differs between two specs as
+// "path: old -> new", sorted by path. Fields are named by their JSON paths,
+// such as vault.url.
+func specChanges(before, after opsv1alpha1.VaultUnsealerSpec) []string {
+	old, current := specFields(before), specFields(after)
+	paths := make([]string, 0, len(current))
+	for path := range current {
+		paths = append(paths, path)
+	}
+	for path := range old {
+		if _, ok := current[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+
+	var changes []string
+	for _, path := range paths {
+		from, wasSet := old[path]
+		to, isSet := current[path]
+		if wasSet && isSet && from == to {
+			continue
+		}
+		if !wasSet {
+			from = "unset"
+		}
+		if !isSet {
+			to = "unset"
+		}
+		changes = append(changes, fmt.Sprintf("%s: %s -> %s", path, from, to))
+	}
+	return changes
+}
+
+// specFields flattens spec into its set leaf fields keyed by JSON path.
+// Lists are compared as a whole.
+func specFields(spec opsv1alpha1.VaultUnsealerSpec) map[string]string {
+	fields := make(map[string]string)
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return fields
+	}
+	var value map[string]any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fields
+	}
+	flattenFields("", value, fields)
+	return fields
+}
+
+func flattenFields(path string, value any, fields map[string]string) {
+	if object, ok := value.(map[string]any); ok {
+		for name, child := range object {
+			if path != "" {
+				name = path + "." + name
+			}
+			flattenFields(name, child, fields)
+		}
+		return
+	}
+
+	var formatted string
+	if s, ok := value.(string); ok {
+		formatted = s
+	} else {
+		data, _ := json.Marshal(value)
+		formatted = string(data)
+	}
+	if len(formatted) > maxChangeValueLength {
+		formatted = formatted[:maxChangeValueLength] + "..."
+	}
+	fields[path] = formatted
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/record"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+func TestSpecChanges(t *testing.T) {
+	before := newFinalizerTestUnsealer().WithKeyThreshold(3).Spec
+	after := newFinalizerTestUnsealer().WithVaultURL("https://vault.vault.svc:8200").WithLabelSelector("app=vault-new").Spec
+	after.Mode.Scope = opsv1alpha1.ReplicaScopeSingle
+
+	assert.Equal(t, []string{
+		"keyThreshold: 3 -> unset",
+		"mode.scope: unset -> " + opsv1alpha1.ReplicaScopeSingle,
+		"vault.address: http://vault:8200 -> https://vault.vault.svc:8200",
+		"vaultLabelSelector: app=vault -> app=vault-new",
+	}, specChanges(before, after))
+	assert.Empty(t, specChanges(before, before))
+}
+
+// specChangedEvents drains recorder, returning the SpecChanged Events.
+func specChangedEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for len(recorder.Events) > 0 {
+		if event := <-recorder.Events; strings.Contains(event, ReasonSpecChanged) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func TestReconcile_ReportsSpecChanges(t *testing.T) {
+	vu := newFinalizerTestUnsealer()
+	vu.Generation = 1
+	r := newFakeReconciler(t, vu)
+	recorder := record.NewFakeRecorder(100)
+	r.Recorder = recorder
+
+	got := reconcileAndGet(t, r)
+	assert.Empty(t, specChangedEvents(recorder), "the first generation seen is only recorded")
+
+	got.Spec.KeyThreshold = 2
+	got.Generation = 2
+	require.NoError(t, r.Update(context.Background(), got))
+	reconcileAndGet(t, r)
+	events := specChangedEvents(recorder)
+	require.Len(t, events, 1)
+	assert.Contains(t, events[0], "Normal SpecChanged Generation 2 changed keyThreshold: unset -> 2")
+
+	reconcileAndGet(t, r)
+	assert.Empty(t, specChangedEvents(recorder), "an unchanged generation is not reported again")
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// runtimeSpecError reports spec problems that no retry can fix, so resources
+// admitted without the webhook stop being requeued until their spec changes.
+func runtimeSpecError(vaultUnsealer *opsv1alpha1.VaultUnsealer) error {
+	spec := vaultUnsealer.Spec
+	if _, err := labels.Parse(spec.VaultLabelSelector); err != nil {
+		return fmt.Errorf("spec.vaultLabelSelector: invalid label selector: %w", err)
+	}
+	for i, selector := range spec.VaultLabelSelectors {
+		if _, err := labels.Parse(selector); err != nil {
+			return fmt.Errorf("spec.vaultLabelSelectors[%d]: invalid label selector: %w", i, err)
+		}
+	}
+	if address := spec.Vault.Endpoint(); address != "" {
+		u, err := url.Parse(address)
+		if err != nil {
+			return fmt.Errorf("spec.vault.address: invalid URL: %w", err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("spec.vault.address: %q must be an http or https URL with a host", address)
+		}
+	}
+	if hostTemplate := spec.Vault.PerPodHostTemplate; hostTemplate != "" {
+		if _, err := template.New("perPodHost").Parse(hostTemplate); err != nil {
+			return fmt.Errorf("spec.vault.perPodHostTemplate: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateSpec runs the runtime checks and, when configured, the full
+// SpecValidator.
+func (r *VaultUnsealerReconciler) validateSpec(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) error {
+	if err := runtimeSpecError(vaultUnsealer); err != nil {
+		return err
+	}
+	if r.SpecValidator != nil {
+		return r.SpecValidator(ctx, vaultUnsealer)
+	}
+	return nil
+}
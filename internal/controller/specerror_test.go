@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+func TestRuntimeSpecError(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(vu *opsv1alpha1.VaultUnsealer)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(*opsv1alpha1.VaultUnsealer) {}},
+		{
+			name:    "bad selector",
+			mutate:  func(vu *opsv1alpha1.VaultUnsealer) { vu.Spec.VaultLabelSelector = "app in (vault" },
+			wantErr: "spec.vaultLabelSelector",
+		},
+		{
+			name:    "bad additional selector",
+			mutate:  func(vu *opsv1alpha1.VaultUnsealer) { vu.WithLabelSelectors("app=vault", "app in (vault") },
+			wantErr: "spec.vaultLabelSelectors[1]",
+		},
+		{
+			name:    "malformed URL",
+			mutate:  func(vu *opsv1alpha1.VaultUnsealer) { vu.Spec.Vault.Address = "http://[vault" },
+			wantErr: "spec.vault.address",
+		},
+		{
+			name:    "URL without scheme",
+			mutate:  func(vu *opsv1alpha1.VaultUnsealer) { vu.Spec.Vault.Address = "vault.vault.svc:8200" },
+			wantErr: "spec.vault.address",
+		},
+		{
+			name:    "bad host template",
+			mutate:  func(vu *opsv1alpha1.VaultUnsealer) { vu.Spec.Vault.PerPodHostTemplate = "vault-{{.Ordinal" },
+			wantErr: "spec.vault.perPodHostTemplate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vu := newFinalizerTestUnsealer()
+			tt.mutate(vu)
+			err := runtimeSpecError(vu)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestReconcile_InvalidSpecIsTerminal(t *testing.T) {
+	vu := newFinalizerTestUnsealer().WithLabelSelector("app in (vault")
+	r := newFakeReconciler(t, vu)
+	recorder := record.NewFakeRecorder(10)
+	r.Recorder = recorder
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "vault", Name: "main"}}
+
+	for range 2 {
+		result, err := r.Reconcile(t.Context(), request)
+		require.NoError(t, err)
+		assert.Zero(t, result, "an invalid spec is not retried")
+	}
+	require.Len(t, recorder.Events, 1, "the event is only raised when the spec first fails")
+
+	got := &opsv1alpha1.VaultUnsealer{}
+	require.NoError(t, r.Get(t.Context(), request.NamespacedName, got))
+	assertCondition(t, got, ConditionTypeInvalidSpec, ConditionStatusTrue, ReasonValidationFailed)
+	assert.Nil(t, findCondition(got, ConditionTypeTargetsDiscovered), "discovery did not run")
+
+	got.Spec.VaultLabelSelector = "app=vault"
+	require.NoError(t, r.Update(t.Context(), got))
+	assert.Nil(t, findCondition(reconcileAndGet(t, r), ConditionTypeInvalidSpec))
+}
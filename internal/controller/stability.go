@@ -0,0 +1,51 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// stabilityCheckDelay is how long after an unseal the pod is checked again
+// before it is reported stable, so a Vault that reseals straight away, such as
+// a crash-looping container, is noticed even when no pod event arrives.
+const stabilityCheckDelay = 30 * time.Second
+
+// unsealStable reports whether a pod found unsealed at now counts as stable.
+// Pods the operator never unsealed are stable right away.
+func unsealStable(lastUnsealTime *metav1.Time, now time.Time) bool {
+	return lastUnsealTime == nil || now.Sub(lastUnsealTime.Time) >= stabilityCheckDelay
+}
+
+// stabilityRecheckAfter returns how long to wait before checking the earliest
+// unsealed pod that is not yet stable. ok is false when there is none.
+func stabilityRecheckAfter(podStatuses []opsv1alpha1.PodStatus, now time.Time) (wait time.Duration, ok bool) {
+	for _, podStatus := range podStatuses {
+		if podStatus.State != opsv1alpha1.PodStateUnsealed || podStatus.Stable || podStatus.LastUnsealTime == nil {
+			continue
+		}
+		remaining := max(podStatus.LastUnsealTime.Add(stabilityCheckDelay).Sub(now), time.Second)
+		if !ok || remaining < wait {
+			wait, ok = remaining, true
+		}
+	}
+	return wait, ok
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+func TestUnsealStable(t *testing.T) {
+	now := time.Now()
+	assert.True(t, unsealStable(nil, now), "never unsealed by the operator")
+	assert.False(t, unsealStable(&metav1.Time{Time: now}, now))
+	assert.False(t, unsealStable(&metav1.Time{Time: now.Add(-stabilityCheckDelay + time.Second)}, now))
+	assert.True(t, unsealStable(&metav1.Time{Time: now.Add(-stabilityCheckDelay)}, now))
+}
+
+func TestStabilityRecheckAfter(t *testing.T) {
+	now := time.Now()
+	_, ok := stabilityRecheckAfter([]opsv1alpha1.PodStatus{
+		{Name: "vault-0", State: opsv1alpha1.PodStateUnsealed, Stable: true, LastUnsealTime: &metav1.Time{Time: now}},
+		{Name: "vault-1", State: opsv1alpha1.PodStateSealed, LastUnsealTime: &metav1.Time{Time: now}},
+		{Name: "vault-2", State: opsv1alpha1.PodStateUnsealed},
+	}, now)
+	assert.False(t, ok, "no freshly unsealed pod")
+
+	wait, ok := stabilityRecheckAfter([]opsv1alpha1.PodStatus{
+		{Name: "vault-0", State: opsv1alpha1.PodStateUnsealed, LastUnsealTime: &metav1.Time{Time: now}},
+		{Name: "vault-1", State: opsv1alpha1.PodStateUnsealed, LastUnsealTime: &metav1.Time{Time: now.Add(-20 * time.Second)}},
+	}, now)
+	assert.True(t, ok)
+	assert.Equal(t, stabilityCheckDelay-20*time.Second, wait, "earliest pending check wins")
+
+	wait, _ = stabilityRecheckAfter([]opsv1alpha1.PodStatus{
+		{Name: "vault-0", State: opsv1alpha1.PodStateUnsealed, LastUnsealTime: &metav1.Time{Time: now.Add(-time.Hour)}},
+	}, now)
+	assert.Equal(t, time.Second, wait, "an overdue check is not scheduled in the past")
+}
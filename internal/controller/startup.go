@@ -0,0 +1,73 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+)
+
+// reconcileLimiter bounds how many reconciles run at once. Until the startup
+// window, which opens with the first reconcile, has passed it admits up to
+// burst reconciles so every Vault is unsealed quickly after a cluster-wide
+// restart; afterwards it admits up to steady.
+type reconcileLimiter struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	active int
+
+	burst, steady int
+	window        time.Duration
+	burstUntil    time.Time
+	now           func() time.Time
+}
+
+func newReconcileLimiter(burst, steady int, window time.Duration) *reconcileLimiter {
+	l := &reconcileLimiter{burst: burst, steady: steady, window: window, now: time.Now}
+	l.cond = sync.NewCond(&l.mu)
+	return l
+}
+
+// limit returns the number of reconciles currently allowed. Callers hold l.mu.
+func (l *reconcileLimiter) limit() int {
+	now := l.now()
+	if l.burstUntil.IsZero() {
+		l.burstUntil = now.Add(l.window)
+	}
+	if now.Before(l.burstUntil) {
+		return l.burst
+	}
+	return l.steady
+}
+
+// acquire blocks until another reconcile may run.
+func (l *reconcileLimiter) acquire() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for l.active >= l.limit() {
+		l.cond.Wait()
+	}
+	l.active++
+}
+
+// release frees the slot taken by acquire.
+func (l *reconcileLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.active--
+	l.cond.Broadcast()
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcileLimiter_BurstThenSteady(t *testing.T) {
+	now := time.Now()
+	var mu sync.Mutex
+	l := newReconcileLimiter(3, 1, time.Minute)
+	l.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+
+	// The burst admits three reconciles at once.
+	for range 3 {
+		l.acquire()
+	}
+	assert.Equal(t, 3, l.active)
+	for range 3 {
+		l.release()
+	}
+
+	mu.Lock()
+	now = now.Add(2 * time.Minute)
+	mu.Unlock()
+
+	// After the window only one runs at a time.
+	l.acquire()
+	acquired := make(chan struct{})
+	go func() {
+		l.acquire()
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("second reconcile admitted after the startup window")
+	case <-time.After(50 * time.Millisecond):
+	}
+	l.release()
+	<-acquired
+	l.release()
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/metrics"
+)
+
+func TestUpdateStatus_RetriesOnConflict(t *testing.T) {
+	ctx := context.Background()
+	r := newFakeReconciler(t, newFinalizerTestUnsealer())
+
+	stale := &opsv1alpha1.VaultUnsealer{}
+	require.NoError(t, r.Get(ctx, client.ObjectKey{Namespace: "vault", Name: "main"}, stale))
+
+	// Another writer updates the resource, leaving our copy stale.
+	latest := stale.DeepCopy()
+	latest.Labels = map[string]string{"team": "platform"}
+	require.NoError(t, r.Update(ctx, latest))
+
+	stale.Status.UnsealedPods = []string{"vault-0"}
+	require.NoError(t, r.updateStatus(ctx, stale))
+
+	got := &opsv1alpha1.VaultUnsealer{}
+	require.NoError(t, r.Get(ctx, client.ObjectKey{Namespace: "vault", Name: "main"}, got))
+	assert.Equal(t, []string{"vault-0"}, got.Status.UnsealedPods)
+	assert.Equal(t, "platform", got.Labels["team"])
+}
+
+func TestReconcile_SkipsNoOpStatusWrites(t *testing.T) {
+	r := newFakeReconciler(t, newFinalizerTestUnsealer())
+
+	first := reconcileAndGet(t, r)
+	require.NotEmpty(t, first.Status.Conditions, "first reconcile records the missing keys")
+
+	second := reconcileAndGet(t, r)
+	assert.Equal(t, first.ResourceVersion, second.ResourceVersion)
+	assert.Equal(t, first.Status, second.Status)
+}
+
+func TestReconcile_RecordsOperatorVersion(t *testing.T) {
+	r := newFakeReconciler(t, newFinalizerTestUnsealer())
+	r.Version = "v1.2.0"
+	assert.Equal(t, "v1.2.0", reconcileAndGet(t, r).Status.OperatorVersion)
+
+	// An upgraded operator rewrites status even when nothing else changed.
+	r.Version = "v1.3.0"
+	assert.Equal(t, "v1.3.0", reconcileAndGet(t, r).Status.OperatorVersion)
+}
+
+func TestStatusUnchanged(t *testing.T) {
+	original := &opsv1alpha1.VaultUnsealerStatus{LastReconcileTime: &metav1.Time{Time: time.Now()}}
+	current := original.DeepCopy()
+	current.LastReconcileTime = &metav1.Time{Time: time.Now().Add(time.Minute)}
+	current.PodsChecked = []string{}
+	assert.True(t, statusUnchanged(original, current))
+
+	current.UnsealedPods = []string{"vault-0"}
+	assert.False(t, statusUnchanged(original, current))
+}
+
+func TestReconcile_PrunesDeletedPods(t *testing.T) {
+	vu := newFinalizerTestUnsealer()
+	vu.Name = "pruned"
+	vu.Status.PodsChecked = []string{"vault-0", "vault-1"}
+	vu.Status.Pods = []opsv1alpha1.PodStatus{{Name: "vault-0"}, {Name: "vault-1"}}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "vault", Name: "vault-0", Labels: map[string]string{"app": "vault"}}}
+	metrics.VaultConnectionStatus.WithLabelValues("pruned", "vault", "vault-0").Set(1)
+	metrics.VaultConnectionStatus.WithLabelValues("pruned", "vault", "vault-1").Set(1)
+	before := testutil.CollectAndCount(metrics.VaultConnectionStatus)
+
+	r := newFakeReconciler(t, vu, pod)
+	key := types.NamespacedName{Namespace: "vault", Name: "pruned"}
+	_, _ = r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+
+	got := &opsv1alpha1.VaultUnsealer{}
+	require.NoError(t, r.Get(context.Background(), key, got))
+	require.Len(t, got.Status.Pods, 1)
+	assert.Equal(t, "vault-0", got.Status.Pods[0].Name)
+	assert.Equal(t, before-1, testutil.CollectAndCount(metrics.VaultConnectionStatus))
+}
+
+func TestTrackedPods(t *testing.T) {
+	status := &opsv1alpha1.VaultUnsealerStatus{
+		PodsChecked: []string{"vault-1", "vault-0"},
+		Pods:        []opsv1alpha1.PodStatus{{Name: "vault-0"}, {Name: "vault-2"}},
+	}
+	assert.Equal(t, []string{"vault-0", "vault-1", "vault-2"}, trackedPods(status))
+}
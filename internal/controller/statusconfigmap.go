@@ -0,0 +1,203 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// StatusConfigMapNameData is the data a status ConfigMap name template is
+// rendered with.
+type StatusConfigMapNameData struct {
+	Name      string
+	Namespace string
+}
+
+// RenderStatusConfigMapName renders a status ConfigMap name template, using
+// the default template when nameTemplate is empty.
+func RenderStatusConfigMapName(nameTemplate string, data StatusConfigMapNameData) (string, error) {
+	if nameTemplate == "" {
+		nameTemplate = opsv1alpha1.DefaultStatusConfigMapNameTemplate
+	}
+	tmpl, err := template.New("statusConfigMap").Option("missingkey=error").Parse(nameTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid status ConfigMap name template: %w", err)
+	}
+	var name strings.Builder
+	if err := tmpl.Execute(&name, data); err != nil {
+		return "", fmt.Errorf("failed to render status ConfigMap name template: %w", err)
+	}
+	if name.Len() == 0 {
+		return "", fmt.Errorf("status ConfigMap name template rendered an empty name")
+	}
+	return name.String(), nil
+}
+
+// podSummary is one entry of the status ConfigMap's pods.json.
+type podSummary struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+	Role  string `json:"role,omitempty"`
+}
+
+// statusConfigMapData summarises status in the keys of the status ConfigMap.
+// It leaves out lastReconcileTime so an unchanged status leaves the
+// ConfigMap untouched.
+func statusConfigMapData(vaultUnsealer *opsv1alpha1.VaultUnsealer) (map[string]string, error) {
+	status := &vaultUnsealer.Status
+	ready := ConditionStatusUnknown
+	if condition := findCondition(vaultUnsealer, ConditionTypeReady); condition != nil {
+		ready = condition.Status
+	}
+
+	var unsealed, sealed int
+	var lastUnseal time.Time
+	pods := make([]podSummary, 0, len(status.Pods))
+	for _, pod := range status.Pods {
+		switch pod.State {
+		case opsv1alpha1.PodStateUnsealed:
+			unsealed++
+		case opsv1alpha1.PodStateSealed:
+			sealed++
+		}
+		if pod.LastUnsealTime != nil && pod.LastUnsealTime.After(lastUnseal) {
+			lastUnseal = pod.LastUnsealTime.Time
+		}
+		pods = append(pods, podSummary{Name: pod.Name, State: pod.State, Role: pod.Role})
+	}
+	podsJSON, err := json.Marshal(pods)
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]string{
+		opsv1alpha1.StatusConfigMapReadyKey:           ready,
+		opsv1alpha1.StatusConfigMapAllPodsUnsealedKey: strconv.FormatBool(status.AllPodsUnsealed),
+		opsv1alpha1.StatusConfigMapPodsKey:            strconv.Itoa(len(status.Pods)),
+		opsv1alpha1.StatusConfigMapUnsealedPodsKey:    strconv.Itoa(unsealed),
+		opsv1alpha1.StatusConfigMapSealedPodsKey:      strconv.Itoa(sealed),
+		opsv1alpha1.StatusConfigMapLastUnsealTimeKey:  "",
+		opsv1alpha1.StatusConfigMapLastErrorKey:       status.LastError,
+		opsv1alpha1.StatusConfigMapPodsJSONKey:        string(podsJSON),
+	}
+	if !lastUnseal.IsZero() {
+		data[opsv1alpha1.StatusConfigMapLastUnsealTimeKey] = lastUnseal.UTC().Format(time.RFC3339)
+	}
+	return data, nil
+}
+
+// exportStatus writes the status summary to the ConfigMap named by
+// spec.statusConfigMap and removes the one it was previously exported to
+// when the name changed or the export was turned off. A failed export is
+// logged and reported in status.warnings without failing the reconcile.
+func (r *VaultUnsealerReconciler) exportStatus(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) {
+	log := logf.FromContext(ctx)
+
+	name := ""
+	if spec := vaultUnsealer.Spec.StatusConfigMap; spec != nil {
+		var err error
+		name, err = RenderStatusConfigMapName(spec.NameTemplate, StatusConfigMapNameData{Name: vaultUnsealer.Name, Namespace: vaultUnsealer.Namespace})
+		if err == nil {
+			err = r.writeStatusConfigMap(ctx, vaultUnsealer, name)
+		}
+		if err != nil {
+			log.Error(err, "Failed to export status to ConfigMap", "configMap", name)
+			addWarning(vaultUnsealer, opsv1alpha1.WarningSourceRuntime, fmt.Sprintf("Status could not be exported to a ConfigMap: %v", err))
+			return
+		}
+	}
+
+	if previous := vaultUnsealer.Status.StatusConfigMap; previous != "" && previous != name {
+		if err := r.deleteStatusConfigMap(ctx, vaultUnsealer, previous); err != nil {
+			log.Error(err, "Failed to delete previous status ConfigMap", "configMap", previous)
+			return
+		}
+		log.Info("Deleted previous status ConfigMap", "configMap", previous)
+	}
+	vaultUnsealer.Status.StatusConfigMap = name
+}
+
+// statusConfigMapReader reads status ConfigMaps past the cache, so the
+// operator does not watch every ConfigMap in the cluster.
+func (r *VaultUnsealerReconciler) statusConfigMapReader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+func (r *VaultUnsealerReconciler) writeStatusConfigMap(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer, name string) error {
+	data, err := statusConfigMapData(vaultUnsealer)
+	if err != nil {
+		return err
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err = r.statusConfigMapReader().Get(ctx, types.NamespacedName{Namespace: vaultUnsealer.Namespace, Name: name}, configMap)
+	if apierrors.IsNotFound(err) {
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: vaultUnsealer.Namespace, Name: name},
+			Data:       data,
+		}
+		if err := controllerutil.SetControllerReference(vaultUnsealer, configMap, r.Scheme); err != nil {
+			return err
+		}
+		return r.Create(ctx, configMap)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !metav1.IsControlledBy(configMap, vaultUnsealer) {
+		return fmt.Errorf("ConfigMap %s already exists and is not owned by this VaultUnsealer", name)
+	}
+	if maps.Equal(configMap.Data, data) {
+		return nil
+	}
+	configMap.Data = data
+	return r.Update(ctx, configMap)
+}
+
+// deleteStatusConfigMap deletes a status ConfigMap, leaving it alone if the
+// VaultUnsealer does not own it.
+func (r *VaultUnsealerReconciler) deleteStatusConfigMap(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer, name string) error {
+	configMap := &corev1.ConfigMap{}
+	if err := r.statusConfigMapReader().Get(ctx, types.NamespacedName{Namespace: vaultUnsealer.Namespace, Name: name}, configMap); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(configMap, vaultUnsealer) {
+		return nil
+	}
+	return client.IgnoreNotFound(r.Delete(ctx, configMap, client.Preconditions{UID: &configMap.UID}))
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+func TestRenderStatusConfigMapName(t *testing.T) {
+	data := StatusConfigMapNameData{Name: "main", Namespace: "vault"}
+
+	name, err := RenderStatusConfigMapName("", data)
+	require.NoError(t, err)
+	assert.Equal(t, "main-unseal-status", name)
+
+	name, err = RenderStatusConfigMapName("{{ .Namespace }}-{{ .Name }}", data)
+	require.NoError(t, err)
+	assert.Equal(t, "vault-main", name)
+
+	_, err = RenderStatusConfigMapName("{{ .Ordinal }}", data)
+	assert.Error(t, err)
+}
+
+func getStatusConfigMap(t *testing.T, r *VaultUnsealerReconciler, name string) (*corev1.ConfigMap, error) {
+	configMap := &corev1.ConfigMap{}
+	err := r.Get(t.Context(), types.NamespacedName{Namespace: "vault", Name: name}, configMap)
+	return configMap, err
+}
+
+func TestReconcile_ExportsStatusConfigMap(t *testing.T) {
+	fake := vaultfake.NewServer(vaultfake.WithKeys([]string{"key-1"}, 1))
+	defer fake.Close()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": []byte("key-1\n")},
+	}
+	vu := newFinalizerTestUnsealer().WithVaultURL("http://vault")
+	vu.Spec.StatusConfigMap = &opsv1alpha1.StatusConfigMapSpec{}
+	r := newFakeReconciler(t, vu, newRolloutPod(fake, "vault-0", "v1"), secret)
+
+	got := reconcileAndGet(t, r)
+	assert.Equal(t, "main-unseal-status", got.Status.StatusConfigMap)
+	configMap, err := getStatusConfigMap(t, r, "main-unseal-status")
+	require.NoError(t, err)
+	assert.True(t, metav1.IsControlledBy(configMap, got))
+	assert.Equal(t, "true", configMap.Data[opsv1alpha1.StatusConfigMapAllPodsUnsealedKey])
+	assert.Equal(t, "1", configMap.Data[opsv1alpha1.StatusConfigMapPodsKey])
+	assert.Equal(t, "1", configMap.Data[opsv1alpha1.StatusConfigMapUnsealedPodsKey])
+	assert.Equal(t, "0", configMap.Data[opsv1alpha1.StatusConfigMapSealedPodsKey])
+	assert.NotEmpty(t, configMap.Data[opsv1alpha1.StatusConfigMapLastUnsealTimeKey])
+	var pods []podSummary
+	require.NoError(t, json.Unmarshal([]byte(configMap.Data[opsv1alpha1.StatusConfigMapPodsJSONKey]), &pods))
+	require.Len(t, pods, 1)
+	assert.Equal(t, podSummary{Name: "vault-0", State: opsv1alpha1.PodStateUnsealed, Role: opsv1alpha1.PodRoleActive}, pods[0])
+
+	// An unchanged status leaves the ConfigMap as it was.
+	reconcileAndGet(t, r)
+	again, err := getStatusConfigMap(t, r, "main-unseal-status")
+	require.NoError(t, err)
+	assert.Equal(t, configMap.ResourceVersion, again.ResourceVersion)
+
+	// Renaming the ConfigMap removes the previous one.
+	got.Spec.StatusConfigMap.NameTemplate = "{{ .Name }}-status"
+	require.NoError(t, r.Update(t.Context(), got))
+	got = reconcileAndGet(t, r)
+	assert.Equal(t, "main-status", got.Status.StatusConfigMap)
+	_, err = getStatusConfigMap(t, r, "main-status")
+	require.NoError(t, err)
+	_, err = getStatusConfigMap(t, r, "main-unseal-status")
+	assert.True(t, apierrors.IsNotFound(err))
+
+	// Turning the export off removes it too.
+	got.Spec.StatusConfigMap = nil
+	require.NoError(t, r.Update(t.Context(), got))
+	got = reconcileAndGet(t, r)
+	assert.Empty(t, got.Status.StatusConfigMap)
+	_, err = getStatusConfigMap(t, r, "main-status")
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestReconcile_StatusConfigMapNotOwned(t *testing.T) {
+	vu := newFinalizerTestUnsealer()
+	vu.Spec.StatusConfigMap = &opsv1alpha1.StatusConfigMapSpec{}
+	foreign := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "main-unseal-status", Namespace: "vault"},
+		Data:       map[string]string{"owner": "someone-else"},
+	}
+	r := newFakeReconciler(t, vu, foreign)
+
+	got := reconcileAndGet(t, r)
+	assert.Empty(t, got.Status.StatusConfigMap)
+	require.NotEmpty(t, got.Status.Warnings)
+	assert.Contains(t, got.Status.Warnings[len(got.Status.Warnings)-1].Message, "not owned by this VaultUnsealer")
+
+	configMap, err := getStatusConfigMap(t, r, "main-unseal-status")
+	require.NoError(t, err)
+	assert.Equal(t, foreign.Data, configMap.Data)
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/secrets"
+)
+
+// loadStatusToken reads the token sent with status checks. It is always read
+// from the VaultUnsealer's namespace, since it is sent to the configured
+// address.
+func (r *VaultUnsealerReconciler) loadStatusToken(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer, ref opsv1alpha1.SecretRef) (secrets.SecretString, error) {
+	if ref.Namespace != "" && ref.Namespace != vaultUnsealer.Namespace {
+		return secrets.SecretString{}, fmt.Errorf("token secret must be in namespace %s", vaultUnsealer.Namespace)
+	}
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: vaultUnsealer.Namespace, Name: ref.Name}, secret); err != nil {
+		return secrets.SecretString{}, fmt.Errorf("token secret %s/%s: %w", vaultUnsealer.Namespace, ref.Name, err)
+	}
+	token := strings.TrimSpace(string(secret.Data[ref.Key]))
+	if token == "" {
+		return secrets.SecretString{}, fmt.Errorf("key %s not found in token secret %s/%s", ref.Key, vaultUnsealer.Namespace, ref.Name)
+	}
+	return secrets.NewSecretString(token), nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+func TestCreateVaultClient_StatusToken(t *testing.T) {
+	var tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("X-Vault-Token"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"sealed":false,"t":1,"n":1,"progress":0}`))
+	}))
+	defer server.Close()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-0", Namespace: "vault"},
+		Status:     corev1.PodStatus{PodIP: strings.TrimPrefix(server.URL, "http://")},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-proxy", Namespace: "vault"},
+		Data:       map[string][]byte{"token": []byte("s.proxy\n")},
+	}
+	r := newFakeReconciler(t, secret)
+	vu := opsv1alpha1.NewVaultUnsealer("vault", "main").WithVaultURL("http://vault").WithTokenSecret("vault-proxy", "token")
+
+	vaultClient, err := r.createVaultClient(context.Background(), pod, vu)
+	require.NoError(t, err)
+	_, err = vaultClient.GetSealStatus(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"s.proxy"}, tokens)
+
+	vu.Spec.Vault.TokenSecretRef.Key = "missing"
+	_, err = r.createVaultClient(context.Background(), pod, vu)
+	assert.ErrorContains(t, err, "key missing not found")
+
+	vu.Spec.Vault.TokenSecretRef = &opsv1alpha1.SecretRef{Name: "vault-proxy", Namespace: "other", Key: "token"}
+	_, err = r.createVaultClient(context.Background(), pod, vu)
+	assert.ErrorContains(t, err, "must be in namespace vault")
+}
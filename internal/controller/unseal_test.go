@@ -0,0 +1,226 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/metrics"
+	"github.com/panteparak/vault-unsealer/internal/secrets"
+	"github.com/panteparak/vault-unsealer/internal/vault"
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+// newFakeVaultPod returns a pod whose IP resolves to the fake server, relying
+// on the reconciler substituting the pod IP for "vault" in the URL.
+func newFakeVaultPod(fake *vaultfake.Server) (*corev1.Pod, *opsv1alpha1.VaultUnsealer) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-0", Namespace: "vault"},
+		Status:     corev1.PodStatus{PodIP: strings.TrimPrefix(fake.URL(), "http://")},
+	}
+	vu := opsv1alpha1.NewVaultUnsealer("vault", "main").WithVaultURL("http://vault")
+	return pod, vu
+}
+
+func TestCheckAndUnsealPod_Unseals(t *testing.T) {
+	keys := []string{"key-1", "key-2", "key-3"}
+	fake := vaultfake.NewServer(vaultfake.WithKeys(keys, 2))
+	defer fake.Close()
+	pod, vu := newFakeVaultPod(fake)
+
+	r := &VaultUnsealerReconciler{}
+	result, err := r.checkAndUnsealPod(context.Background(), pod, vu, newKeys(keys[:2]...), nil)
+	require.NoError(t, err)
+	assert.False(t, result.sealed)
+	assert.True(t, result.unsealedNow)
+	assert.Equal(t, opsv1alpha1.PodRoleActive, result.role)
+	assert.False(t, fake.Sealed())
+	assert.Zero(t, testutil.ToFloat64(metrics.SealProgress.WithLabelValues("main", "vault", "vault-0")))
+
+	// An already unsealed pod gets no further keys.
+	fake.SetStandby(true)
+	result, err = r.checkAndUnsealPod(context.Background(), pod, vu, newKeys(keys[:2]...), nil)
+	require.NoError(t, err)
+	assert.False(t, result.unsealedNow)
+	assert.Equal(t, opsv1alpha1.PodRoleStandby, result.role)
+	assert.Equal(t, 2, fake.UnsealRequests())
+}
+
+func TestCheckAndUnsealPod_NotEnoughKeys(t *testing.T) {
+	keys := []string{"key-1", "key-2", "key-3"}
+	fake := vaultfake.NewServer(vaultfake.WithKeys(keys, 3))
+	defer fake.Close()
+	pod, vu := newFakeVaultPod(fake)
+
+	result, err := (&VaultUnsealerReconciler{}).checkAndUnsealPod(context.Background(), pod, vu, newKeys(keys[:2]...), nil)
+	require.NoError(t, err)
+	assert.True(t, result.sealed)
+	assert.True(t, fake.Sealed())
+	assert.InDelta(t, 2.0/3.0, testutil.ToFloat64(metrics.SealProgress.WithLabelValues("main", "vault", "vault-0")), 0.001)
+
+	vaultClient, err := vault.NewClient(fake.URL(), nil)
+	require.NoError(t, err)
+	status, err := vaultClient.GetSealStatus(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, status.Nonce)
+	assert.Equal(t, unsealRound{nonce: status.Nonce, progress: 2, threshold: 3}, result.round)
+}
+
+func TestReconcile_ReportsUnsealRound(t *testing.T) {
+	fake := vaultfake.NewServer(vaultfake.WithKeys([]string{"key-1", "key-2", "key-3"}, 3))
+	defer fake.Close()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": []byte("key-1\n")},
+	}
+	r := newFakeReconciler(t, newFinalizerTestUnsealer().WithVaultURL("http://vault"), newRolloutPod(fake, "vault-0", "v1"), secret)
+
+	got := reconcileAndGet(t, r)
+	require.Len(t, got.Status.Pods, 1)
+	assert.NotEmpty(t, got.Status.Pods[0].UnsealNonce)
+	assert.Equal(t, int32(1), got.Status.Pods[0].UnsealProgress)
+	assert.Equal(t, int32(3), got.Status.Pods[0].UnsealThreshold)
+
+	// Once the pod is unsealed, by hand here, no round is in progress.
+	vaultClient, err := vault.NewClient(fake.URL(), nil)
+	require.NoError(t, err)
+	for _, key := range []string{"key-2", "key-3"} {
+		_, err := vaultClient.Unseal(context.Background(), secrets.NewSecretString(key))
+		require.NoError(t, err)
+	}
+	got = reconcileAndGet(t, r)
+	assert.Equal(t, opsv1alpha1.PodStateUnsealed, got.Status.Pods[0].State)
+	assert.Empty(t, got.Status.Pods[0].UnsealNonce)
+	assert.Zero(t, got.Status.Pods[0].UnsealProgress)
+}
+
+func TestCheckAndUnsealPod_CountsVaultResponses(t *testing.T) {
+	keys := []string{"key-1", "key-2"}
+	fake := vaultfake.NewServer(vaultfake.WithKeys(keys, 2))
+	defer fake.Close()
+	pod, vu := newFakeVaultPod(fake)
+	vu.Name = "responses"
+
+	_, err := (&VaultUnsealerReconciler{}).checkAndUnsealPod(context.Background(), pod, vu, newKeys("key-1", "stale"), nil)
+	require.Error(t, err)
+	assert.Positive(t, testutil.ToFloat64(metrics.VaultResponses.WithLabelValues("responses", "vault", "sys/seal-status", "2xx")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.VaultResponses.WithLabelValues("responses", "vault", "sys/unseal", "4xx")))
+
+	(&VaultUnsealerReconciler{}).cleanupMetrics(vu)
+	assert.Zero(t, testutil.ToFloat64(metrics.VaultResponses.WithLabelValues("responses", "vault", "sys/unseal", "4xx")),
+		"series are removed with the VaultUnsealer")
+}
+
+func TestCheckAndUnsealPod_KeyAccounting(t *testing.T) {
+	keys := []string{"key-1", "key-2", "key-3"}
+	fake := vaultfake.NewServer(vaultfake.WithKeys(keys, 2))
+	defer fake.Close()
+	pod, vu := newFakeVaultPod(fake)
+	r := &VaultUnsealerReconciler{}
+
+	// A repeated share is accepted by Vault but does not advance progress.
+	result, err := r.checkAndUnsealPod(context.Background(), pod, vu, newKeys("key-1", "key-1", "key-2"), nil)
+	require.NoError(t, err)
+	assert.False(t, result.sealed)
+	require.Len(t, result.submissions, 3)
+	assert.Equal(t, keyResultAdvanced, result.submissions[0].result)
+	assert.Equal(t, keyResultNoProgress, result.submissions[1].result)
+	assert.Equal(t, keyResultAdvanced, result.submissions[2].result)
+	assert.Equal(t, 3, result.submissions[2].index)
+
+	// A share Vault does not recognise is recorded as rejected.
+	fake.Seal()
+	result, err = r.checkAndUnsealPod(context.Background(), pod, vu, newKeys("stale", "key-3"), nil)
+	require.Error(t, err)
+	require.Len(t, result.submissions, 1)
+	assert.Equal(t, keyResultRejected, result.submissions[0].result)
+	assert.Equal(t, fingerprint("stale"), result.submissions[0].fingerprint)
+}
+
+func TestCheckAndUnsealPod_SkipsQuarantinedKeys(t *testing.T) {
+	keys := []string{"key-1", "key-2", "key-3"}
+	fake := vaultfake.NewServer(vaultfake.WithKeys(keys, 2))
+	defer fake.Close()
+	pod, vu := newFakeVaultPod(fake)
+
+	quarantined := map[string]bool{fingerprint("stale"): true}
+	result, err := (&VaultUnsealerReconciler{}).checkAndUnsealPod(context.Background(), pod, vu, newKeys("stale", "key-1", "key-2"), quarantined)
+	require.NoError(t, err)
+	assert.False(t, result.sealed)
+	assert.Equal(t, 2, fake.UnsealRequests())
+	require.Len(t, result.submissions, 2)
+	assert.Equal(t, 2, result.submissions[0].index)
+}
+
+// unsealConcurrently runs checkAndUnsealPod with a key submission delay and,
+// once the controller has submitted its first share, lets someone else submit
+// others shares to the same pod.
+func unsealConcurrently(t *testing.T, fake *vaultfake.Server, keys []secrets.SecretString, others ...string) podUnsealResult {
+	pod, vu := newFakeVaultPod(fake)
+	vu.WithKeySubmissionDelay(500 * time.Millisecond)
+
+	done := make(chan podUnsealResult, 1)
+	go func() {
+		result, err := (&VaultUnsealerReconciler{}).checkAndUnsealPod(context.Background(), pod, vu, keys, nil)
+		assert.NoError(t, err)
+		done <- result
+	}()
+
+	require.Eventually(t, func() bool { return fake.UnsealRequests() == 1 }, time.Second, 10*time.Millisecond)
+	operator, err := vault.NewClient(fake.URL(), nil)
+	require.NoError(t, err)
+	for _, key := range others {
+		_, err := operator.Unseal(context.Background(), secrets.NewSecretString(key))
+		require.NoError(t, err)
+	}
+	return <-done
+}
+
+func TestCheckAndUnsealPod_StopsWhenUnsealedConcurrently(t *testing.T) {
+	keys := []string{"key-1", "key-2", "key-3"}
+	fake := vaultfake.NewServer(vaultfake.WithKeys(keys, 3))
+	defer fake.Close()
+
+	result := unsealConcurrently(t, fake, newKeys(keys...), "key-2", "key-3")
+	assert.False(t, result.sealed)
+	assert.False(t, result.unsealedNow)
+	assert.Len(t, result.submissions, 1)
+	assert.Equal(t, 3, fake.UnsealRequests())
+}
+
+func TestCheckAndUnsealPod_TracksConcurrentProgress(t *testing.T) {
+	keys := []string{"key-1", "key-2", "key-3"}
+	fake := vaultfake.NewServer(vaultfake.WithKeys(keys, 3))
+	defer fake.Close()
+
+	// The share someone else already provided no longer counts as progress.
+	result := unsealConcurrently(t, fake, newKeys(keys...), "key-2")
+	assert.True(t, result.unsealedNow)
+	require.Len(t, result.submissions, 3)
+	assert.Equal(t, keyResultNoProgress, result.submissions[1].result)
+	assert.Equal(t, keyResultAdvanced, result.submissions[2].result)
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/vault"
+)
+
+// unsealHistoryLimit is how many unseal attempts are kept per pod. It is
+// enough to spot a pod that keeps getting resealed without bloating status.
+const unsealHistoryLimit = 10
+
+// unsealAttemptResult classifies a checkAndUnsealPod call for the pod's
+// unseal history. It returns "" when no unseal was attempted because the pod
+// was already unsealed or no keys were submitted.
+func unsealAttemptResult(result podUnsealResult, err error) string {
+	// A rate limited attempt did not fail; it is Incomplete if it got
+	// some shares in before the quota ran out.
+	_, rateLimited := vault.IsRateLimited(err)
+	switch {
+	case err != nil && !rateLimited:
+		return opsv1alpha1.UnsealResultFailed
+	case result.unsealedNow:
+		return opsv1alpha1.UnsealResultUnsealed
+	case result.sealed && len(result.submissions) > 0:
+		return opsv1alpha1.UnsealResultIncomplete
+	default:
+		return ""
+	}
+}
+
+// appendUnsealEvent returns history with a new event appended, dropping the
+// oldest events beyond unsealHistoryLimit. history itself is not modified.
+func appendUnsealEvent(history []opsv1alpha1.UnsealEvent, result string, started time.Time, duration time.Duration) []opsv1alpha1.UnsealEvent {
+	if result == "" {
+		return history
+	}
+	if len(history) >= unsealHistoryLimit {
+		history = history[len(history)-unsealHistoryLimit+1:]
+	}
+	updated := make([]opsv1alpha1.UnsealEvent, 0, len(history)+1)
+	updated = append(updated, history...)
+	return append(updated, opsv1alpha1.UnsealEvent{
+		Time:     metav1.Time{Time: started},
+		Result:   result,
+		Duration: metav1.Duration{Duration: duration.Round(time.Millisecond)},
+	})
+}
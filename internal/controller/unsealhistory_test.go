@@ -0,0 +1,94 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/vault"
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+func TestAppendUnsealEvent_KeepsLatest(t *testing.T) {
+	start := time.Now()
+	var history []opsv1alpha1.UnsealEvent
+	for i := range unsealHistoryLimit + 3 {
+		history = appendUnsealEvent(history, opsv1alpha1.UnsealResultUnsealed, start.Add(time.Duration(i)*time.Minute), time.Second)
+	}
+	require.Len(t, history, unsealHistoryLimit)
+	assert.Equal(t, start.Add(3*time.Minute), history[0].Time.Time, "oldest events are dropped")
+	assert.Equal(t, start.Add(time.Duration(unsealHistoryLimit+2)*time.Minute), history[len(history)-1].Time.Time)
+
+	assert.Equal(t, history, appendUnsealEvent(history, "", start, time.Second), "passes without an attempt are not recorded")
+}
+
+func TestUnsealAttemptResult(t *testing.T) {
+	submitted := []keySubmission{{index: 1, result: keyResultAdvanced}}
+	assert.Equal(t, opsv1alpha1.UnsealResultFailed, unsealAttemptResult(podUnsealResult{}, fmt.Errorf("boom")))
+	assert.Equal(t, opsv1alpha1.UnsealResultUnsealed, unsealAttemptResult(podUnsealResult{unsealedNow: true, submissions: submitted}, nil))
+	assert.Equal(t, opsv1alpha1.UnsealResultIncomplete, unsealAttemptResult(podUnsealResult{sealed: true, submissions: submitted}, nil))
+	assert.Empty(t, unsealAttemptResult(podUnsealResult{}, nil), "already unsealed")
+
+	throttled := &vault.RateLimitError{RetryAfter: time.Second}
+	assert.Empty(t, unsealAttemptResult(podUnsealResult{sealed: true}, throttled), "throttled before any key")
+	assert.Equal(t, opsv1alpha1.UnsealResultIncomplete, unsealAttemptResult(podUnsealResult{sealed: true, submissions: submitted}, throttled))
+}
+
+func TestReconcile_RecordsUnsealHistory(t *testing.T) {
+	keys := []string{"key-1", "key-2"}
+	fake := vaultfake.NewServer(vaultfake.WithKeys(keys, 2))
+	defer fake.Close()
+
+	pod, _ := newFakeVaultPod(fake)
+	pod.Labels = map[string]string{"app": "vault"}
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	keysJSON, err := json.Marshal(keys)
+	require.NoError(t, err)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": keysJSON},
+	}
+	r := newFakeReconciler(t, newFinalizerTestUnsealer().WithVaultURL("http://vault"), pod, secret)
+
+	got := reconcileAndGet(t, r)
+	require.Len(t, got.Status.Pods, 1)
+	history := got.Status.Pods[0].UnsealHistory
+	require.Len(t, history, 1)
+	assert.Equal(t, opsv1alpha1.UnsealResultUnsealed, history[0].Result)
+
+	// Finding the pod unsealed again adds nothing.
+	got = reconcileAndGet(t, r)
+	assert.Len(t, got.Status.Pods[0].UnsealHistory, 1)
+
+	// A reseal shows up as a second unseal.
+	fake.Seal()
+	got = reconcileAndGet(t, r)
+	history = got.Status.Pods[0].UnsealHistory
+	require.Len(t, history, 2)
+	assert.Equal(t, opsv1alpha1.UnsealResultUnsealed, history[1].Result)
+	assert.False(t, history[1].Time.Before(&history[0].Time))
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// podNames returns the names of pods in order.
+func podNames(pods []corev1.Pod) []string {
+	names := make([]string, 0, len(pods))
+	for i := range pods {
+		names = append(names, pods[i].Name)
+	}
+	return names
+}
+
+// reportUnsealOrder raises an UnsealOrder Event describing the order an
+// ordering strategy put pods in, annotated with the role or revision each pod
+// was last seen with. Nothing is reported when the strategy kept the
+// discovered order or every pod was already unsealed, so steady-state
+// reconciles stay quiet.
+func (r *VaultUnsealerReconciler) reportUnsealOrder(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer, discovered []string, pods []corev1.Pod, ro *rollout) {
+	ordered := podNames(pods)
+	if slices.Equal(discovered, ordered) {
+		return
+	}
+
+	previous := make(map[string]opsv1alpha1.PodStatus, len(vaultUnsealer.Status.Pods))
+	for _, podStatus := range vaultUnsealer.Status.Pods {
+		previous[podStatus.Name] = podStatus
+	}
+	needsKeys := false
+	described := make([]string, 0, len(pods))
+	for i := range pods {
+		podStatus, known := previous[pods[i].Name]
+		if !known || podStatus.State != opsv1alpha1.PodStateUnsealed {
+			needsKeys = true
+		}
+		var notes []string
+		if ro != nil && podRevision(&pods[i]) == ro.updateRevision {
+			notes = append(notes, "new revision")
+		}
+		if podStatus.State == opsv1alpha1.PodStateUnsealed && podStatus.Role != "" {
+			notes = append(notes, strings.ToLower(podStatus.Role))
+		}
+		if len(notes) > 0 {
+			described = append(described, fmt.Sprintf("%s (%s)", pods[i].Name, strings.Join(notes, ", ")))
+		} else {
+			described = append(described, pods[i].Name)
+		}
+	}
+	if !needsKeys {
+		return
+	}
+
+	message := fmt.Sprintf("Unsealing %s first", described[0])
+	if len(described) > 1 {
+		message += ", then " + strings.Join(described[1:], ", ")
+	}
+	r.event(ctx, vaultUnsealer, corev1.EventTypeNormal, ReasonUnsealOrder, message)
+}
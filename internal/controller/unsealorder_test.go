@@ -0,0 +1,60 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+func TestReportUnsealOrder(t *testing.T) {
+	ctx := context.Background()
+	fake := vaultfake.NewServer()
+	defer fake.Close()
+	ro := &rollout{statefulSet: "vault", updateRevision: "v2", replicas: 3}
+	discovered := []string{"vault-0", "vault-1", "vault-2"}
+	pods := []corev1.Pod{*newRolloutPod(fake, "vault-2", "v2"), *newRolloutPod(fake, "vault-0", "v1"), *newRolloutPod(fake, "vault-1", "v1")}
+
+	vu := newFinalizerTestUnsealer()
+	vu.Status.Pods = []opsv1alpha1.PodStatus{
+		{Name: "vault-0", State: opsv1alpha1.PodStateUnsealed, Role: opsv1alpha1.PodRoleActive},
+		{Name: "vault-1", State: opsv1alpha1.PodStateUnsealed, Role: opsv1alpha1.PodRoleStandby},
+	}
+	recorder := record.NewFakeRecorder(4)
+	r := &VaultUnsealerReconciler{Recorder: recorder}
+
+	r.reportUnsealOrder(ctx, vu, discovered, pods, ro)
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "UnsealOrder Unsealing vault-2 (new revision) first, then vault-0 (active), vault-1 (standby)")
+
+	// Keeping the discovered order is not a decision worth reporting.
+	r.reportUnsealOrder(ctx, vu, discovered, []corev1.Pod{pods[1], pods[2], pods[0]}, ro)
+	assert.Empty(t, recorder.Events)
+
+	// Nor is an order in which no pod needs keys.
+	vu.Status.Pods = append(vu.Status.Pods, opsv1alpha1.PodStatus{Name: "vault-2", State: opsv1alpha1.PodStateUnsealed})
+	r.reportUnsealOrder(ctx, vu, discovered, pods, ro)
+	assert.Empty(t, recorder.Events)
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+const (
+	// defaultDiscoveredVaultURL relies on the reconciler substituting the pod IP for "vault".
+	defaultDiscoveredVaultURL = "http://vault:8200"
+	defaultDiscoveredKeysKey  = "keys.json"
+)
+
+// VaultDiscoveryReconciler provisions a VaultUnsealer for every Vault
+// StatefulSet labelled for discovery.
+type VaultDiscoveryReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Shard, when set, restricts discovery to the StatefulSets assigned to
+	// one shard, so only one replica provisions each VaultUnsealer.
+	Shard *Shard
+}
+
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch
+
+func (r *VaultDiscoveryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	var statefulSet appsv1.StatefulSet
+	if err := r.Get(ctx, req.NamespacedName, &statefulSet); err != nil {
+		// Owner references garbage-collect the VaultUnsealer on deletion.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !statefulSet.DeletionTimestamp.IsZero() || !discoveryEnabled(&statefulSet) || !ownedBy(r.Shard, &statefulSet) {
+		return ctrl.Result{}, nil
+	}
+
+	spec, err := discoveredSpec(&statefulSet)
+	if err != nil {
+		log.Error(err, "Invalid discovery annotations on StatefulSet")
+		return ctrl.Result{}, nil
+	}
+	return ctrl.Result{}, provisionDiscovered(ctx, r.Client, r.Scheme, &statefulSet, spec)
+}
+
+// provisionDiscovered creates or updates the VaultUnsealer generated for
+// statefulSet. A VaultUnsealer of the same name that discovery did not
+// create is left alone.
+func provisionDiscovered(ctx context.Context, c client.Client, scheme *runtime.Scheme, statefulSet *appsv1.StatefulSet, spec opsv1alpha1.VaultUnsealerSpec) error {
+	log := logf.FromContext(ctx)
+
+	vaultUnsealer := &opsv1alpha1.VaultUnsealer{
+		ObjectMeta: metav1.ObjectMeta{Name: statefulSet.Name, Namespace: statefulSet.Namespace},
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(vaultUnsealer), vaultUnsealer); err == nil {
+		if vaultUnsealer.Labels[opsv1alpha1.LabelDiscoveredFrom] != statefulSet.Name {
+			log.Info("VaultUnsealer already exists and is not managed by discovery, leaving it alone",
+				"vaultunsealer", vaultUnsealer.Name)
+			return nil
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	op, err := controllerutil.CreateOrUpdate(ctx, c, vaultUnsealer, func() error {
+		if vaultUnsealer.Labels == nil {
+			vaultUnsealer.Labels = map[string]string{}
+		}
+		vaultUnsealer.Labels[opsv1alpha1.LabelDiscoveredFrom] = statefulSet.Name
+		// Interval, the mode fields other than scope and, unless annotated,
+		// keyThreshold are left alone so users can tune them on the generated
+		// resource.
+		vaultUnsealer.Spec.Vault = spec.Vault
+		vaultUnsealer.Spec.UnsealKeysSecretRefs = spec.UnsealKeysSecretRefs
+		vaultUnsealer.Spec.VaultLabelSelector = spec.VaultLabelSelector
+		vaultUnsealer.Spec.Mode.Scope = spec.Mode.Scope
+		if statefulSet.Annotations[opsv1alpha1.AnnotationKeyThreshold] != "" {
+			vaultUnsealer.Spec.KeyThreshold = spec.KeyThreshold
+		}
+		return controllerutil.SetControllerReference(statefulSet, vaultUnsealer, scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to provision VaultUnsealer: %w", err)
+	}
+	if op != controllerutil.OperationResultNone {
+		log.Info("Provisioned VaultUnsealer from StatefulSet", "vaultunsealer", vaultUnsealer.Name, "operation", op)
+	}
+	return nil
+}
+
+// discoveryEnabled reports whether the StatefulSet opted into discovery.
+func discoveryEnabled(obj client.Object) bool {
+	return obj.GetLabels()[opsv1alpha1.LabelDiscover] == "true"
+}
+
+// discoveredSpec derives a VaultUnsealer spec from a Vault StatefulSet and its
+// discovery annotations.
+func discoveredSpec(statefulSet *appsv1.StatefulSet) (opsv1alpha1.VaultUnsealerSpec, error) {
+	annotations := statefulSet.Annotations
+
+	selector, err := metav1.LabelSelectorAsSelector(statefulSet.Spec.Selector)
+	if err != nil || selector.Empty() {
+		return opsv1alpha1.VaultUnsealerSpec{}, fmt.Errorf("StatefulSet %s has no usable pod selector", statefulSet.Name)
+	}
+
+	secretRef := opsv1alpha1.SecretRef{Name: statefulSet.Name + "-unseal-keys", Key: defaultDiscoveredKeysKey}
+	if value := annotations[opsv1alpha1.AnnotationUnsealKeysSecret]; value != "" {
+		name, key, found := strings.Cut(value, "/")
+		secretRef.Name = name
+		if found {
+			secretRef.Key = key
+		}
+	}
+
+	vaultURL := defaultDiscoveredVaultURL
+	if value := annotations[opsv1alpha1.AnnotationVaultURL]; value != "" {
+		vaultURL = value
+	}
+
+	keyThreshold := 0
+	if value := annotations[opsv1alpha1.AnnotationKeyThreshold]; value != "" {
+		keyThreshold, err = strconv.Atoi(value)
+		if err != nil {
+			return opsv1alpha1.VaultUnsealerSpec{}, fmt.Errorf("invalid %s annotation %q: %w", opsv1alpha1.AnnotationKeyThreshold, value, err)
+		}
+	}
+
+	scope := opsv1alpha1.ReplicaScopeSingle
+	if statefulSet.Spec.Replicas != nil && *statefulSet.Spec.Replicas > 1 {
+		scope = opsv1alpha1.ReplicaScopeCluster
+	}
+
+	return opsv1alpha1.VaultUnsealerSpec{
+		Vault:                opsv1alpha1.VaultConnectionSpec{Address: vaultURL},
+		UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{secretRef},
+		VaultLabelSelector:   selector.String(),
+		Mode:                 opsv1alpha1.ModeSpec{Scope: scope},
+		KeyThreshold:         keyThreshold,
+	}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *VaultDiscoveryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&appsv1.StatefulSet{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return discoveryEnabled(obj) && ownedBy(r.Shard, obj)
+		}))).
+		Owns(&opsv1alpha1.VaultUnsealer{}).
+		Named("vaultdiscovery").
+		Complete(r)
+}
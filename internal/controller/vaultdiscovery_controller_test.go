@@ -0,0 +1,153 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+func newDiscoveryReconciler(t *testing.T, objs ...client.Object) *VaultDiscoveryReconciler {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, opsv1alpha1.AddToScheme(scheme))
+	return &VaultDiscoveryReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build(),
+		Scheme: scheme,
+	}
+}
+
+func newVaultStatefulSet(annotations map[string]string) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "vault",
+			Namespace:   "vault",
+			UID:         "sts-uid",
+			Labels:      map[string]string{opsv1alpha1.LabelDiscover: "true"},
+			Annotations: annotations,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: ptr.To(int32(3)),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/name": "vault"}},
+		},
+	}
+}
+
+func reconcileDiscovery(t *testing.T, r *VaultDiscoveryReconciler) *opsv1alpha1.VaultUnsealer {
+	key := types.NamespacedName{Namespace: "vault", Name: "vault"}
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+
+	vu := &opsv1alpha1.VaultUnsealer{}
+	require.NoError(t, r.Get(context.Background(), key, vu))
+	return vu
+}
+
+func TestVaultDiscovery_ProvisionsDefaults(t *testing.T) {
+	r := newDiscoveryReconciler(t, newVaultStatefulSet(nil))
+	vu := reconcileDiscovery(t, r)
+
+	assert.Equal(t, "vault", vu.Labels[opsv1alpha1.LabelDiscoveredFrom])
+	assert.Equal(t, defaultDiscoveredVaultURL, vu.Spec.Vault.Address)
+	assert.Equal(t, []opsv1alpha1.SecretRef{{Name: "vault-unseal-keys", Key: "keys.json"}}, vu.Spec.UnsealKeysSecretRefs)
+	assert.Equal(t, "app.kubernetes.io/name=vault", vu.Spec.VaultLabelSelector)
+	assert.Equal(t, opsv1alpha1.ReplicaScopeCluster, vu.Spec.Mode.Scope)
+	require.Len(t, vu.OwnerReferences, 1)
+	assert.Equal(t, "StatefulSet", vu.OwnerReferences[0].Kind)
+}
+
+func TestVaultDiscovery_Annotations(t *testing.T) {
+	r := newDiscoveryReconciler(t, newVaultStatefulSet(map[string]string{
+		opsv1alpha1.AnnotationUnsealKeysSecret: "keys/unseal.json",
+		opsv1alpha1.AnnotationVaultURL:         "https://vault:8200",
+		opsv1alpha1.AnnotationKeyThreshold:     "3",
+	}))
+	vu := reconcileDiscovery(t, r)
+
+	assert.Equal(t, "https://vault:8200", vu.Spec.Vault.Address)
+	assert.Equal(t, []opsv1alpha1.SecretRef{{Name: "keys", Key: "unseal.json"}}, vu.Spec.UnsealKeysSecretRefs)
+	assert.Equal(t, 3, vu.Spec.KeyThreshold)
+}
+
+func TestVaultDiscovery_KeepsUserTunedFields(t *testing.T) {
+	r := newDiscoveryReconciler(t, newVaultStatefulSet(nil))
+	vu := reconcileDiscovery(t, r)
+
+	vu.Spec.Mode.MonitorOnly = true
+	vu.Spec.Mode.StopAfterFirstUnseal = ptr.To(true)
+	vu.Spec.KeyThreshold = 2
+	require.NoError(t, r.Update(context.Background(), vu))
+	vu = reconcileDiscovery(t, r)
+
+	assert.True(t, vu.Spec.Mode.MonitorOnly)
+	assert.Equal(t, ptr.To(true), vu.Spec.Mode.StopAfterFirstUnseal)
+	assert.Equal(t, opsv1alpha1.ReplicaScopeCluster, vu.Spec.Mode.Scope)
+	assert.Equal(t, 2, vu.Spec.KeyThreshold)
+
+	statefulSet := &appsv1.StatefulSet{}
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{Namespace: "vault", Name: "vault"}, statefulSet))
+	statefulSet.Annotations = map[string]string{opsv1alpha1.AnnotationKeyThreshold: "3"}
+	require.NoError(t, r.Update(context.Background(), statefulSet))
+	vu = reconcileDiscovery(t, r)
+	assert.Equal(t, 3, vu.Spec.KeyThreshold, "the annotation still wins")
+}
+
+func TestVaultDiscovery_OnlyOwningShardProvisions(t *testing.T) {
+	statefulSet := newVaultStatefulSet(nil)
+	owner := ShardFor(statefulSet, 3)
+	key := types.NamespacedName{Namespace: "vault", Name: "vault"}
+
+	for id := range 3 {
+		r := newDiscoveryReconciler(t, statefulSet.DeepCopy())
+		r.Shard = &Shard{ID: id, Count: 3}
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+		require.NoError(t, err)
+		err = r.Get(context.Background(), key, &opsv1alpha1.VaultUnsealer{})
+		if id == owner {
+			assert.NoError(t, err, "shard %d owns the StatefulSet", id)
+		} else {
+			assert.True(t, apierrors.IsNotFound(err), "shard %d does not own the StatefulSet", id)
+		}
+	}
+}
+
+func TestVaultDiscovery_LeavesUnmanagedResources(t *testing.T) {
+	existing := &opsv1alpha1.VaultUnsealer{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault", Namespace: "vault"},
+		Spec:       opsv1alpha1.VaultUnsealerSpec{Vault: opsv1alpha1.VaultConnectionSpec{URL: "https://custom:8200"}},
+	}
+	r := newDiscoveryReconciler(t, newVaultStatefulSet(nil), existing)
+	vu := reconcileDiscovery(t, r)
+
+	assert.Equal(t, "https://custom:8200", vu.Spec.Vault.Endpoint())
+	assert.Empty(t, vu.Labels[opsv1alpha1.LabelDiscoveredFrom])
+}
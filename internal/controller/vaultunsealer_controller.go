@@ -18,24 +18,34 @@ package controller
 
 import (
 	"context"
-	"crypto/rand"
 	"crypto/tls"
-	"crypto/x509"
-	"encoding/hex"
+	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
 	"github.com/panteparak/vault-unsealer/internal/logging"
@@ -49,13 +59,95 @@ type VaultUnsealerReconciler struct {
 	client.Client
 	Scheme        *runtime.Scheme
 	SecretsLoader *secrets.Loader
+
+	// SpecValidator, when set, validates each VaultUnsealer before it is
+	// reconciled. It is used when the admission webhook is disabled so that
+	// invalid resources surface as an InvalidSpec condition instead.
+	SpecValidator func(ctx context.Context, vu *opsv1alpha1.VaultUnsealer) error
+
+	// SpecWarnings, when set, returns the admission warnings for a
+	// VaultUnsealer so they are kept in status.warnings instead of only being
+	// shown to whoever applied the resource.
+	SpecWarnings func(ctx context.Context, vu *opsv1alpha1.VaultUnsealer) []string
+
+	// Shard, when set, restricts this reconciler to the VaultUnsealers
+	// assigned to one shard so several replicas can split the work.
+	Shard *Shard
+
+	// GlobalPause, when engaged, stops key submission for every
+	// VaultUnsealer. Unlike the pause annotation it is not overridden by
+	// break-glass.
+	GlobalPause *GlobalPause
+
+	// Recorder, when set, publishes Kubernetes Events for the VaultUnsealer.
+	Recorder record.EventRecorder
+
+	// MinInterval and MaxInterval bound spec.interval. Zero values use the
+	// API defaults.
+	MinInterval time.Duration
+	MaxInterval time.Duration
+
+	// EnableFinalizer adds a finalizer so metrics are cleaned up even for
+	// deletions the operator misses while it is down. It is off by default
+	// because the finalizer blocks namespace deletion whenever the operator
+	// is not running; metrics are otherwise cleaned up from delete events.
+	EnableFinalizer bool
+
+	// MaxConcurrentReconciles is how many VaultUnsealers are reconciled in
+	// parallel. Defaults to 1.
+	MaxConcurrentReconciles int
+	// StartupConcurrency, when larger than MaxConcurrentReconciles, is how
+	// many VaultUnsealers are reconciled in parallel for StartupBurstDuration
+	// after the controller starts, so Vault comes back quickly after a
+	// cluster-wide restart.
+	StartupConcurrency   int
+	StartupBurstDuration time.Duration
+
+	// ReconcileTimeout, when positive, is how long a reconcile may run
+	// before a watchdog cancels it, records ReconcileTimeout=True and frees
+	// its worker, so one wedged endpoint cannot hold a worker forever.
+	ReconcileTimeout time.Duration
+
+	// Version is the operator version recorded in status.operatorVersion.
+	Version string
+
+	// APIServerProxy, when set, reaches the pods of VaultUnsealers with
+	// spec.vault.apiServerProxy through the apiserver's pod proxy.
+	APIServerProxy *APIServerProxy
+
+	// APIReader, when set, reads status ConfigMaps directly from the
+	// apiserver instead of through the Client's cache.
+	APIReader client.Reader
+
+	limiter  *reconcileLimiter
+	podLocks podLocks
+	specs    specHistory
+	watchdog reconcileWatchdog
+	// wakeups carries reconcile requests that no Kubernetes watch sees.
+	wakeups chan event.GenericEvent
 }
 
 const (
-	ConditionTypeReady           = "Ready"
-	ConditionTypeKeysMissing     = "KeysMissing"
-	ConditionTypeVaultAPIFailure = "VaultAPIFailure"
-	ConditionTypePodUnavailable  = "PodUnavailable"
+	ConditionTypeReady             = "Ready"
+	ConditionTypeReconciling       = "Reconciling"
+	ConditionTypeTargetsDiscovered = "TargetsDiscovered"
+	ConditionTypeKeysLoaded        = "KeysLoaded"
+	ConditionTypeVaultAPIFailure   = "VaultAPIFailure"
+	ConditionTypePaused            = "Paused"
+	ConditionTypeInvalidSpec       = "InvalidSpec"
+	ConditionTypeMaintenance       = "InMaintenanceWindow"
+	ConditionTypeCABundleInvalid   = "CABundleInvalid"
+	ConditionTypeCAExpiring        = "CAExpiringSoon"
+	ConditionTypeSealMigration     = "SealMigrationInProgress"
+	ConditionTypeProgressing       = "Progressing"
+	ConditionTypeCanary            = "CanaryInProgress"
+	ConditionTypeReconcileTimeout  = "ReconcileTimeout"
+	ConditionTypeVaultRateLimited  = "VaultRateLimited"
+
+	// Deprecated: replaced by KeysLoaded=False and TargetsDiscovered=False.
+	// They are no longer set and are removed from existing resources.
+	ConditionTypeKeysMissing    = "KeysMissing"
+	ConditionTypePodUnavailable = "PodUnavailable"
 
 	ConditionStatusTrue    = "True"
 	ConditionStatusFalse   = "False"
@@ -67,6 +159,50 @@ const (
 	ReasonPodNotReady      = "PodNotReady"
 	ReasonUnsealSuccess    = "UnsealSuccess"
 	ReasonUnsealFailed     = "UnsealFailed"
+	ReasonPausedByUser     = "PausedByAnnotation"
+	ReasonPausedGlobally   = "PausedGlobally"
+	ReasonValidationFailed = "ValidationFailed"
+
+	// Reasons for Reconciling=True, naming the phase the reconcile stopped in.
+	ReasonPhaseDiscovery = "DiscoveringTargets"
+	ReasonPhaseKeys      = "LoadingKeys"
+	ReasonPhaseUnseal    = "Unsealing"
+
+	ReasonPodsDiscovered     = "PodsDiscovered"
+	ReasonPodDiscoveryFailed = "PodDiscoveryFailed"
+	ReasonNoPodsFound        = "NoPodsFound"
+	ReasonKeysLoaded         = "KeysLoaded"
+	ReasonCustodianQuorum    = "CustodianQuorumNotMet"
+
+	ReasonReadinessPolicyUnmet  = "ReadinessPolicyUnmet"
+	ReasonKeyQuarantined        = "UnsealKeyQuarantined"
+	ReasonIntervalClamped       = "IntervalClamped"
+	ReasonVaultSealed           = "VaultSealed"
+	ReasonMaintenanceWindow     = "MaintenanceWindow"
+	ReasonBreakGlassGranted     = "BreakGlassGranted"
+	ReasonBreakGlassEnded       = "BreakGlassEnded"
+	ReasonBreakGlassRejected    = "BreakGlassRejected"
+	ReasonSecretAccessDenied    = "SecretAccessDenied"
+	ReasonTLSVerificationFailed = "TLSVerificationFailed"
+	ReasonNotAVaultEndpoint     = "NotAVaultEndpoint"
+	ReasonCABundleInvalid       = "CABundleInvalid"
+	ReasonCAExpiring            = "CAExpiring"
+	ReasonCAExpired             = "CAExpired"
+	ReasonReconcileAborted      = "ReconcileAborted"
+	ReasonSealMigrationPaused   = "SealMigrationPaused"
+	ReasonSealMigrationUnseal   = "SealMigrationUnsealing"
+	ReasonUnsealingPods         = "UnsealingPods"
+	ReasonInvalidKeysSkipped    = "InvalidUnsealKeysSkipped"
+	ReasonCanarySoaking         = "CanarySoaking"
+	ReasonKeySecretChanged      = "KeySecretChanged"
+	ReasonUnsealOrder           = "UnsealOrder"
+	ReasonReconcileBudget       = "ReconcileBudgetExceeded"
+	ReasonSpecChanged           = "SpecChanged"
+	ReasonReconcileTimedOut     = "ReconcileTimedOut"
+	ReasonMonitorOnly           = "MonitorOnly"
+	ReasonRateLimitQuota        = "RateLimitQuotaExceeded"
+	ReasonPodReconcileRequested = "PodReconcileRequested"
+	ReasonSealConfigChanged     = "SealConfigChanged"
 
 	// Finalizer for cleanup
 	VaultUnsealerFinalizer = "autounseal.vault.io/finalizer"
@@ -78,14 +214,35 @@ const (
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch
+// Cross-namespace Secret access is in internal/rbac/crossnamespace.
 
 func (r *VaultUnsealerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if r.limiter != nil {
+		r.limiter.acquire()
+		defer r.limiter.release()
+	}
+
+	if r.ReconcileTimeout <= 0 {
+		return r.reconcile(ctx, req)
+	}
+	result, err := r.watchdog.run(ctx, req.NamespacedName, r.ReconcileTimeout, func(ctx context.Context) (ctrl.Result, error) {
+		return r.reconcile(ctx, req)
+	})
+	if errors.Is(err, errReconcileTimedOut) {
+		r.recordReconcileTimeout(ctx, req.NamespacedName)
+	}
+	return result, err
+}
+
+func (r *VaultUnsealerReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
 	var vaultUnsealer opsv1alpha1.VaultUnsealer
 	if err := r.Get(ctx, req.NamespacedName, &vaultUnsealer); err != nil {
 		if apierrors.IsNotFound(err) {
 			log.Info("VaultUnsealer resource not found. Ignoring since object must be deleted")
+			r.specs.forget(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		log.Error(err, "Failed to get VaultUnsealer")
@@ -96,10 +253,20 @@ func (r *VaultUnsealerReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		r.SecretsLoader = secrets.NewLoader(r.Client)
 	}
 
+	// Drop a finalizer left behind from when it was enabled, so it cannot
+	// block deletion while the operator is down.
+	if !r.EnableFinalizer && controllerutil.ContainsFinalizer(&vaultUnsealer, VaultUnsealerFinalizer) {
+		log.Info("Removing finalizer since finalizers are disabled")
+		controllerutil.RemoveFinalizer(&vaultUnsealer, VaultUnsealerFinalizer)
+		if err := r.Update(ctx, &vaultUnsealer); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Handle deletion
 	if vaultUnsealer.DeletionTimestamp.IsZero() {
 		// The object is not being deleted, ensure finalizer is present
-		if !controllerutil.ContainsFinalizer(&vaultUnsealer, VaultUnsealerFinalizer) {
+		if r.EnableFinalizer && !controllerutil.ContainsFinalizer(&vaultUnsealer, VaultUnsealerFinalizer) {
 			controllerutil.AddFinalizer(&vaultUnsealer, VaultUnsealerFinalizer)
 			return ctrl.Result{}, r.Update(ctx, &vaultUnsealer)
 		}
@@ -123,15 +290,19 @@ func (r *VaultUnsealerReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	return r.reconcileVaultUnsealer(ctx, &vaultUnsealer)
 }
 
-func (r *VaultUnsealerReconciler) reconcileVaultUnsealer(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) (ctrl.Result, error) {
+func (r *VaultUnsealerReconciler) reconcileVaultUnsealer(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) (_ ctrl.Result, reconcileErr error) {
 	// Generate unique reconciliation ID for tracking
 	reconcileID, _ := generateReconcileID()
+	ctx = withReconcileID(ctx, reconcileID)
 
 	// Create structured logger with VaultUnsealer context
 	log := logging.WithVaultUnsealer(logf.FromContext(ctx), vaultUnsealer)
 	log = logging.WithReconciliation(log, reconcileID)
+	// The phases log through the context so their entries carry these fields.
+	ctx = logf.IntoContext(ctx, log)
 
 	log.Info("Starting reconciliation")
+	r.reportSpecChanges(ctx, vaultUnsealer)
 
 	// Record reconciliation metrics
 	startTime := time.Now()
@@ -142,127 +313,275 @@ func (r *VaultUnsealerReconciler) reconcileVaultUnsealer(ctx context.Context, va
 		log.Info("Reconciliation completed", "duration", duration.String())
 	}()
 
-	defaultInterval := 60 * time.Second
-	if vaultUnsealer.Spec.Interval != nil {
-		defaultInterval = vaultUnsealer.Spec.Interval.Duration
+	defaultInterval, clamped := r.reconcileInterval(vaultUnsealer)
+	if clamped {
+		log.Info("Interval outside the allowed range, clamping", "interval", vaultUnsealer.Spec.Interval.Duration, "effectiveInterval", defaultInterval)
+		r.event(ctx, vaultUnsealer, corev1.EventTypeWarning, ReasonIntervalClamped,
+			fmt.Sprintf("Interval %s is outside the allowed range, using %s", vaultUnsealer.Spec.Interval.Duration, defaultInterval))
 	}
+	fastInterval := r.fastInterval(vaultUnsealer, defaultInterval)
+
+	// The status update below deliberately uses the parent context so the
+	// outcome is still recorded when the budget runs out.
+	budgetCtx, cancel := withReconcileBudget(ctx, defaultInterval)
+	defer cancel()
+	budgetCtx = withResolver(budgetCtx, vaultUnsealer)
 
+	// Status is written once, after all mutations, and only if it changed.
+	// Unseal progress reports patch conditions alone and update original.
+	original := vaultUnsealer.Status.DeepCopy()
 	vaultUnsealer.Status.LastReconcileTime = &metav1.Time{Time: time.Now()}
-	vaultUnsealer.Status.PodsChecked = []string{}
-	vaultUnsealer.Status.UnsealedPods = []string{}
+	vaultUnsealer.Status.EffectiveConfig = effectiveConfig(vaultUnsealer, defaultInterval, fastInterval, previousKeyThreshold(vaultUnsealer, original))
+	// A reconcile the watchdog cancels never writes status, so reaching
+	// the write below means this one completed.
+	r.clearCondition(vaultUnsealer, ConditionTypeReconcileTimeout)
+	if r.Version != "" {
+		vaultUnsealer.Status.OperatorVersion = r.Version
+	}
+	ranPhases := false
+	defer func() {
+		if ranPhases {
+			recordReconcileFailure(&vaultUnsealer.Status, reconcileFailure(vaultUnsealer, reconcileErr))
+		}
+		r.exportStatus(ctx, vaultUnsealer)
+		if statusUnchanged(original, &vaultUnsealer.Status) {
+			log.V(1).Info("Status unchanged, skipping update")
+			return
+		}
+		if err := r.updateStatus(ctx, vaultUnsealer); err != nil {
+			log.Error(err, "Failed to update status")
+			metrics.ReconciliationErrors.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, "status_update").Inc()
+			if reconcileErr == nil {
+				reconcileErr = err
+			}
+		}
+	}()
 
-	pods, err := r.getVaultPods(ctx, vaultUnsealer)
-	if err != nil {
-		log.Error(err, "Failed to get Vault pods")
-		metrics.ReconciliationErrors.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, "pod_discovery").Inc()
-		r.setCondition(vaultUnsealer, ConditionTypePodUnavailable, ConditionStatusTrue, ReasonPodNotReady, err.Error())
-		if updateErr := r.updateStatus(ctx, vaultUnsealer); updateErr != nil {
-			log.Error(updateErr, "Failed to update status after pod discovery error")
+	r.refreshWarnings(ctx, vaultUnsealer)
+
+	if err := r.validateSpec(ctx, vaultUnsealer); err != nil {
+		log.Info("VaultUnsealer spec is invalid, skipping reconciliation", "reason", err.Error())
+		if findCondition(vaultUnsealer, ConditionTypeInvalidSpec) == nil {
+			r.event(ctx, vaultUnsealer, corev1.EventTypeWarning, ReasonValidationFailed, err.Error())
 		}
-		return ctrl.Result{RequeueAfter: defaultInterval}, err
+		r.setCondition(vaultUnsealer, ConditionTypeInvalidSpec, ConditionStatusTrue, ReasonValidationFailed, err.Error())
+		r.setCondition(vaultUnsealer, ConditionTypeReady, ConditionStatusFalse, ReasonValidationFailed, "Spec failed validation")
+		r.setCondition(vaultUnsealer, ConditionTypeReconciling, ConditionStatusFalse, ReasonValidationFailed, "Spec failed validation")
+		// A spec change triggers a new reconcile, so there is nothing to retry.
+		return ctrl.Result{}, nil
 	}
+	r.clearCondition(vaultUnsealer, ConditionTypeInvalidSpec)
 
-	if len(pods) == 0 {
-		log.Info("No Vault pods found matching label selector", "labelSelector", vaultUnsealer.Spec.VaultLabelSelector)
-		r.setCondition(vaultUnsealer, ConditionTypePodUnavailable, ConditionStatusTrue, ReasonPodNotReady, "No pods found")
-		if updateErr := r.updateStatus(ctx, vaultUnsealer); updateErr != nil {
-			log.Error(updateErr, "Failed to update status after no pods found")
-		}
+	r.reconcileCABundle(budgetCtx, vaultUnsealer, time.Now())
+
+	breakGlassExpiry, breakGlass := r.reconcileBreakGlass(ctx, vaultUnsealer, time.Now())
+	if breakGlass {
+		log.Info("Break-glass override in effect, ignoring pause and maintenance windows", "until", breakGlassExpiry)
+		// Reconcile again when the override expires so it ends on time.
+		defaultInterval = min(defaultInterval, max(time.Until(breakGlassExpiry), time.Second))
+	}
+
+	if r.GlobalPause.Engaged() {
+		log.Info("Global pause engaged, skipping key submission")
+		r.setCondition(vaultUnsealer, ConditionTypePaused, ConditionStatusTrue, ReasonPausedGlobally,
+			"Unsealing paused operator-wide by the global kill switch")
+		r.setCondition(vaultUnsealer, ConditionTypeReconciling, ConditionStatusFalse, ReasonPausedGlobally, "Unsealing is paused")
 		return ctrl.Result{RequeueAfter: defaultInterval}, nil
 	}
 
-	unsealKeys, err := r.SecretsLoader.LoadUnsealKeys(ctx, vaultUnsealer.Namespace, vaultUnsealer.Spec.UnsealKeysSecretRefs, vaultUnsealer.Spec.KeyThreshold)
-	if err != nil {
-		log.Error(err, "Failed to load unseal keys")
-		metrics.ReconciliationErrors.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, "keys_loading").Inc()
-		r.setCondition(vaultUnsealer, ConditionTypeKeysMissing, ConditionStatusTrue, ReasonKeysMissing, err.Error())
-		if updateErr := r.updateStatus(ctx, vaultUnsealer); updateErr != nil {
-			log.Error(updateErr, "Failed to update status after key loading error")
-		}
-		return ctrl.Result{RequeueAfter: defaultInterval}, err
+	if isPaused(vaultUnsealer) && !breakGlass {
+		log.Info("VaultUnsealer is paused, skipping key submission")
+		r.setCondition(vaultUnsealer, ConditionTypePaused, ConditionStatusTrue, ReasonPausedByUser,
+			fmt.Sprintf("Unsealing paused via %s annotation", opsv1alpha1.AnnotationPaused))
+		r.setCondition(vaultUnsealer, ConditionTypeReconciling, ConditionStatusFalse, ReasonPausedByUser, "Unsealing is paused")
+		return ctrl.Result{RequeueAfter: defaultInterval}, nil
 	}
+	r.clearCondition(vaultUnsealer, ConditionTypePaused)
 
-	log.Info("Loaded unseal keys", "keyCount", len(unsealKeys))
-	metrics.UnsealKeysLoaded.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace).Set(float64(len(unsealKeys)))
+	blocked, message, windowErrs := maintenanceBlocked(vaultUnsealer.Spec.MaintenanceWindows, time.Now())
+	for _, err := range windowErrs {
+		log.Error(err, "Ignoring invalid maintenance window")
+	}
+	if blocked && !breakGlass {
+		log.Info("In maintenance window, skipping key submission", "reason", message)
+		r.setCondition(vaultUnsealer, ConditionTypeMaintenance, ConditionStatusTrue, ReasonMaintenanceWindow, message)
+		r.setCondition(vaultUnsealer, ConditionTypeReconciling, ConditionStatusFalse, ReasonMaintenanceWindow, "Unsealing is blocked by a maintenance window")
+		return ctrl.Result{RequeueAfter: min(defaultInterval, maintenanceRecheckInterval)}, nil
+	}
+	r.clearCondition(vaultUnsealer, ConditionTypeMaintenance)
 
-	unsealedCount := 0
-	for _, pod := range pods {
-		vaultUnsealer.Status.PodsChecked = append(vaultUnsealer.Status.PodsChecked, pod.Name)
+	vaultUnsealer.Status.PodsChecked = []string{}
+	vaultUnsealer.Status.UnsealedPods = []string{}
 
-		if !r.isPodReady(&pod) {
-			log.Info("Pod is not ready, skipping", "pod", pod.Name)
-			continue
-		}
+	ranPhases = true
+	pods, result, done := r.discoverTargets(budgetCtx, vaultUnsealer, original, min(defaultInterval, fastInterval))
+	if done {
+		return result, nil
+	}
 
-		sealed, err := r.checkAndUnsealPod(ctx, &pod, vaultUnsealer, unsealKeys)
-		if err != nil {
-			log.Error(err, "Failed to check/unseal pod", "pod", pod.Name)
-			metrics.UnsealAttempts.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, pod.Name, "failed").Inc()
-			metrics.VaultConnectionStatus.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, pod.Name).Set(0)
-			continue
+	monitorOnly := vaultUnsealer.Spec.Mode.MonitorOnly
+	var unsealKeys []secrets.SecretString
+	if monitorOnly {
+		r.skipKeys(vaultUnsealer)
+	} else {
+		var err error
+		if unsealKeys, err = r.loadKeys(budgetCtx, vaultUnsealer); err != nil {
+			return ctrl.Result{RequeueAfter: defaultInterval}, err
 		}
+	}
 
-		if !sealed {
-			vaultUnsealer.Status.UnsealedPods = append(vaultUnsealer.Status.UnsealedPods, pod.Name)
-			unsealedCount++
-			metrics.UnsealAttempts.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, pod.Name, "success").Inc()
-			metrics.VaultConnectionStatus.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, pod.Name).Set(1)
-
-			if !vaultUnsealer.Spec.Mode.HA {
-				log.Info("HA mode disabled, stopping after first successful unseal", "pod", pod.Name)
-				break
-			}
-		} else {
-			metrics.VaultConnectionStatus.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, pod.Name).Set(1)
-		}
+	rollout := r.observeRollout(budgetCtx, vaultUnsealer, pods)
+	if rollout != nil {
+		discovered := podNames(pods)
+		rollout.prioritize(pods)
+		r.reportUnsealOrder(ctx, vaultUnsealer, discovered, pods, rollout)
+	}
+	deferredFirst(pods, original.DeferredPods)
+	requested := requestedPods(pods, original.Pods)
+	if len(requested) > 0 {
+		names := requestedPodNames(requested)
+		log.Info("Reconciling requested pods only", "pods", names)
+		r.event(ctx, vaultUnsealer, corev1.EventTypeNormal, ReasonPodReconcileRequested,
+			fmt.Sprintf("Reconciling pods %s on request, other pods are left untouched", strings.Join(names, ", ")))
+	}
+	progress := &unsealProgress{vaultUnsealer: vaultUnsealer, persisted: original, total: len(pods)}
+	outcome := r.unsealTargets(budgetCtx, vaultUnsealer, pods, unsealKeys, progress, reconcileBudgetDeadline(vaultUnsealer, startTime), requested)
+	vaultUnsealer.Status.DeferredPods = outcome.deferred
+	if outcome.unsealedNow {
+		recordUnsealedKeySecrets(vaultUnsealer, time.Now())
+	}
+	r.clearCondition(vaultUnsealer, ConditionTypeProgressing)
+	podStatuses, unsealedCount := outcome.podStatuses, outcome.unsealedCount
+	vaultUnsealer.Status.Pods = podStatuses
+	r.reconcileReadinessGates(ctx, vaultUnsealer, pods)
+	if progress := rollout.progress(podStatuses); progress != vaultUnsealer.Status.Rollout {
+		log.Info("StatefulSet rollout progress changed", "progress", progress)
+		vaultUnsealer.Status.Rollout = progress
+	}
+	if errors.Is(budgetCtx.Err(), context.DeadlineExceeded) {
+		log.Info("Reconcile budget exhausted while checking pods", "budget", max(defaultInterval, minReconcileBudget))
+		metrics.ReconciliationErrors.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, "deadline_exceeded").Inc()
 	}
 
 	// Update pod metrics
 	metrics.PodsChecked.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace).Set(float64(len(vaultUnsealer.Status.PodsChecked)))
 	metrics.PodsUnsealed.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace).Set(float64(unsealedCount))
+	r.setAllPodsUnsealed(vaultUnsealer, unsealedCount == len(pods))
 
-	if unsealedCount > 0 {
+	policy := readinessPolicy(vaultUnsealer)
+	switch {
+	case readinessSatisfied(policy, unsealedCount, len(pods), outcome.activeUnsealed) && monitorOnly:
+		r.setCondition(vaultUnsealer, ConditionTypeReady, ConditionStatusTrue, ReasonMonitorOnly, fmt.Sprintf("%d of %d pods are unsealed", unsealedCount, len(pods)))
+	case readinessSatisfied(policy, unsealedCount, len(pods), outcome.activeUnsealed):
 		r.setCondition(vaultUnsealer, ConditionTypeReady, ConditionStatusTrue, ReasonReconcileSuccess, fmt.Sprintf("Successfully unsealed %d pods", unsealedCount))
-	} else {
+	case unsealedCount > 0:
+		r.setCondition(vaultUnsealer, ConditionTypeReady, ConditionStatusFalse, ReasonReadinessPolicyUnmet,
+			fmt.Sprintf("Only %d of %d pods are unsealed, readiness policy %s not met", unsealedCount, len(pods), policy))
+	case monitorOnly:
+		r.setCondition(vaultUnsealer, ConditionTypeReady, ConditionStatusFalse, ReasonVaultSealed, "No pods are unsealed")
+	default:
 		r.setCondition(vaultUnsealer, ConditionTypeReady, ConditionStatusFalse, ReasonUnsealFailed, "No pods were successfully unsealed")
 	}
 
-	r.clearCondition(vaultUnsealer, ConditionTypeKeysMissing)
-	r.clearCondition(vaultUnsealer, ConditionTypePodUnavailable)
-
-	if err := r.updateStatus(ctx, vaultUnsealer); err != nil {
-		log.Error(err, "Failed to update status")
-		metrics.ReconciliationErrors.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, "status_update").Inc()
-		return ctrl.Result{RequeueAfter: defaultInterval}, err
+	if reason, message, failed := outcome.failures.condition(); failed {
+		r.setCondition(vaultUnsealer, ConditionTypeVaultAPIFailure, ConditionStatusTrue, reason, message)
+	} else {
+		r.clearCondition(vaultUnsealer, ConditionTypeVaultAPIFailure)
+	}
+	if message, throttled := outcome.throttled.condition(); throttled {
+		r.setCondition(vaultUnsealer, ConditionTypeVaultRateLimited, ConditionStatusTrue, ReasonRateLimitQuota, message)
+	} else {
+		r.clearCondition(vaultUnsealer, ConditionTypeVaultRateLimited)
+	}
+	keyCount := len(unsealKeys)
+	if monitorOnly {
+		keyCount = -1
+	}
+	r.recordSealConfig(ctx, vaultUnsealer, outcome.seal, keyCount)
+	r.reconcileSealMigration(ctx, vaultUnsealer, outcome.sealMigrationPods)
+	r.reconcileCanary(vaultUnsealer, outcome)
+	switch {
+	case len(outcome.deferred) > 0:
+		r.setCondition(vaultUnsealer, ConditionTypeReconciling, ConditionStatusTrue, ReasonReconcileBudget,
+			fmt.Sprintf("Reconcile budget of %s used up, continuing with %d more pods", vaultUnsealer.Spec.ReconcileBudget.Duration, len(outcome.deferred)))
+	case monitorOnly:
+		r.setCondition(vaultUnsealer, ConditionTypeReconciling, ConditionStatusFalse, ReasonMonitorOnly, "Seal status read, keys are never submitted")
+	case needsAttention(podStatuses):
+		r.setCondition(vaultUnsealer, ConditionTypeReconciling, ConditionStatusTrue, ReasonPhaseUnseal,
+			fmt.Sprintf("%d of %d pods are unsealed", unsealedCount, len(pods)))
+	default:
+		r.setCondition(vaultUnsealer, ConditionTypeReconciling, ConditionStatusFalse, ReasonReconcileSuccess, "All phases completed")
 	}
 
 	log.Info("Reconciliation completed", "podsChecked", len(vaultUnsealer.Status.PodsChecked), "podsUnsealed", len(vaultUnsealer.Status.UnsealedPods))
-	return ctrl.Result{RequeueAfter: defaultInterval}, nil
+	if len(outcome.deferred) > 0 {
+		return ctrl.Result{RequeueAfter: deferredRequeueDelay}, nil
+	}
+	recheckAfter, recheck := stabilityRecheckAfter(podStatuses, time.Now())
+	if !needsAttention(podStatuses) && !breakGlass {
+		if recheck {
+			// Confirm freshly unsealed pods stayed unsealed.
+			return ctrl.Result{RequeueAfter: recheckAfter}, nil
+		}
+		// Nothing is left to do. Pod and Secret watches trigger the next
+		// reconcile, since a Vault pod that restarts or seals changes its
+		// status.
+		return ctrl.Result{}, nil
+	}
+	interval := defaultInterval
+	if needsAttention(podStatuses) {
+		interval = min(defaultInterval, fastInterval)
+	}
+	if recheck {
+		interval = min(interval, recheckAfter)
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter(interval, podStatuses, time.Now())}, nil
 }
 
-func (r *VaultUnsealerReconciler) getVaultPods(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) ([]corev1.Pod, error) {
-	selector, err := labels.Parse(vaultUnsealer.Spec.VaultLabelSelector)
-	if err != nil {
-		return nil, fmt.Errorf("invalid label selector: %w", err)
+// setAllPodsUnsealed records status.allPodsUnsealed and its gauge.
+func (r *VaultUnsealerReconciler) setAllPodsUnsealed(vaultUnsealer *opsv1alpha1.VaultUnsealer, unsealed bool) {
+	vaultUnsealer.Status.AllPodsUnsealed = unsealed
+	value := 0.0
+	if unsealed {
+		value = 1
 	}
+	metrics.AllPodsUnsealed.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace).Set(value)
+}
+
+// isPaused reports whether key submission has been suspended for the VaultUnsealer.
+func isPaused(vaultUnsealer *opsv1alpha1.VaultUnsealer) bool {
+	return vaultUnsealer.Annotations[opsv1alpha1.AnnotationPaused] == "true"
+}
 
-	podList := &corev1.PodList{}
-	if err := r.List(ctx, podList, &client.ListOptions{
-		Namespace:     vaultUnsealer.Namespace,
-		LabelSelector: selector,
-	}); err != nil {
+func (r *VaultUnsealerReconciler) getVaultPods(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) ([]corev1.Pod, error) {
+	selectors, err := vaultPodSelectors(vaultUnsealer)
+	if err != nil {
 		return nil, err
 	}
 
-	return podList.Items, nil
+	// Selectors are ORed: a pod matching several of them is listed once.
+	var pods []corev1.Pod
+	seen := make(map[string]bool)
+	for _, selector := range selectors {
+		podList := &corev1.PodList{}
+		if err := r.List(ctx, podList, &client.ListOptions{
+			Namespace:     vaultUnsealer.Namespace,
+			LabelSelector: selector,
+		}); err != nil {
+			return nil, err
+		}
+		for _, pod := range podList.Items {
+			if !seen[pod.Name] {
+				seen[pod.Name] = true
+				pods = append(pods, pod)
+			}
+		}
+	}
+	return pods, nil
 }
 
 func (r *VaultUnsealerReconciler) isPodReady(pod *corev1.Pod) bool {
-	if pod.Status.Phase != corev1.PodRunning {
-		return false
-	}
-
-	if pod.Status.PodIP == "" {
+	if !isPodRunning(pod) {
 		return false
 	}
 
@@ -275,99 +594,261 @@ func (r *VaultUnsealerReconciler) isPodReady(pod *corev1.Pod) bool {
 	return false
 }
 
-func (r *VaultUnsealerReconciler) checkAndUnsealPod(ctx context.Context, pod *corev1.Pod, vaultUnsealer *opsv1alpha1.VaultUnsealer, unsealKeys []string) (bool, error) {
+// isPodRunning reports whether the pod is running and reachable by IP.
+func isPodRunning(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodRunning && pod.Status.PodIP != ""
+}
+
+// isPodUnsealable reports whether keys may be submitted to the pod. Unless
+// spec.requirePodReady is false, the pod must also be Ready, or have its
+// containers ready with spec.readinessGate, which holds Ready back until the
+// pod is unsealed. With spec.vault.containerName only the Vault container is
+// considered.
+func (r *VaultUnsealerReconciler) isPodUnsealable(pod *corev1.Pod, vaultUnsealer *opsv1alpha1.VaultUnsealer) bool {
+	running, ready := isPodRunning(pod), r.isPodReady(pod)
+	if vaultUnsealer.Spec.ReadinessGate != nil {
+		ready = running && podConditionTrue(pod, corev1.ContainersReady)
+	}
+	if name := vaultUnsealer.Spec.Vault.ContainerName; name != "" {
+		running, ready = isContainerRunning(pod, name), isContainerReady(pod, name)
+	}
+	if vaultUnsealer.Spec.RequirePodReady != nil && !*vaultUnsealer.Spec.RequirePodReady {
+		return running
+	}
+	return ready
+}
+
+// podUnsealResult captures the outcome of checking and unsealing a single pod.
+type podUnsealResult struct {
+	// sealed reports whether the pod is still sealed after the attempt.
+	sealed bool
+	// unsealedNow is true when keys submitted in this pass unsealed the pod.
+	unsealedNow bool
+	// submissions lists the outcome of each key share submitted in this pass.
+	submissions []keySubmission
+	// role is the node's HA role once unsealed, or "" if it could not be read.
+	role string
+	// sealMigration is true when the pod reported a pending seal migration.
+	sealMigration bool
+	// round is the unseal round last reported by a pod that is still sealed.
+	round unsealRound
+	// seal is the seal configuration the pod reported, if it was read.
+	seal sealConfig
+}
+
+// unsealRound is the state of a sealed pod's unseal round, as Vault reports it.
+type unsealRound struct {
+	nonce     string
+	progress  int
+	threshold int
+}
+
+// checkAndUnsealPod submits unsealKeys to a sealed pod, skipping the shares
+// whose fingerprints are quarantined for it.
+func (r *VaultUnsealerReconciler) checkAndUnsealPod(ctx context.Context, pod *corev1.Pod, vaultUnsealer *opsv1alpha1.VaultUnsealer, unsealKeys []secrets.SecretString, quarantined map[string]bool) (podUnsealResult, error) {
 	log := logging.WithPod(logf.FromContext(ctx), pod)
+	var round unsealRound
+	observe := func(sealed bool, nonce string, progress, threshold int) {
+		recordSealProgress(vaultUnsealer, pod.Name, sealed, progress, threshold)
+		round = unsealRound{nonce: nonce, progress: progress, threshold: threshold}
+	}
 
 	vaultClient, err := r.createVaultClient(ctx, pod, vaultUnsealer)
 	if err != nil {
-		return true, fmt.Errorf("failed to create vault client: %w", err)
+		return podUnsealResult{sealed: true}, fmt.Errorf("failed to create vault client: %w", err)
 	}
 
 	status, err := vaultClient.GetSealStatus(ctx)
 	if err != nil {
 		log.Error(err, "Failed to get seal status")
-		return true, err
+		return podUnsealResult{sealed: true}, err
 	}
 
+	seal := sealConfig{shares: status.N, threshold: status.T}
 	log.Info("Vault seal status", "sealed", status.Sealed, "progress", status.Progress, "threshold", status.T)
+	observe(status.Sealed, status.Nonce, status.Progress, status.T)
 
 	if !status.Sealed {
 		log.Info("Vault pod is already unsealed")
-		return false, nil
+		return podUnsealResult{seal: seal, sealed: false, role: podRole(ctx, vaultClient)}, nil
+	}
+	if status.Migration {
+		if !vaultUnsealer.Spec.SealMigration {
+			log.Info("Seal migration in progress, not submitting keys")
+			return podUnsealResult{seal: seal, sealed: true, round: round, sealMigration: true}, nil
+		}
+		log.Info("Seal migration in progress, submitting keys in migration mode")
+		vaultClient.SetSealMigration(true)
 	}
 
+	if len(unsealKeys) == 0 {
+		// Only the seal status was asked for, e.g. while canary pods soak.
+		return podUnsealResult{seal: seal, sealed: true, round: round, sealMigration: status.Migration}, nil
+	}
+
+	var submissions []keySubmission
+	record := func(index int, fingerprint, outcome string) {
+		submissions = append(submissions, keySubmission{index: index, fingerprint: fingerprint, result: outcome})
+		metrics.UnsealKeySubmissions.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, pod.Name, strconv.Itoa(index), outcome).Inc()
+	}
+
+	delay := keySubmissionDelay(vaultUnsealer)
+	progress := status.Progress
+	submitted := false
 	for i, key := range unsealKeys {
-		keyLog := logging.WithUnsealAttempt(log, pod.Name, i+1, len(unsealKeys))
+		fingerprint := keyFingerprint(key)
+		keyLog := logging.WithUnsealAttempt(log, pod.Name, i+1, len(unsealKeys)).WithValues("keyFingerprint", fingerprint)
+		if quarantined[fingerprint] {
+			keyLog.Info("Skipping quarantined unseal key")
+			continue
+		}
+
+		if submitted && delay > 0 {
+			paced, err := pacedSealStatus(ctx, vaultClient, delay)
+			if err != nil {
+				keyLog.Error(err, "Failed to re-check seal status between key submissions")
+				return podUnsealResult{seal: seal, sealed: true, round: round, submissions: submissions, sealMigration: status.Migration}, err
+			}
+			if !paced.Sealed {
+				keyLog.Info("Vault pod was unsealed concurrently, not submitting further keys")
+				return podUnsealResult{seal: seal, sealed: false, submissions: submissions, role: podRole(ctx, vaultClient)}, nil
+			}
+			if paced.Progress != progress {
+				keyLog.Info("Unseal progress changed concurrently", "expectedProgress", progress, "progress", paced.Progress)
+				progress = paced.Progress
+			}
+			observe(paced.Sealed, paced.Nonce, paced.Progress, paced.T)
+		}
+		if r.GlobalPause.Engaged() {
+			keyLog.Info("Global pause engaged, not submitting further keys")
+			return podUnsealResult{seal: seal, sealed: true, round: round, submissions: submissions, sealMigration: status.Migration}, nil
+		}
+		submitted = true
 		keyLog.Info("Submitting unseal key")
 
 		unsealResp, err := vaultClient.Unseal(ctx, key)
 		if err != nil {
 			keyLog.Error(err, "Failed to submit unseal key")
-			return true, err
+			if vault.IsKeyRejected(err) {
+				record(i+1, fingerprint, keyResultRejected)
+			}
+			return podUnsealResult{seal: seal, sealed: true, round: round, submissions: submissions, sealMigration: status.Migration}, err
 		}
 
+		outcome := keyResult(progress, unsealResp)
+		record(i+1, fingerprint, outcome)
+		progress = unsealResp.Progress
+		observe(unsealResp.Sealed, unsealResp.Nonce, unsealResp.Progress, unsealResp.T)
+
 		keyLog.Info("Unseal key submitted successfully",
 			"sealed", unsealResp.Sealed,
 			"progress", unsealResp.Progress,
-			"threshold", unsealResp.T)
+			"threshold", unsealResp.T,
+			"result", outcome)
 
 		if !unsealResp.Sealed {
 			keyLog.Info("Vault pod successfully unsealed")
-			return false, nil
+			return podUnsealResult{seal: seal, sealed: false, unsealedNow: true, submissions: submissions, role: podRole(ctx, vaultClient)}, nil
 		}
 	}
 
 	log.Info("All keys submitted but vault still sealed", "keysSubmitted", len(unsealKeys))
-	return true, nil
+	return podUnsealResult{seal: seal, sealed: true, round: round, submissions: submissions, sealMigration: status.Migration}, nil
 }
 
-func (r *VaultUnsealerReconciler) createVaultClient(ctx context.Context, pod *corev1.Pod, vaultUnsealer *opsv1alpha1.VaultUnsealer) (*vault.Client, error) {
-	vaultURL := strings.Replace(vaultUnsealer.Spec.Vault.URL, "vault.vault.svc", pod.Status.PodIP, 1)
-	vaultURL = strings.Replace(vaultURL, "vault", pod.Status.PodIP, 1)
-
-	if !strings.HasPrefix(vaultURL, "http") {
-		vaultURL = "http://" + pod.Status.PodIP + ":8200"
+// recordSealProgress publishes a pod's unseal progress as a fraction of the
+// threshold. A value that stays between 0 and 1 across reconciles usually
+// means someone else is submitting keys at the same time.
+func recordSealProgress(vaultUnsealer *opsv1alpha1.VaultUnsealer, podName string, sealed bool, progress, threshold int) {
+	value := 0.0
+	if sealed && threshold > 0 {
+		value = float64(progress) / float64(threshold)
 	}
+	metrics.SealProgress.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, podName).Set(value)
+}
 
-	var tlsConfig *tls.Config
-	if vaultUnsealer.Spec.Vault.CABundleSecretRef != nil {
-		tlsConfig, _ = r.getTLSConfig(ctx, vaultUnsealer)
-	} else if vaultUnsealer.Spec.Vault.InsecureSkipVerify {
-		tlsConfig = &tls.Config{InsecureSkipVerify: true}
+// podRole reads the HA role of an unsealed pod. A node that was just unsealed
+// may not have joined the cluster yet, so failures are only logged and the
+// role is filled in by a later reconcile.
+func podRole(ctx context.Context, vaultClient *vault.Client) string {
+	role, err := vaultClient.GetRole(ctx)
+	if err != nil {
+		logf.FromContext(ctx).V(1).Info("Could not determine Vault node role", "reason", err.Error())
 	}
-
-	return vault.NewClient(vaultURL, tlsConfig)
+	return role
 }
 
-func (r *VaultUnsealerReconciler) getTLSConfig(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) (*tls.Config, error) {
-	if vaultUnsealer.Spec.Vault.CABundleSecretRef == nil {
-		return nil, nil
+func (r *VaultUnsealerReconciler) createVaultClient(ctx context.Context, pod *corev1.Pod, vaultUnsealer *opsv1alpha1.VaultUnsealer) (*vault.Client, error) {
+	vaultURL, err := podVaultURL(vaultUnsealer, pod)
+	if err != nil {
+		return nil, err
 	}
 
-	namespace := vaultUnsealer.Spec.Vault.CABundleSecretRef.Namespace
-	if namespace == "" {
-		namespace = vaultUnsealer.Namespace
+	var vaultClient *vault.Client
+	if vaultUnsealer.Spec.Vault.APIServerProxy {
+		if r.APIServerProxy == nil {
+			return nil, errNoAPIServerProxy
+		}
+		vaultClient, err = r.APIServerProxy.client(pod, vaultURL)
+	} else {
+		vaultClient, err = r.directVaultClient(ctx, vaultUnsealer, vaultURL)
 	}
-
-	secret := &corev1.Secret{}
-	if err := r.Get(ctx, types.NamespacedName{
-		Namespace: namespace,
-		Name:      vaultUnsealer.Spec.Vault.CABundleSecretRef.Name,
-	}, secret); err != nil {
+	if err != nil {
+		return nil, err
+	}
+	if err := vaultClient.SetPathPrefix(vaultUnsealer.Spec.Vault.PathPrefix); err != nil {
 		return nil, err
 	}
+	if healthCheck := vaultUnsealer.Spec.Vault.HealthCheck; healthCheck != nil {
+		vaultClient.SetHealthCheck(healthCheck.Path, healthCheck.AcceptedStatusCodes)
+	}
+	if reconcileID := reconcileIDFrom(ctx); reconcileID != "" {
+		vaultClient.SetRequestID(reconcileID)
+	}
+	vaultClient.SetResponseObserver(func(endpoint, statusClass string) {
+		metrics.VaultResponses.WithLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, endpoint, statusClass).Inc()
+	})
+	if ref := vaultUnsealer.Spec.Vault.TokenSecretRef; ref != nil {
+		token, err := r.loadStatusToken(ctx, vaultUnsealer, *ref)
+		if err != nil {
+			return nil, err
+		}
+		vaultClient.SetStatusToken(token)
+	}
+	return vaultClient, nil
+}
 
-	caData, ok := secret.Data[vaultUnsealer.Spec.Vault.CABundleSecretRef.Key]
-	if !ok {
-		return nil, fmt.Errorf("key %s not found in CA bundle secret", vaultUnsealer.Spec.Vault.CABundleSecretRef.Key)
+// directVaultClient returns a client connecting to vaultURL, or to the mesh
+// sidecar in front of it, with the VaultUnsealer's TLS and DNS settings.
+func (r *VaultUnsealerReconciler) directVaultClient(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer, vaultURL string) (*vault.Client, error) {
+	var host string
+	var err error
+	if sidecar := vaultUnsealer.Spec.Vault.MeshSidecar; sidecar != nil {
+		if vaultURL, host, err = meshSidecarURL(vaultURL, *sidecar); err != nil {
+			return nil, err
+		}
 	}
 
-	caCertPool := x509.NewCertPool()
-	if !caCertPool.AppendCertsFromPEM(caData) {
-		return nil, fmt.Errorf("failed to parse CA certificate")
+	var tlsConfig *tls.Config
+	if len(caBundleRefs(vaultUnsealer)) > 0 {
+		if tlsConfig, err = r.getTLSConfig(ctx, vaultUnsealer); err != nil {
+			return nil, fmt.Errorf("%w: %w", errCABundleUnusable, err)
+		}
+	} else if vaultUnsealer.Spec.Vault.InsecureSkipVerify {
+		tlsConfig = &tls.Config{InsecureSkipVerify: true}
 	}
 
-	return &tls.Config{RootCAs: caCertPool}, nil
+	vaultClient, err := vault.NewClient(vaultURL, tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+	if host != "" {
+		vaultClient.SetHost(host)
+	}
+	if resolver := resolverFor(ctx, vaultUnsealer); resolver != nil {
+		vaultClient.SetResolver(resolver)
+	}
+	return vaultClient, nil
 }
 
 func (r *VaultUnsealerReconciler) setCondition(vaultUnsealer *opsv1alpha1.VaultUnsealer, condType, status, reason, message string) {
@@ -388,6 +869,16 @@ func (r *VaultUnsealerReconciler) setCondition(vaultUnsealer *opsv1alpha1.VaultU
 	vaultUnsealer.Status.Conditions = append(vaultUnsealer.Status.Conditions, condition)
 }
 
+// findCondition returns the condition of the given type, or nil.
+func findCondition(vaultUnsealer *opsv1alpha1.VaultUnsealer, condType string) *opsv1alpha1.Condition {
+	for i := range vaultUnsealer.Status.Conditions {
+		if vaultUnsealer.Status.Conditions[i].Type == condType {
+			return &vaultUnsealer.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
 func (r *VaultUnsealerReconciler) clearCondition(vaultUnsealer *opsv1alpha1.VaultUnsealer, condType string) {
 	for i, condition := range vaultUnsealer.Status.Conditions {
 		if condition.Type == condType {
@@ -400,44 +891,137 @@ func (r *VaultUnsealerReconciler) clearCondition(vaultUnsealer *opsv1alpha1.Vaul
 	}
 }
 
-func (r *VaultUnsealerReconciler) updateStatus(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) error {
-	return r.Status().Update(ctx, vaultUnsealer)
+// event records a Kubernetes Event when a Recorder is configured. Events
+// raised during a reconcile carry its reconcile ID.
+func (r *VaultUnsealerReconciler) event(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer, eventType, reason, message string) {
+	if r.Recorder == nil {
+		return
+	}
+	r.Recorder.Event(vaultUnsealer, eventType, reason, withReconcileIDSuffix(ctx, message))
 }
 
-// generateReconcileID creates a unique identifier for tracking reconciliation operations
-func generateReconcileID() (string, error) {
-	bytes := make([]byte, 4)
-	if _, err := rand.Read(bytes); err != nil {
-		return "", err
-	}
-	return hex.EncodeToString(bytes), nil
+// statusUnchanged reports whether a reconcile left status as it was, ignoring
+// lastReconcileTime so that no-op reconciles do not write a new resourceVersion.
+func statusUnchanged(original, current *opsv1alpha1.VaultUnsealerStatus) bool {
+	originalCopy, currentCopy := original.DeepCopy(), current.DeepCopy()
+	originalCopy.LastReconcileTime, currentCopy.LastReconcileTime = nil, nil
+	return equality.Semantic.DeepEqual(originalCopy, currentCopy)
+}
+
+// updateStatus writes the status computed by this reconcile. On a conflict
+// it re-reads the VaultUnsealer and applies the same status on top of the
+// latest version, since the operator is the only owner of its status.
+func (r *VaultUnsealerReconciler) updateStatus(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) error {
+	desired := vaultUnsealer.Status.DeepCopy()
+	refresh := false
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if refresh {
+			if err := r.Get(ctx, client.ObjectKeyFromObject(vaultUnsealer), vaultUnsealer); err != nil {
+				return err
+			}
+			desired.DeepCopyInto(&vaultUnsealer.Status)
+		}
+		refresh = true
+		return r.Status().Update(ctx, vaultUnsealer)
+	})
 }
 
 func (r *VaultUnsealerReconciler) cleanupMetrics(vaultUnsealer *opsv1alpha1.VaultUnsealer) {
 	// Clean up Prometheus metrics to prevent memory leaks
 	metrics.ReconciliationTotal.DeleteLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace)
-	metrics.ReconciliationErrors.DeleteLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, "pod_discovery")
-	metrics.ReconciliationErrors.DeleteLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, "keys_loading")
-	metrics.ReconciliationErrors.DeleteLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, "status_update")
+	metrics.ReconciliationErrors.DeletePartialMatch(prometheus.Labels{"vaultunsealer": vaultUnsealer.Name, "namespace": vaultUnsealer.Namespace})
 	metrics.PodsUnsealed.DeleteLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace)
+	metrics.AllPodsUnsealed.DeleteLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace)
 	metrics.PodsChecked.DeleteLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace)
 	metrics.UnsealKeysLoaded.DeleteLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace)
+	metrics.UnsealKeysAge.DeleteLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace)
 	metrics.ReconciliationDuration.DeleteLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace)
 
 	// Clean up pod-specific metrics for all pods that were tracked
-	if len(vaultUnsealer.Status.PodsChecked) > 0 {
-		for _, podName := range vaultUnsealer.Status.PodsChecked {
-			metrics.UnsealAttempts.DeleteLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, podName, "success")
-			metrics.UnsealAttempts.DeleteLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, podName, "failed")
-			metrics.VaultConnectionStatus.DeleteLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, podName)
+	for _, podName := range trackedPods(&vaultUnsealer.Status) {
+		deletePodMetrics(vaultUnsealer, podName)
+	}
+	metrics.UnsealKeySubmissions.DeletePartialMatch(prometheus.Labels{"vaultunsealer": vaultUnsealer.Name, "namespace": vaultUnsealer.Namespace})
+	metrics.VaultResponses.DeletePartialMatch(prometheus.Labels{"vaultunsealer": vaultUnsealer.Name, "namespace": vaultUnsealer.Namespace})
+}
+
+// deletePodMetrics removes the per-pod metric series for podName.
+func deletePodMetrics(vaultUnsealer *opsv1alpha1.VaultUnsealer, podName string) {
+	metrics.UnsealAttempts.DeleteLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, podName, "success")
+	metrics.UnsealAttempts.DeleteLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, podName, "failed")
+	metrics.VaultConnectionStatus.DeleteLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, podName)
+	metrics.VaultRateLimited.DeleteLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, podName)
+	metrics.PodSealed.DeleteLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, podName)
+	metrics.SealProgress.DeleteLabelValues(vaultUnsealer.Name, vaultUnsealer.Namespace, podName)
+	metrics.UnsealKeySubmissions.DeletePartialMatch(prometheus.Labels{
+		"vaultunsealer": vaultUnsealer.Name, "namespace": vaultUnsealer.Namespace, "pod": podName,
+	})
+}
+
+// trackedPods returns the names of every pod recorded in status.
+func trackedPods(status *opsv1alpha1.VaultUnsealerStatus) []string {
+	names := slices.Clone(status.PodsChecked)
+	for _, podStatus := range status.Pods {
+		names = append(names, podStatus.Name)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// pruneDeletedPods drops the status entries and metrics of pods tracked in the
+// previous status that no longer match the selector, returning their names.
+func pruneDeletedPods(vaultUnsealer *opsv1alpha1.VaultUnsealer, previous *opsv1alpha1.VaultUnsealerStatus, pods []corev1.Pod) []string {
+	existing := make(map[string]bool, len(pods))
+	for _, pod := range pods {
+		existing[pod.Name] = true
+	}
+
+	var pruned []string
+	for _, podName := range trackedPods(previous) {
+		if existing[podName] {
+			continue
 		}
+		deletePodMetrics(vaultUnsealer, podName)
+		pruned = append(pruned, podName)
+	}
+	vaultUnsealer.Status.Pods = slices.DeleteFunc(vaultUnsealer.Status.Pods, func(podStatus opsv1alpha1.PodStatus) bool {
+		return !existing[podStatus.Name]
+	})
+	return pruned
+}
+
+// onDelete cleans up the metrics of a deleted VaultUnsealer using the last
+// state seen in the cache, which still carries the pods it tracked.
+func (r *VaultUnsealerReconciler) onDelete(_ context.Context, e event.DeleteEvent, _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	if vaultUnsealer, ok := e.Object.(*opsv1alpha1.VaultUnsealer); ok {
+		r.cleanupMetrics(vaultUnsealer)
+		r.specs.forget(client.ObjectKeyFromObject(vaultUnsealer))
 	}
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *VaultUnsealerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	workers := max(r.MaxConcurrentReconciles, 1)
+	if r.StartupConcurrency > workers && r.StartupBurstDuration > 0 {
+		r.limiter = newReconcileLimiter(r.StartupConcurrency, workers, r.StartupBurstDuration)
+		workers = r.StartupConcurrency
+	}
+
+	// Pod and Secret events are mapped to the VaultUnsealers of this shard,
+	// so the shard filter only applies to the VaultUnsealers themselves.
+	r.wakeups = make(chan event.GenericEvent, 16)
+
+	var owned predicate.Predicate = predicate.Funcs{}
+	if r.Shard != nil && r.Shard.Count > 1 {
+		owned = predicate.NewPredicateFuncs(r.Shard.Owns)
+	}
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&opsv1alpha1.VaultUnsealer{}).
+		For(&opsv1alpha1.VaultUnsealer{}, builder.WithPredicates(owned)).
+		Watches(&opsv1alpha1.VaultUnsealer{}, handler.Funcs{DeleteFunc: r.onDelete}, builder.WithPredicates(owned)).
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.vaultUnsealersForPod), builder.WithPredicates(vaultPodChanged)).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.vaultUnsealersForSecret)).
+		WatchesRawSource(source.Channel(r.wakeups, &handler.EnqueueRequestForObject{})).
+		WithOptions(controller.Options{MaxConcurrentReconciles: workers, NewQueue: newPrioritizedQueue(mgr.GetClient())}).
 		Named("vaultunsealer").
 		Complete(r)
 }
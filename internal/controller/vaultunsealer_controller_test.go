@@ -18,11 +18,13 @@ package controller
 
 import (
 	"context"
+	"fmt"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -63,7 +65,7 @@ var _ = Describe("VaultUnsealer Controller", func() {
 						},
 						VaultLabelSelector: "app.kubernetes.io/name=vault",
 						Mode: opsv1alpha1.ModeSpec{
-							HA: true,
+							HA: ptr.To(true),
 						},
 						KeyThreshold: 3,
 					},
@@ -95,5 +97,35 @@ var _ = Describe("VaultUnsealer Controller", func() {
 			// TODO(user): Add more specific assertions depending on your controller's reconciliation logic.
 			// Example: If you expect a certain status condition after reconciliation, verify it here.
 		})
+
+		It("should report InvalidSpec when the spec validator rejects the resource", func() {
+			controllerReconciler := &VaultUnsealerReconciler{
+				Client: k8sClient,
+				Scheme: k8sClient.Scheme(),
+				SpecValidator: func(context.Context, *opsv1alpha1.VaultUnsealer) error {
+					return fmt.Errorf("keyThreshold is too high")
+				},
+			}
+
+			By("Reconciling the resource")
+			for range 2 {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: typeNamespacedName,
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			resource := &opsv1alpha1.VaultUnsealer{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, resource)).To(Succeed())
+			var condition *opsv1alpha1.Condition
+			for i := range resource.Status.Conditions {
+				if resource.Status.Conditions[i].Type == ConditionTypeInvalidSpec {
+					condition = &resource.Status.Conditions[i]
+				}
+			}
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(ConditionStatusTrue))
+			Expect(condition.Message).To(ContainSubstring("keyThreshold is too high"))
+		})
 	})
 })
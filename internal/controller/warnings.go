@@ -0,0 +1,46 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// refreshWarnings rebuilds status.warnings from the admission warnings of the
+// current spec. The phases then add their runtime warnings with addWarning.
+func (r *VaultUnsealerReconciler) refreshWarnings(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) {
+	vaultUnsealer.Status.Warnings = nil
+	if r.SpecWarnings == nil {
+		return
+	}
+	for _, message := range r.SpecWarnings(ctx, vaultUnsealer) {
+		addWarning(vaultUnsealer, opsv1alpha1.WarningSourceAdmission, message)
+	}
+}
+
+// addWarning records a warning in status.warnings unless it is already listed.
+func addWarning(vaultUnsealer *opsv1alpha1.VaultUnsealer, source, message string) {
+	warning := opsv1alpha1.StatusWarning{Source: source, Message: message}
+	for _, existing := range vaultUnsealer.Status.Warnings {
+		if existing == warning {
+			return
+		}
+	}
+	vaultUnsealer.Status.Warnings = append(vaultUnsealer.Status.Warnings, warning)
+}
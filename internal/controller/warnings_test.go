@@ -0,0 +1,47 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+const thresholdWarning = "keyThreshold is 0, all available keys will be used for unsealing"
+
+func TestReconcile_RefreshesAdmissionWarnings(t *testing.T) {
+	r := newFakeReconciler(t, newFinalizerTestUnsealer())
+	specWarnings := []string{thresholdWarning, thresholdWarning}
+	r.SpecWarnings = func(context.Context, *opsv1alpha1.VaultUnsealer) []string {
+		return specWarnings
+	}
+
+	got := reconcileAndGet(t, r)
+	assert.Equal(t, []opsv1alpha1.StatusWarning{{
+		Source:  opsv1alpha1.WarningSourceAdmission,
+		Message: thresholdWarning,
+	}}, got.Status.Warnings, "duplicates are listed once")
+
+	// Fixing the spec clears the warning on the next reconcile.
+	specWarnings = nil
+	got = reconcileAndGet(t, r)
+	assert.Empty(t, got.Status.Warnings)
+}
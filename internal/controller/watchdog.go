@@ -0,0 +1,130 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/metrics"
+)
+
+// DefaultReconcileTimeout is how long a reconcile may run before the
+// watchdog cancels it and frees its worker.
+const DefaultReconcileTimeout = 10 * time.Minute
+
+// watchdogWriteTimeout bounds recording a timed out reconcile, which cannot
+// use the cancelled reconcile's context.
+const watchdogWriteTimeout = 10 * time.Second
+
+var (
+	errReconcileTimedOut     = errors.New("reconcile timed out")
+	errReconcileStillRunning = errors.New("a timed out reconcile of this resource has not stopped yet")
+)
+
+// reconcileWatchdog stops one wedged reconcile, for example one stuck in a
+// TCP connect that ignores its deadline, from holding a worker forever.
+// The zero value is ready to use.
+type reconcileWatchdog struct {
+	mu sync.Mutex
+	// abandoned holds the resources whose timed out reconcile is still
+	// running in the background.
+	abandoned map[types.NamespacedName]bool
+}
+
+// run calls reconcile with a context that is cancelled once timeout passes.
+// If reconcile has not returned by then, run returns errReconcileTimedOut
+// without waiting for it. Until the abandoned reconcile returns, further
+// runs for key fail with errReconcileStillRunning rather than start a
+// second reconcile of the same resource alongside it.
+func (w *reconcileWatchdog) run(ctx context.Context, key types.NamespacedName, timeout time.Duration,
+	reconcile func(context.Context) (ctrl.Result, error)) (ctrl.Result, error) {
+	w.mu.Lock()
+	if w.abandoned[key] {
+		w.mu.Unlock()
+		return ctrl.Result{}, errReconcileStillRunning
+	}
+	w.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	type outcome struct {
+		result ctrl.Result
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		result, err := reconcile(ctx)
+		done <- outcome{result, err}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case out := <-done:
+		cancel()
+		return out.result, out.err
+	case <-timer.C:
+	}
+
+	cancel()
+	w.mu.Lock()
+	if w.abandoned == nil {
+		w.abandoned = make(map[types.NamespacedName]bool)
+	}
+	w.abandoned[key] = true
+	w.mu.Unlock()
+	go func() {
+		<-done
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.abandoned, key)
+	}()
+	return ctrl.Result{}, fmt.Errorf("%w after %s", errReconcileTimedOut, timeout)
+}
+
+// recordReconcileTimeout sets ReconcileTimeout=True on the VaultUnsealer
+// whose reconcile the watchdog cancelled. The next reconcile that completes
+// removes it again.
+func (r *VaultUnsealerReconciler) recordReconcileTimeout(ctx context.Context, key types.NamespacedName) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), watchdogWriteTimeout)
+	defer cancel()
+	log := logf.FromContext(ctx)
+
+	message := fmt.Sprintf("Reconcile ran longer than %s and was cancelled to free its worker", r.ReconcileTimeout)
+	log.Info("Reconcile timed out", "vaultunsealer", key, "timeout", r.ReconcileTimeout)
+	metrics.ReconciliationErrors.WithLabelValues(key.Name, key.Namespace, "timeout").Inc()
+
+	vaultUnsealer := &opsv1alpha1.VaultUnsealer{}
+	if err := r.Get(ctx, key, vaultUnsealer); err != nil {
+		log.Error(err, "Failed to get VaultUnsealer to record the reconcile timeout")
+		return
+	}
+	r.event(ctx, vaultUnsealer, corev1.EventTypeWarning, ReasonReconcileTimedOut, message)
+	base := vaultUnsealer.DeepCopy()
+	r.setCondition(vaultUnsealer, ConditionTypeReconcileTimeout, ConditionStatusTrue, ReasonReconcileTimedOut, message)
+	if err := r.Status().Patch(ctx, vaultUnsealer, client.MergeFrom(base)); err != nil {
+		log.Error(err, "Failed to record the reconcile timeout")
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+func TestReconcileWatchdog(t *testing.T) {
+	var w reconcileWatchdog
+	key := types.NamespacedName{Namespace: "vault", Name: "main"}
+
+	result, err := w.run(context.Background(), key, time.Second, func(context.Context) (ctrl.Result, error) {
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, result.RequeueAfter)
+
+	// A reconcile that ignores its cancelled context, like a stuck connect.
+	release := make(chan struct{})
+	cancelled := make(chan struct{})
+	_, err = w.run(context.Background(), key, 10*time.Millisecond, func(ctx context.Context) (ctrl.Result, error) {
+		<-ctx.Done()
+		close(cancelled)
+		<-release
+		return ctrl.Result{}, ctx.Err()
+	})
+	require.ErrorIs(t, err, errReconcileTimedOut)
+	<-cancelled
+
+	_, err = w.run(context.Background(), key, time.Second, func(context.Context) (ctrl.Result, error) {
+		t.Error("a second reconcile started alongside the abandoned one")
+		return ctrl.Result{}, nil
+	})
+	assert.ErrorIs(t, err, errReconcileStillRunning)
+	_, err = w.run(context.Background(), types.NamespacedName{Namespace: "vault", Name: "other"}, time.Second,
+		func(context.Context) (ctrl.Result, error) { return ctrl.Result{}, nil })
+	assert.NoError(t, err, "other resources are unaffected")
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		_, err := w.run(context.Background(), key, time.Second, func(context.Context) (ctrl.Result, error) { return ctrl.Result{}, nil })
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestReconcile_RecordsReconcileTimeout(t *testing.T) {
+	r := newFakeReconciler(t, newFinalizerTestUnsealer())
+	recorder := record.NewFakeRecorder(100)
+	r.Recorder = recorder
+	r.ReconcileTimeout = time.Minute
+
+	key := types.NamespacedName{Namespace: "vault", Name: "main"}
+	r.recordReconcileTimeout(context.Background(), key)
+	got := &opsv1alpha1.VaultUnsealer{}
+	require.NoError(t, r.Get(context.Background(), key, got))
+	assertCondition(t, got, ConditionTypeReconcileTimeout, ConditionStatusTrue, ReasonReconcileTimedOut)
+	require.NotEmpty(t, recorder.Events)
+	assert.Contains(t, <-recorder.Events, "Warning ReconcileTimedOut Reconcile ran longer than 1m0s and was cancelled")
+
+	got = reconcileAndGet(t, r)
+	assert.Nil(t, findCondition(got, ConditionTypeReconcileTimeout), "a completed reconcile clears the condition")
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// vaultPodChanged passes pod updates that can change what a reconcile does:
+// a new IP or address annotation, phase or readiness of the pod or one of its
+// containers, a container restart, new labels, a reconcile request or the
+// start of deletion. Vault's readiness probe fails while it is sealed, so a
+// pod that seals is seen here as well.
+var vaultPodChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldPod, okOld := e.ObjectOld.(*corev1.Pod)
+		newPod, okNew := e.ObjectNew.(*corev1.Pod)
+		if !okOld || !okNew {
+			return true
+		}
+		return podWatchState(oldPod) != podWatchState(newPod) ||
+			!equality.Semantic.DeepEqual(oldPod.Labels, newPod.Labels)
+	},
+}
+
+type podState struct {
+	podIP    string
+	address  string
+	phase    corev1.PodPhase
+	ready    bool
+	restarts int32
+	// readyContainers tracks container readiness, which can change while
+	// a sidecar keeps the pod as a whole unready.
+	readyContainers int
+	deleting        bool
+	// reconcileRequestedAt asks for the pod to be reconciled on its own.
+	reconcileRequestedAt string
+}
+
+func podWatchState(pod *corev1.Pod) podState {
+	state := podState{
+		podIP:    pod.Status.PodIP,
+		address:  pod.Annotations[opsv1alpha1.AnnotationPodAddress],
+		phase:    pod.Status.Phase,
+		deleting: pod.DeletionTimestamp != nil,
+
+		reconcileRequestedAt: pod.Annotations[opsv1alpha1.AnnotationReconcileRequestedAt],
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			state.ready = condition.Status == corev1.ConditionTrue
+		}
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		state.restarts += status.RestartCount
+		if status.Ready {
+			state.readyContainers++
+		}
+	}
+	return state
+}
+
+// requestReconcile queues vaultUnsealer outside of the Kubernetes watches, as
+// the seal watcher does when a pod seals without any change to the pod.
+func (r *VaultUnsealerReconciler) requestReconcile(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) {
+	if r.wakeups == nil {
+		return
+	}
+	select {
+	case r.wakeups <- event.GenericEvent{Object: vaultUnsealer}:
+	case <-ctx.Done():
+	}
+}
+
+// vaultUnsealersForPod maps a pod to the VaultUnsealers with a selector
+// matching it.
+func (r *VaultUnsealerReconciler) vaultUnsealersForPod(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.vaultUnsealersMatching(ctx, client.InNamespace(obj.GetNamespace()), func(vaultUnsealer *opsv1alpha1.VaultUnsealer) bool {
+		return MatchesVaultPod(vaultUnsealer, obj.GetLabels())
+	})
+}
+
+// vaultUnsealersForSecret maps a Secret to the VaultUnsealers reading unseal
+// keys, CA bundles or HCP credentials from it.
+func (r *VaultUnsealerReconciler) vaultUnsealersForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.vaultUnsealersMatching(ctx, nil, func(vaultUnsealer *opsv1alpha1.VaultUnsealer) bool {
+		return slices.Contains(referencedSecrets(vaultUnsealer), client.ObjectKeyFromObject(obj))
+	})
+}
+
+func (r *VaultUnsealerReconciler) vaultUnsealersMatching(ctx context.Context, opt client.ListOption, match func(*opsv1alpha1.VaultUnsealer) bool) []reconcile.Request {
+	var opts []client.ListOption
+	if opt != nil {
+		opts = append(opts, opt)
+	}
+	list := &opsv1alpha1.VaultUnsealerList{}
+	if err := r.List(ctx, list, opts...); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list VaultUnsealers for watch event")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range list.Items {
+		vaultUnsealer := &list.Items[i]
+		if r.Shard != nil && !r.Shard.Owns(vaultUnsealer) {
+			continue
+		}
+		if match(vaultUnsealer) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(vaultUnsealer)})
+		}
+	}
+	return requests
+}
+
+// referencedSecrets returns every Secret vaultUnsealer reads.
+func referencedSecrets(vaultUnsealer *opsv1alpha1.VaultUnsealer) []client.ObjectKey {
+	refs := append(vaultUnsealer.Spec.KeySecretRefs(), caBundleRefs(vaultUnsealer)...)
+	keys := make([]client.ObjectKey, 0, len(refs)+1)
+	for _, ref := range refs {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = vaultUnsealer.Namespace
+		}
+		keys = append(keys, client.ObjectKey{Namespace: namespace, Name: ref.Name})
+	}
+	if hcp := vaultUnsealer.Spec.HCPVaultSecrets; hcp != nil && hcp.CredentialsSecretName != "" {
+		keys = append(keys, client.ObjectKey{Namespace: vaultUnsealer.Namespace, Name: hcp.CredentialsSecretName})
+	}
+	if ref := vaultUnsealer.Spec.Vault.TokenSecretRef; ref != nil {
+		keys = append(keys, client.ObjectKey{Namespace: vaultUnsealer.Namespace, Name: ref.Name})
+	}
+	return keys
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+func TestVaultUnsealersForPod(t *testing.T) {
+	other := opsv1alpha1.NewVaultUnsealer("vault", "other").WithVaultURL("http://vault:8200").WithLabelSelector("app=other")
+	r := newFakeReconciler(t, newFinalizerTestUnsealer(), other)
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "vault-0", Namespace: "vault", Labels: map[string]string{"app": "vault"}}}
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "vault", Name: "main"}}},
+		r.vaultUnsealersForPod(context.Background(), pod))
+
+	pod.Namespace = "elsewhere"
+	assert.Empty(t, r.vaultUnsealersForPod(context.Background(), pod), "selectors only match pods in their own namespace")
+}
+
+func TestVaultUnsealersForSecret(t *testing.T) {
+	crossNamespace := opsv1alpha1.NewVaultUnsealer("other", "remote").WithVaultURL("http://vault:8200").
+		WithLabelSelector("app=vault").
+		WithCABundleSecrets(opsv1alpha1.SecretRef{Name: "vault-keys", Namespace: "vault", Key: "ca.crt"})
+	r := newFakeReconciler(t, newFinalizerTestUnsealer(), crossNamespace)
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"}}
+	assert.ElementsMatch(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "vault", Name: "main"}},
+		{NamespacedName: types.NamespacedName{Namespace: "other", Name: "remote"}},
+	}, r.vaultUnsealersForSecret(context.Background(), secret))
+
+	secret.Name = "unrelated"
+	assert.Empty(t, r.vaultUnsealersForSecret(context.Background(), secret))
+}
+
+func TestVaultPodChanged(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-0", Namespace: "vault", ResourceVersion: "1"},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	updated := pod.DeepCopy()
+	updated.ResourceVersion = "2"
+	updated.Annotations = map[string]string{"unrelated": "change"}
+	assert.False(t, vaultPodChanged.Update(event.UpdateEvent{ObjectOld: pod, ObjectNew: updated}))
+
+	updated.Status.Conditions[0].Status = corev1.ConditionFalse
+	assert.True(t, vaultPodChanged.Update(event.UpdateEvent{ObjectOld: pod, ObjectNew: updated}), "readiness changed")
+
+	updated = pod.DeepCopy()
+	updated.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "vault", RestartCount: 1}}
+	assert.True(t, vaultPodChanged.Update(event.UpdateEvent{ObjectOld: pod, ObjectNew: updated}), "container restarted")
+}
+
+func TestReconcile_NoRequeueWhenIdle(t *testing.T) {
+	keys := []string{"key-1", "key-2"}
+	fake := vaultfake.NewServer(vaultfake.WithKeys(keys, 2))
+	defer fake.Close()
+
+	pod, _ := newFakeVaultPod(fake)
+	pod.Labels = map[string]string{"app": "vault"}
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	keysJSON, err := json.Marshal(keys)
+	require.NoError(t, err)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "vault"},
+		Data:       map[string][]byte{"keys.json": keysJSON},
+	}
+	r := newFakeReconciler(t, newFinalizerTestUnsealer().WithVaultURL("http://vault"), pod, secret)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "vault", Name: "main"}}
+
+	result, err := r.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+	assert.InDelta(t, stabilityCheckDelay, result.RequeueAfter, float64(time.Second), "a fresh unseal is checked again")
+	assert.False(t, fake.Sealed())
+
+	// Once the unseal is old enough the pod is stable and the reconcile idles.
+	vu := &opsv1alpha1.VaultUnsealer{}
+	require.NoError(t, r.Get(context.Background(), request.NamespacedName, vu))
+	assert.False(t, vu.Status.Pods[0].Stable)
+	vu.Status.Pods[0].LastUnsealTime = &metav1.Time{Time: time.Now().Add(-stabilityCheckDelay)}
+	require.NoError(t, r.Status().Update(context.Background(), vu))
+	result, err = r.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter, "all pods unsealed, watches trigger the next reconcile")
+	require.NoError(t, r.Get(context.Background(), request.NamespacedName, vu))
+	assert.True(t, vu.Status.Pods[0].Stable)
+
+	pod.Status.Phase = corev1.PodPending
+	require.NoError(t, r.Status().Update(context.Background(), pod))
+	result, err = r.Reconcile(context.Background(), request)
+	require.NoError(t, err)
+	assert.Equal(t, opsv1alpha1.DefaultInterval, result.RequeueAfter, "a pod that is not running is checked again")
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+// LogLevelPath is where the runtime log-level endpoint is served. GET
+// returns the current level and PUT changes it, e.g. {"level":"debug"}.
+const LogLevelPath = "/debug/loglevel"
+
+// AtomicLevel returns a log level that can be changed while the operator
+// runs and installs it in opts, so loggers built from opts follow it. A level
+// set with --zap-log-level is already atomic and is returned as is; otherwise
+// the zap defaults apply.
+func AtomicLevel(opts *zap.Options) uberzap.AtomicLevel {
+	if level, ok := opts.Level.(uberzap.AtomicLevel); ok {
+		return level
+	}
+
+	initial := zapcore.InfoLevel
+	if opts.Development {
+		initial = zapcore.DebugLevel
+	}
+	level := uberzap.NewAtomicLevelAt(initial)
+	opts.Level = level
+	return level
+}
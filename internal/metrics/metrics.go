@@ -17,6 +17,8 @@ limitations under the License.
 package metrics
 
 import (
+	"runtime"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
@@ -49,6 +51,42 @@ var (
 		[]string{"vaultunsealer", "namespace", "pod", "status"},
 	)
 
+	// UnsealKeySubmissions tracks the outcome of each key share submitted to a pod
+	UnsealKeySubmissions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "vault_unsealer_unseal_key_submissions_total",
+			Help: "Total number of unseal key share submissions by key index and result",
+		},
+		[]string{"vaultunsealer", "namespace", "pod", "key_index", "result"},
+	)
+
+	// VaultResponses counts Vault API responses by endpoint and status class
+	VaultResponses = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "vault_unsealer_vault_responses_total",
+			Help: "Vault API responses by endpoint and status class (2xx, 4xx, 5xx, or error when no response was received)",
+		},
+		[]string{"vaultunsealer", "namespace", "endpoint", "status_class"},
+	)
+
+	// PodSealed tracks pod seal status observed by the seal watcher
+	PodSealed = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "vault_unsealer_pod_sealed",
+			Help: "Seal status observed by the seal watcher (1=sealed, 0=unsealed)",
+		},
+		[]string{"vaultunsealer", "namespace", "pod"},
+	)
+
+	// SealProgress tracks how far a sealed pod is through its unseal, as a fraction of the threshold
+	SealProgress = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "vault_unsealer_seal_progress",
+			Help: "Unseal progress of a sealed pod as a fraction of the key threshold (0=no keys accepted yet or unsealed)",
+		},
+		[]string{"vaultunsealer", "namespace", "pod"},
+	)
+
 	// PodsUnsealed tracks number of successfully unsealed pods
 	PodsUnsealed = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -58,6 +96,15 @@ var (
 		[]string{"vaultunsealer", "namespace"},
 	)
 
+	// AllPodsUnsealed reports whether every Vault pod of a VaultUnsealer is unsealed
+	AllPodsUnsealed = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "vault_unsealer_all_pods_unsealed",
+			Help: "Whether every Vault pod of the VaultUnsealer is unsealed (1=yes, 0=no)",
+		},
+		[]string{"vaultunsealer", "namespace"},
+	)
+
 	// PodsChecked tracks number of pods checked
 	PodsChecked = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -76,6 +123,33 @@ var (
 		[]string{"vaultunsealer", "namespace"},
 	)
 
+	// ResidentKeyBytes tracks unseal key bytes held in the operator's Secret cache
+	ResidentKeyBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "vault_unsealer_resident_key_bytes",
+			Help: "Bytes of unseal key data of a key Secret held in the operator's Secret cache",
+		},
+		[]string{"namespace", "secret"},
+	)
+
+	// ResidentKeySeconds tracks how long the cached copy of a key Secret has been held
+	ResidentKeySeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "vault_unsealer_resident_key_seconds",
+			Help: "Seconds the current copy of a key Secret has been held in the operator's Secret cache",
+		},
+		[]string{"namespace", "secret"},
+	)
+
+	// UnsealKeysAge tracks time since the oldest key Secret was modified
+	UnsealKeysAge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "vault_unsealer_unseal_keys_age_seconds",
+			Help: "Seconds since the least recently modified unseal key Secret changed",
+		},
+		[]string{"vaultunsealer", "namespace"},
+	)
+
 	// ReconciliationDuration tracks reconciliation duration
 	ReconciliationDuration = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -94,6 +168,50 @@ var (
 		},
 		[]string{"vaultunsealer", "namespace", "pod"},
 	)
+
+	// VaultRateLimited counts requests to a pod that a Vault rate limit quota refused
+	VaultRateLimited = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "vault_unsealer_vault_rate_limited_total",
+			Help: "Total number of pod checks throttled by a Vault rate limit quota (429 Too Many Requests)",
+		},
+		[]string{"vaultunsealer", "namespace", "pod"},
+	)
+
+	// GlobalPause reports whether the operator-wide kill switch is engaged
+	GlobalPause = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "vault_unsealer_global_pause",
+			Help: "Whether key submission is paused for every VaultUnsealer (1=paused, 0=not paused)",
+		},
+	)
+
+	// BuildInfo exposes the running operator's build as labels on a constant 1
+	BuildInfo = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "vault_unsealer_build_info",
+			Help: "Build information of the running operator, always 1",
+		},
+		[]string{"version", "commit", "goversion"},
+	)
+
+	// WebhookValidations tracks admission validations by outcome (allowed, warned, denied)
+	WebhookValidations = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "vault_unsealer_webhook_validations_total",
+			Help: "Total number of admission validations by operation and outcome",
+		},
+		[]string{"operation", "outcome"},
+	)
+
+	// WebhookValidationFailures tracks which validation rules reject resources
+	WebhookValidationFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "vault_unsealer_webhook_validation_failures_total",
+			Help: "Total number of admission validation errors by field and reason",
+		},
+		[]string{"field", "reason"},
+	)
 )
 
 func init() {
@@ -102,10 +220,29 @@ func init() {
 		ReconciliationTotal,
 		ReconciliationErrors,
 		UnsealAttempts,
+		UnsealKeySubmissions,
+		VaultResponses,
+		PodSealed,
+		SealProgress,
 		PodsUnsealed,
+		AllPodsUnsealed,
 		PodsChecked,
 		UnsealKeysLoaded,
+		UnsealKeysAge,
+		ResidentKeyBytes,
+		ResidentKeySeconds,
 		ReconciliationDuration,
 		VaultConnectionStatus,
+		VaultRateLimited,
+		WebhookValidations,
+		WebhookValidationFailures,
+		BuildInfo,
+		GlobalPause,
 	)
 }
+
+// SetBuildInfo publishes the operator version and commit on BuildInfo.
+func SetBuildInfo(version, commit string) {
+	BuildInfo.Reset()
+	BuildInfo.WithLabelValues(version, commit, runtime.Version()).Set(1)
+}
@@ -0,0 +1,23 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rbac groups the RBAC markers of optional features in packages of
+// their own, so controller-gen can generate a role per feature next to the
+// combined manager role. The packages contain no code.
+//
+// A feature whose code lives in its own package, such as the admin API in
+// internal/admin, keeps its markers there instead.
+package rbac
@@ -0,0 +1,152 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schedule evaluates the cron schedules used by maintenance windows.
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxWindow bounds how far back Active looks for the opening of a window.
+const maxWindow = 31 * 24 * time.Hour
+
+// Schedule is a parsed five-field cron expression:
+// minute, hour, day of month, month and day of week.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+	// domStar and dowStar record unrestricted day fields. As in cron, when
+	// both day fields are restricted a time matches if either one does.
+	domStar, dowStar bool
+}
+
+type bounds struct{ min, max int }
+
+var fieldBounds = []bounds{
+	{0, 59}, // minute
+	{0, 23}, // hour
+	{1, 31}, // day of month
+	{1, 12}, // month
+	{0, 7},  // day of week, 0 and 7 are Sunday
+}
+
+// Parse parses a five-field cron expression. Each field accepts "*", values,
+// ranges ("1-5"), steps ("*/15", "0-30/10") and comma-separated lists.
+func Parse(expr string) (*Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields in schedule %q, got %d", expr, len(fields))
+	}
+
+	bits := make([]uint64, len(fields))
+	for i, f := range fields {
+		b, err := parseField(f, fieldBounds[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %w", expr, err)
+		}
+		bits[i] = b
+	}
+
+	// Fold day-of-week 7 onto Sunday.
+	if bits[4]&(1<<7) != 0 {
+		bits[4] = bits[4]&^(1<<7) | 1
+	}
+
+	return &Schedule{
+		minute:  bits[0],
+		hour:    bits[1],
+		dom:     bits[2],
+		month:   bits[3],
+		dow:     bits[4],
+		domStar: fields[2] == "*",
+		dowStar: fields[4] == "*",
+	}, nil
+}
+
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			rangePart, step = part[:i], n
+		}
+
+		lo, hi := b.min, b.max
+		if rangePart != "*" {
+			var err error
+			if i := strings.Index(rangePart, "-"); i >= 0 {
+				if lo, err = strconv.Atoi(rangePart[:i]); err == nil {
+					hi, err = strconv.Atoi(rangePart[i+1:])
+				}
+			} else {
+				lo, err = strconv.Atoi(rangePart)
+				hi = lo
+				if step > 1 {
+					hi = b.max
+				}
+			}
+			if err != nil {
+				return 0, fmt.Errorf("invalid value in %q", part)
+			}
+		}
+		if lo < b.min || hi > b.max || lo > hi {
+			return 0, fmt.Errorf("%q is outside %d-%d", part, b.min, b.max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// Matches reports whether the schedule fires in the minute containing t.
+func (s *Schedule) Matches(t time.Time) bool {
+	if s.minute&(1<<uint(t.Minute())) == 0 ||
+		s.hour&(1<<uint(t.Hour())) == 0 ||
+		s.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// Active reports whether t falls inside a window that opens whenever the
+// schedule fires and stays open for d. Times are matched in t's location.
+func (s *Schedule) Active(t time.Time, d time.Duration) bool {
+	if d > maxWindow {
+		d = maxWindow
+	}
+	start := t.Truncate(time.Minute)
+	for opened := start; t.Sub(opened) < d; opened = opened.Add(-time.Minute) {
+		if s.Matches(opened) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *"} {
+		_, err := Parse(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestSchedule_Matches(t *testing.T) {
+	// Tuesday 2025-03-04 02:30 UTC.
+	at := time.Date(2025, 3, 4, 2, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{"* * * * *", true},
+		{"30 2 * * *", true},
+		{"*/15 * * * *", true},
+		{"0-20/10 * * * *", false},
+		{"30 2 * * 2", true},
+		{"30 2 * * 1,3-5", false},
+		{"30 2 4 * *", true},
+		{"30 2 * 1-2 *", false},
+		// With both day fields restricted, either may match.
+		{"30 2 1 * 2", true},
+		{"30 2 1 * 0", false},
+		{"30 2 * * 7", false},
+	}
+	for _, tt := range tests {
+		s, err := Parse(tt.expr)
+		require.NoError(t, err, tt.expr)
+		assert.Equal(t, tt.want, s.Matches(at), tt.expr)
+	}
+
+	sunday, err := Parse("0 0 * * 7")
+	require.NoError(t, err)
+	assert.True(t, sunday.Matches(time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestSchedule_Active(t *testing.T) {
+	s, err := Parse("0 2 * * *")
+	require.NoError(t, err)
+
+	day := time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)
+	assert.False(t, s.Active(day.Add(1*time.Hour+59*time.Minute), time.Hour))
+	assert.True(t, s.Active(day.Add(2*time.Hour), time.Hour))
+	assert.True(t, s.Active(day.Add(2*time.Hour+59*time.Minute), time.Hour))
+	assert.False(t, s.Active(day.Add(3*time.Hour), time.Hour))
+
+	// Schedules are evaluated in the location of the time passed in.
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+	assert.True(t, s.Active(time.Date(2025, 3, 4, 17, 30, 0, 0, time.UTC).In(tokyo), time.Hour))
+}
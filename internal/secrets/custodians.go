@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// ErrCustodianQuorum is returned when fewer custodians than
+// spec.minCustodians contribute shares.
+var ErrCustodianQuorum = errors.New("custodian quorum not met")
+
+// CustodianContribution reports how many shares one custodian holds and how
+// many of them were used. It never carries the shares themselves.
+type CustodianContribution struct {
+	Name      string
+	Available int
+	Used      int
+}
+
+// LoadCustodianKeys loads the unseal keys held by the custodians of
+// vaultUnsealer. Shares are taken from each custodian in turn, at most
+// maxShares apiece, until keyThreshold are held, so they come from as many
+// custodians as possible. The contributions are returned even on error so a
+// custodian falling short can be reported; the error wraps
+// ErrCustodianQuorum when fewer than spec.minCustodians contributed.
+func (l *Loader) LoadCustodianKeys(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) ([]SecretString, []SkippedKey, []CustodianContribution, error) {
+	spec := vaultUnsealer.Spec
+	contributions := make([]CustodianContribution, len(spec.Custodians))
+	shares := make([][]string, len(spec.Custodians))
+	var skipped []SkippedKey
+
+	// Every custodian's Secret is read: the shares must be spread across
+	// them, so stopping at the threshold would favour the first custodians.
+	for i, custodian := range spec.Custodians {
+		contributions[i].Name = custodian.Name
+		keys, err := l.loadKeysFromSecret(ctx, vaultUnsealer.Namespace, spec.SecretsServiceAccountName, custodian.SecretRef)
+		if err != nil {
+			return nil, nil, contributions, fmt.Errorf("failed to load keys of custodian %s: %w", custodian.Name, err)
+		}
+		seen := make(map[string]bool, len(keys))
+		for j, key := range keys {
+			share, err := normalizeShare(key)
+			if err != nil {
+				skipped = append(skipped, SkippedKey{Source: "custodian " + custodian.Name, Entry: j + 1, Reason: err.Error()})
+				continue
+			}
+			if !seen[share] {
+				seen[share] = true
+				shares[i] = append(shares[i], share)
+			}
+		}
+		contributions[i].Available = len(shares[i])
+		if custodian.MaxShares > 0 && len(shares[i]) > int(custodian.MaxShares) {
+			shares[i] = shares[i][:custodian.MaxShares]
+		}
+	}
+
+	var allKeys []SecretString
+	keySet := make(map[string]bool)
+	enough := func() bool {
+		return spec.KeyThreshold > 0 && len(allKeys) >= spec.KeyThreshold
+	}
+	for round := 0; !enough(); round++ {
+		added := false
+		for i := range shares {
+			if enough() {
+				break
+			}
+			if round >= len(shares[i]) {
+				continue
+			}
+			added = true
+			// A share escrowed with two custodians counts for the first.
+			if share := shares[i][round]; !keySet[share] {
+				keySet[share] = true
+				allKeys = append(allKeys, NewSecretString(share))
+				contributions[i].Used++
+			}
+		}
+		if !added {
+			break
+		}
+	}
+
+	contributing := 0
+	for _, contribution := range contributions {
+		if contribution.Used > 0 {
+			contributing++
+		}
+	}
+	if contributing < int(spec.MinCustodians) {
+		return nil, skipped, contributions, fmt.Errorf("%w: %d of %d custodians contributed shares, spec.minCustodians requires %d",
+			ErrCustodianQuorum, contributing, len(spec.Custodians), spec.MinCustodians)
+	}
+	if len(allKeys) == 0 {
+		if len(skipped) > 0 {
+			return nil, skipped, contributions, fmt.Errorf("no valid unseal keys held by any custodian, %d invalid entries skipped", len(skipped))
+		}
+		return nil, nil, contributions, fmt.Errorf("no unseal keys held by any custodian")
+	}
+	return allKeys, skipped, contributions, nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// ErrAccessNotGranted is returned when a VaultUnsealer references a Secret in a
+// namespace that has not granted its namespace access.
+var ErrAccessNotGranted = errors.New("secret access not granted")
+
+// RequireGrants makes the Loader refuse cross-namespace Secrets unless the
+// Secret's namespace lists the VaultUnsealer's namespace in its
+// AnnotationAllowedUnsealerNamespaces annotation.
+func (l *Loader) RequireGrants() *Loader {
+	l.requireGrants = true
+	return l
+}
+
+// checkGrant verifies that namespace allows VaultUnsealers in crNamespace to
+// read its Secrets.
+func (l *Loader) checkGrant(ctx context.Context, crNamespace, namespace string) error {
+	if !l.requireGrants || namespace == crNamespace {
+		return nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := l.client.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+	}
+	if namespaceAllowed(ns.Annotations[opsv1alpha1.AnnotationAllowedUnsealerNamespaces], crNamespace) {
+		return nil
+	}
+	return fmt.Errorf("%w: namespace %s does not list %s in its %s annotation",
+		ErrAccessNotGranted, namespace, crNamespace, opsv1alpha1.AnnotationAllowedUnsealerNamespaces)
+}
+
+func namespaceAllowed(allowList, namespace string) bool {
+	for _, entry := range strings.Split(allowList, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "*" || entry == namespace {
+			return true
+		}
+	}
+	return false
+}
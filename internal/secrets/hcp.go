@@ -0,0 +1,218 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// HCP endpoints used by default.
+const (
+	DefaultHCPAuthURL = "https://auth.idp.hashicorp.com/oauth2/token"
+	DefaultHCPAPIURL  = "https://api.cloud.hashicorp.com"
+)
+
+const (
+	hcpAudience = "https://api.hashicorp.cloud"
+	// hcpTokenExpiryMargin renews cached access tokens this long before they
+	// expire, so a token does not lapse mid-request.
+	hcpTokenExpiryMargin = time.Minute
+)
+
+// HCPClient reads static secrets from HCP Vault Secrets with service
+// principal credentials. Access tokens are cached per client ID and secret,
+// so reconciles do not request a new token every time and a caller only
+// gets a cached token by presenting the secret it was issued for.
+type HCPClient struct {
+	HTTPClient *http.Client
+	AuthURL    string
+	APIURL     string
+
+	mu     sync.Mutex
+	tokens map[string]hcpToken
+}
+
+type hcpToken struct {
+	value  SecretString
+	expiry time.Time
+}
+
+// NewHCPClient returns a client for the public HCP endpoints.
+func NewHCPClient() *HCPClient {
+	return &HCPClient{
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+		AuthURL:    DefaultHCPAuthURL,
+		APIURL:     DefaultHCPAPIURL,
+	}
+}
+
+// OpenSecret returns the current value of the static secret name in source's app.
+func (c *HCPClient) OpenSecret(ctx context.Context, clientID string, clientSecret SecretString, source *opsv1alpha1.HCPVaultSecretsSource, name string) (SecretString, error) {
+	token, err := c.token(ctx, clientID, clientSecret)
+	if err != nil {
+		return SecretString{}, err
+	}
+
+	secretURL := fmt.Sprintf("%s/secrets/2023-11-28/organizations/%s/projects/%s/apps/%s/secrets/%s:open",
+		strings.TrimSuffix(c.APIURL, "/"), url.PathEscape(source.OrganizationID), url.PathEscape(source.ProjectID),
+		url.PathEscape(source.AppName), url.PathEscape(name))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, secretURL, nil)
+	if err != nil {
+		return SecretString{}, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token.Reveal())
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return SecretString{}, fmt.Errorf("failed to open HCP secret %s: %w", name, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		c.forgetToken(clientID, clientSecret)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return SecretString{}, fmt.Errorf("failed to open HCP secret %s: status %d", name, resp.StatusCode)
+	}
+
+	var body struct {
+		Secret struct {
+			StaticVersion struct {
+				Value string `json:"value"`
+			} `json:"static_version"`
+		} `json:"secret"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return SecretString{}, fmt.Errorf("failed to decode HCP secret %s: %w", name, err)
+	}
+	if body.Secret.StaticVersion.Value == "" {
+		return SecretString{}, fmt.Errorf("HCP secret %s has no static value", name)
+	}
+	return NewSecretString(body.Secret.StaticVersion.Value), nil
+}
+
+// hcpTokenKey is the token cache key for a service principal. It covers the
+// client secret, hashed, so a client ID alone never yields a cached token.
+func hcpTokenKey(clientID string, clientSecret SecretString) string {
+	sum := sha256.Sum256([]byte(clientSecret.Reveal()))
+	return clientID + "/" + hex.EncodeToString(sum[:])
+}
+
+// token returns a cached access token for the credentials or requests a new
+// one with the client credentials grant.
+func (c *HCPClient) token(ctx context.Context, clientID string, clientSecret SecretString) (SecretString, error) {
+	key := hcpTokenKey(clientID, clientSecret)
+	c.mu.Lock()
+	cached, ok := c.tokens[key]
+	c.mu.Unlock()
+	if ok && time.Now().Before(cached.expiry) {
+		return cached.value, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {clientID},
+		"client_secret": {clientSecret.Reveal()},
+		"audience":      {hcpAudience},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.AuthURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return SecretString{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return SecretString{}, fmt.Errorf("failed to authenticate to HCP: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return SecretString{}, fmt.Errorf("failed to authenticate to HCP: status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return SecretString{}, fmt.Errorf("failed to decode HCP token response: %w", err)
+	}
+	if body.AccessToken == "" {
+		return SecretString{}, fmt.Errorf("HCP token response has no access token")
+	}
+
+	token := hcpToken{
+		value:  NewSecretString(body.AccessToken),
+		expiry: time.Now().Add(time.Duration(body.ExpiresIn)*time.Second - hcpTokenExpiryMargin),
+	}
+	c.mu.Lock()
+	if c.tokens == nil {
+		c.tokens = make(map[string]hcpToken)
+	}
+	c.tokens[key] = token
+	c.mu.Unlock()
+	return token.value, nil
+}
+
+func (c *HCPClient) forgetToken(clientID string, clientSecret SecretString) {
+	c.mu.Lock()
+	delete(c.tokens, hcpTokenKey(clientID, clientSecret))
+	c.mu.Unlock()
+}
+
+// loadKeysFromHCP reads the service principal credentials from the Secret in
+// namespace and returns the keys held in the source's secrets.
+func (l *Loader) loadKeysFromHCP(ctx context.Context, namespace string, source *opsv1alpha1.HCPVaultSecretsSource) ([]string, error) {
+	credentials := &corev1.Secret{}
+	if err := l.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: source.CredentialsSecretName}, credentials); err != nil {
+		return nil, fmt.Errorf("failed to get HCP credentials secret: %w", err)
+	}
+	clientID := string(credentials.Data[opsv1alpha1.HCPClientIDKey])
+	clientSecret := NewSecretString(string(credentials.Data[opsv1alpha1.HCPClientSecretKey]))
+	if clientID == "" || clientSecret.Reveal() == "" {
+		return nil, fmt.Errorf("HCP credentials secret %s must set %s and %s",
+			source.CredentialsSecretName, opsv1alpha1.HCPClientIDKey, opsv1alpha1.HCPClientSecretKey)
+	}
+
+	var keys []string
+	for _, name := range source.SecretNames {
+		value, err := l.hcp.OpenSecret(ctx, clientID, clientSecret, source, name)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := l.parseKeys(value.Reveal())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse HCP secret %s: %w", name, err)
+		}
+		keys = append(keys, parsed...)
+	}
+	return keys, nil
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+
+	"github.com/onsi/ginkgo/v2"
+	"github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// newFakeHCP serves the HCP token and secret-open endpoints for one service
+// principal and the given secrets of app "unseal" in org "org", project "proj".
+func newFakeHCP(secretValues map[string]string, tokenRequests *atomic.Int32) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests.Add(1)
+		if r.FormValue("grant_type") != "client_credentials" || r.FormValue("client_id") != "sp-id" || r.FormValue("client_secret") != "sp-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"access_token": "hcp-token", "expires_in": 3600})
+	})
+	mux.HandleFunc("GET /secrets/2023-11-28/organizations/org/projects/proj/apps/unseal/secrets/{open}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer hcp-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		name, ok := strings.CutSuffix(r.PathValue("open"), ":open")
+		value, found := secretValues[name]
+		if !ok || !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"secret": map[string]any{"name": name, "static_version": map[string]any{"value": value}}})
+	})
+	return httptest.NewServer(mux)
+}
+
+var _ = ginkgo.Describe("HCP Vault Secrets", func() {
+	var (
+		ctx           context.Context
+		server        *httptest.Server
+		tokenRequests atomic.Int32
+		k8sClient     client.Client
+		loader        *Loader
+		vaultUnsealer *opsv1alpha1.VaultUnsealer
+	)
+
+	ginkgo.BeforeEach(func() {
+		ctx = context.Background()
+		tokenRequests.Store(0)
+		server = newFakeHCP(map[string]string{
+			"shares-a": `["key1", "key2"]`,
+			"shares-b": "key2\nkey3",
+		}, &tokenRequests)
+
+		scheme := runtime.NewScheme()
+		gomega.Expect(corev1.AddToScheme(scheme)).To(gomega.Succeed())
+		credentials := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "hcp-sp", Namespace: "test"},
+			Data: map[string][]byte{
+				opsv1alpha1.HCPClientIDKey:     []byte("sp-id"),
+				opsv1alpha1.HCPClientSecretKey: []byte("sp-secret"),
+			},
+		}
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(credentials).Build()
+		loader = NewLoader(k8sClient).WithHCPClient(&HCPClient{
+			HTTPClient: server.Client(),
+			AuthURL:    server.URL + "/oauth2/token",
+			APIURL:     server.URL,
+		})
+
+		vaultUnsealer = opsv1alpha1.NewVaultUnsealer("test", "main")
+		vaultUnsealer.Spec.HCPVaultSecrets = &opsv1alpha1.HCPVaultSecretsSource{
+			OrganizationID:        "org",
+			ProjectID:             "proj",
+			AppName:               "unseal",
+			SecretNames:           []string{"shares-a", "shares-b"},
+			CredentialsSecretName: "hcp-sp",
+		}
+	})
+
+	ginkgo.AfterEach(func() {
+		server.Close()
+	})
+
+	ginkgo.It("should load and deduplicate keys from every secret", func() {
+		keys, err := loader.LoadUnsealKeysFor(ctx, vaultUnsealer)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(revealed(keys)).To(gomega.Equal([]string{"key1", "key2", "key3"}))
+	})
+
+	ginkgo.It("should reuse the access token across loads", func() {
+		for range 3 {
+			_, err := loader.LoadUnsealKeysFor(ctx, vaultUnsealer)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		}
+		gomega.Expect(tokenRequests.Load()).To(gomega.Equal(int32(1)))
+	})
+
+	ginkgo.It("should fail when a secret does not exist", func() {
+		vaultUnsealer.Spec.HCPVaultSecrets.SecretNames = []string{"missing"}
+		_, err := loader.LoadUnsealKeysFor(ctx, vaultUnsealer)
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("status 404")))
+	})
+
+	ginkgo.It("should fail when the credentials are missing", func() {
+		vaultUnsealer.Spec.HCPVaultSecrets.CredentialsSecretName = "absent"
+		_, err := loader.LoadUnsealKeysFor(ctx, vaultUnsealer)
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("HCP credentials secret")))
+	})
+
+	ginkgo.It("should fail when the service principal is rejected", func() {
+		gomega.Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "hcp-sp-stale", Namespace: "test"},
+			Data: map[string][]byte{
+				opsv1alpha1.HCPClientIDKey:     []byte("sp-id"),
+				opsv1alpha1.HCPClientSecretKey: []byte("rotated-away"),
+			},
+		})).To(gomega.Succeed())
+		vaultUnsealer.Spec.HCPVaultSecrets.CredentialsSecretName = "hcp-sp-stale"
+
+		_, err := loader.LoadUnsealKeysFor(ctx, vaultUnsealer)
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("failed to authenticate to HCP: status 401")))
+	})
+
+	ginkgo.It("should not hand a cached token to a client ID with the wrong secret", func() {
+		_, err := loader.LoadUnsealKeysFor(ctx, vaultUnsealer)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(tokenRequests.Load()).To(gomega.Equal(int32(1)))
+
+		gomega.Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "hcp-sp-guess", Namespace: "test"},
+			Data: map[string][]byte{
+				opsv1alpha1.HCPClientIDKey:     []byte("sp-id"),
+				opsv1alpha1.HCPClientSecretKey: []byte("guessed"),
+			},
+		})).To(gomega.Succeed())
+		vaultUnsealer.Spec.HCPVaultSecrets.CredentialsSecretName = "hcp-sp-guess"
+
+		_, err = loader.LoadUnsealKeysFor(ctx, vaultUnsealer)
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("failed to authenticate to HCP: status 401")))
+		gomega.Expect(tokenRequests.Load()).To(gomega.Equal(int32(2)))
+	})
+
+	ginkgo.It("should combine HCP keys with Secret references", func() {
+		gomega.Expect(k8sClient.Create(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "local", Namespace: "test"},
+			Data:       map[string][]byte{"keys": []byte("key0")},
+		})).To(gomega.Succeed())
+		vaultUnsealer.Spec.UnsealKeysSecretRefs = []opsv1alpha1.SecretRef{{Name: "local", Key: "keys"}}
+
+		keys, err := loader.LoadUnsealKeysFor(ctx, vaultUnsealer)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(revealed(keys)).To(gomega.Equal([]string{"key0", "key1", "key2", "key3"}))
+	})
+})
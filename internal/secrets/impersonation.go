@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClientFactory returns a client that acts as the named ServiceAccount.
+type ClientFactory func(namespace, serviceAccount string) (client.Client, error)
+
+// NewImpersonatingClientFactory returns a ClientFactory that impersonates
+// ServiceAccounts using cfg. Clients are uncached, so every read is
+// authorized against the impersonated ServiceAccount, and are reused per
+// ServiceAccount.
+func NewImpersonatingClientFactory(cfg *rest.Config, scheme *runtime.Scheme, mapper meta.RESTMapper) ClientFactory {
+	var mu sync.Mutex
+	clients := map[string]client.Client{}
+
+	return func(namespace, serviceAccount string) (client.Client, error) {
+		username := ServiceAccountUsername(namespace, serviceAccount)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if c, ok := clients[username]; ok {
+			return c, nil
+		}
+
+		impersonated := rest.CopyConfig(cfg)
+		impersonated.Impersonate = rest.ImpersonationConfig{UserName: username}
+		httpClient, err := rest.HTTPClientFor(impersonated)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create HTTP client impersonating %s: %w", username, err)
+		}
+		c, err := client.New(impersonated, client.Options{HTTPClient: httpClient, Scheme: scheme, Mapper: mapper})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client impersonating %s: %w", username, err)
+		}
+		clients[username] = c
+		return c, nil
+	}
+}
+
+// ServiceAccountUsername returns the username Kubernetes authenticates the
+// ServiceAccount as.
+func ServiceAccountUsername(namespace, serviceAccount string) string {
+	return fmt.Sprintf("system:serviceaccount:%s:%s", namespace, serviceAccount)
+}
@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -29,65 +30,232 @@ import (
 	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
 )
 
+// Loader reads unseal keys into memory. It only ever holds a client.Reader,
+// so loading keys can never write them, or anything else, to a cluster.
 type Loader struct {
-	client client.Client
+	client        client.Reader
+	impersonate   ClientFactory
+	requireGrants bool
+	hcp           *HCPClient
 }
 
-func NewLoader(client client.Client) *Loader {
-	return &Loader{client: client}
+func NewLoader(client client.Reader) *Loader {
+	return &Loader{client: client, hcp: NewHCPClient()}
 }
 
-func (l *Loader) LoadUnsealKeys(ctx context.Context, namespace string, secretRefs []opsv1alpha1.SecretRef, keyThreshold int) ([]string, error) {
-	var allKeys []string
+// WithHCPClient replaces the client used to read HCP Vault Secrets.
+func (l *Loader) WithHCPClient(hcp *HCPClient) *Loader {
+	l.hcp = hcp
+	return l
+}
+
+// WithImpersonation lets the Loader read Secrets as a ServiceAccount.
+func (l *Loader) WithImpersonation(factory ClientFactory) *Loader {
+	l.impersonate = factory
+	return l
+}
+
+// LoadUnsealKeys merges the keys of secretRefs in order, dropping duplicates.
+// With a positive keyThreshold, Secrets after the one that completes the
+// threshold are not read.
+func (l *Loader) LoadUnsealKeys(ctx context.Context, namespace string, secretRefs []opsv1alpha1.SecretRef, keyThreshold int) ([]SecretString, error) {
+	return l.LoadUnsealKeysAs(ctx, namespace, "", secretRefs, keyThreshold)
+}
+
+// LoadUnsealKeysAs is LoadUnsealKeys, except that Secrets outside namespace
+// are read as serviceAccount. The operator's own permissions are then never
+// used to reach into another namespace, so only Secrets the ServiceAccount
+// has been granted can be referenced. An empty serviceAccount reads every
+// Secret with the operator's client.
+func (l *Loader) LoadUnsealKeysAs(ctx context.Context, namespace, serviceAccount string, secretRefs []opsv1alpha1.SecretRef, keyThreshold int) ([]SecretString, error) {
+	keys, _, err := l.loadUnsealKeys(ctx, namespace, serviceAccount, secretRefs, nil, keyThreshold)
+	return keys, err
+}
+
+// LoadUnsealKeysFor loads the unseal keys of vaultUnsealer from its Secret
+// references and, when configured, from HCP Vault Secrets.
+func (l *Loader) LoadUnsealKeysFor(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) ([]SecretString, error) {
+	keys, _, err := l.LoadUnsealKeysChecked(ctx, vaultUnsealer)
+	return keys, err
+}
+
+// LoadUnsealKeysChecked is LoadUnsealKeysFor, also returning the entries that
+// were skipped for not looking like key shares, so they can be reported.
+// Keys of a VaultUnsealer with custodians are loaded by LoadCustodianKeys.
+func (l *Loader) LoadUnsealKeysChecked(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) ([]SecretString, []SkippedKey, error) {
+	if len(vaultUnsealer.Spec.Custodians) > 0 {
+		keys, skipped, _, err := l.LoadCustodianKeys(ctx, vaultUnsealer)
+		return keys, skipped, err
+	}
+	return l.loadUnsealKeys(ctx, vaultUnsealer.Namespace, vaultUnsealer.Spec.SecretsServiceAccountName,
+		vaultUnsealer.Spec.UnsealKeysSecretRefs, vaultUnsealer.Spec.HCPVaultSecrets, vaultUnsealer.Spec.KeyThreshold)
+}
+
+func (l *Loader) loadUnsealKeys(ctx context.Context, namespace, serviceAccount string, secretRefs []opsv1alpha1.SecretRef,
+	hcpSource *opsv1alpha1.HCPVaultSecretsSource, keyThreshold int) ([]SecretString, []SkippedKey, error) {
+	var allKeys []SecretString
+	var skipped []SkippedKey
 	keySet := make(map[string]bool)
+	// Sources are merged in order, so once keyThreshold distinct keys are
+	// held the remaining ones are never read into memory at all.
+	enough := func() bool {
+		return keyThreshold > 0 && len(allKeys) >= keyThreshold
+	}
+	addKeys := func(source string, keys []string) {
+		for i, key := range keys {
+			if enough() {
+				return
+			}
+			share, err := normalizeShare(key)
+			if err != nil {
+				skipped = append(skipped, SkippedKey{Source: source, Entry: i + 1, Reason: err.Error()})
+				continue
+			}
+			if !keySet[share] {
+				keySet[share] = true
+				allKeys = append(allKeys, NewSecretString(share))
+			}
+		}
+	}
 
 	for _, secretRef := range secretRefs {
-		keys, err := l.loadKeysFromSecret(ctx, namespace, secretRef)
+		if enough() {
+			break
+		}
+		keys, err := l.loadKeysFromSecret(ctx, namespace, serviceAccount, secretRef)
 		if err != nil {
-			return nil, fmt.Errorf("failed to load keys from secret %s/%s: %w", secretRef.Namespace, secretRef.Name, err)
+			return nil, nil, fmt.Errorf("failed to load keys from secret %s/%s: %w", secretRef.Namespace, secretRef.Name, err)
 		}
+		secretNamespace := secretRef.Namespace
+		if secretNamespace == "" {
+			secretNamespace = namespace
+		}
+		addKeys(fmt.Sprintf("secret %s/%s key %s", secretNamespace, secretRef.Name, secretRef.Key), keys)
+	}
 
-		for _, key := range keys {
-			if !keySet[key] {
-				keySet[key] = true
-				allKeys = append(allKeys, key)
-			}
+	if hcpSource != nil && !enough() {
+		keys, err := l.loadKeysFromHCP(ctx, namespace, hcpSource)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load keys from HCP Vault Secrets app %s: %w", hcpSource.AppName, err)
 		}
+		addKeys("HCP Vault Secrets app "+hcpSource.AppName, keys)
 	}
 
 	if len(allKeys) == 0 {
-		return nil, fmt.Errorf("no unseal keys found in any referenced secrets")
+		if len(skipped) > 0 {
+			return nil, skipped, fmt.Errorf("no valid unseal keys found in any referenced secrets, %d invalid entries skipped", len(skipped))
+		}
+		return nil, nil, fmt.Errorf("no unseal keys found in any referenced secrets")
+	}
+
+	return allKeys, skipped, nil
+}
+
+func (l *Loader) loadKeysFromSecret(ctx context.Context, defaultNamespace, serviceAccount string, secretRef opsv1alpha1.SecretRef) ([]string, error) {
+	secret, err := l.getSecret(ctx, defaultNamespace, serviceAccount, secretRef)
+	if err != nil {
+		return nil, err
+	}
+
+	data, ok := secret.Data[secretRef.Key]
+	if !ok {
+		return nil, fmt.Errorf("key %s not found in secret", secretRef.Key)
 	}
 
-	if keyThreshold > 0 && len(allKeys) > keyThreshold {
-		allKeys = allKeys[:keyThreshold]
+	return l.parseKeys(string(data))
+}
+
+// KeysetModifiedAt returns when the least recently modified key Secret of
+// vaultUnsealer last changed, so unrotated keys can be alerted on. Keys from
+// HCP Vault Secrets have no Kubernetes modification time and are not
+// considered; ok is false when no Secret is referenced.
+func (l *Loader) KeysetModifiedAt(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) (modifiedAt time.Time, ok bool, err error) {
+	for _, secretRef := range vaultUnsealer.Spec.KeySecretRefs() {
+		secret, err := l.getSecret(ctx, vaultUnsealer.Namespace, vaultUnsealer.Spec.SecretsServiceAccountName, secretRef)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("failed to read secret %s/%s: %w", secretRef.Namespace, secretRef.Name, err)
+		}
+		if t := SecretModifiedAt(secret); !ok || t.Before(modifiedAt) {
+			modifiedAt, ok = t, true
+		}
 	}
+	return modifiedAt, ok, nil
+}
+
+// SecretModifiedAt returns when the data of secret last changed. Kubernetes
+// keeps no such timestamp, so it is taken from the newest managedFields entry
+// that owns data or stringData, falling back to the creation time.
+func SecretModifiedAt(secret *corev1.Secret) time.Time {
+	modifiedAt, _ := lastDataWrite(secret)
+	return modifiedAt
+}
 
-	return allKeys, nil
+// lastDataWrite returns the time and field manager of the newest
+// managedFields entry that owns data or stringData. Without one it returns
+// the creation time and no manager.
+func lastDataWrite(secret *corev1.Secret) (time.Time, string) {
+	modifiedAt, manager := secret.CreationTimestamp.Time, ""
+	for _, entry := range secret.ManagedFields {
+		if entry.Time == nil || entry.FieldsV1 == nil {
+			continue
+		}
+		fields := string(entry.FieldsV1.Raw)
+		if !strings.Contains(fields, `"f:data"`) && !strings.Contains(fields, `"f:stringData"`) {
+			continue
+		}
+		if entry.Time.After(modifiedAt) || (manager == "" && entry.Time.Equal(&secret.CreationTimestamp)) {
+			modifiedAt, manager = entry.Time.Time, entry.Manager
+		}
+	}
+	return modifiedAt, manager
 }
 
-func (l *Loader) loadKeysFromSecret(ctx context.Context, defaultNamespace string, secretRef opsv1alpha1.SecretRef) ([]string, error) {
+// GetSecret reads the Secret behind secretRef on behalf of vaultUnsealer,
+// enforcing the same grants and impersonation as unseal key Secrets, for
+// other Secrets it references such as CA bundles.
+func (l *Loader) GetSecret(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer, secretRef opsv1alpha1.SecretRef) (*corev1.Secret, error) {
+	return l.getSecret(ctx, vaultUnsealer.Namespace, vaultUnsealer.Spec.SecretsServiceAccountName, secretRef)
+}
+
+// getSecret reads the Secret behind secretRef on behalf of a VaultUnsealer in
+// defaultNamespace, enforcing grants and impersonation.
+func (l *Loader) getSecret(ctx context.Context, defaultNamespace, serviceAccount string, secretRef opsv1alpha1.SecretRef) (*corev1.Secret, error) {
 	namespace := secretRef.Namespace
 	if namespace == "" {
 		namespace = defaultNamespace
 	}
 
+	if err := l.checkGrant(ctx, defaultNamespace, namespace); err != nil {
+		return nil, err
+	}
+
+	reader, err := l.readerFor(defaultNamespace, namespace, serviceAccount)
+	if err != nil {
+		return nil, err
+	}
+
 	secret := &corev1.Secret{}
 	namespacedName := types.NamespacedName{
 		Namespace: namespace,
 		Name:      secretRef.Name,
 	}
 
-	if err := l.client.Get(ctx, namespacedName, secret); err != nil {
+	if err := reader.Get(ctx, namespacedName, secret); err != nil {
 		return nil, fmt.Errorf("failed to get secret: %w", err)
 	}
+	return secret, nil
+}
 
-	data, ok := secret.Data[secretRef.Key]
-	if !ok {
-		return nil, fmt.Errorf("key %s not found in secret", secretRef.Key)
+// readerFor returns the client used to read a Secret in namespace on behalf
+// of a VaultUnsealer in crNamespace.
+func (l *Loader) readerFor(crNamespace, namespace, serviceAccount string) (client.Reader, error) {
+	if serviceAccount == "" || namespace == crNamespace {
+		return l.client, nil
 	}
-
-	return l.parseKeys(string(data))
+	if l.impersonate == nil {
+		return nil, fmt.Errorf("secrets service account %s is set but impersonation is not configured", serviceAccount)
+	}
+	return l.impersonate(crNamespace, serviceAccount)
 }
 
 func (l *Loader) parseKeys(data string) ([]string, error) {
@@ -101,6 +269,10 @@ func (l *Loader) parseKeys(data string) ([]string, error) {
 		return keys, nil
 	}
 
+	if isYAMLKeys(data) {
+		return parseYAMLKeys(data)
+	}
+
 	lines := strings.Split(data, "\n")
 	var keys []string
 	for _, line := range lines {
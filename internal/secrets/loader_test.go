@@ -19,12 +19,14 @@ package secrets
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -76,6 +78,44 @@ var _ = ginkgo.Describe("Secrets Loader", func() {
 			gomega.Expect(keys).To(gomega.Equal([]string{`["key1", "key2"`}))
 		})
 
+		ginkgo.It("should parse a YAML list", func() {
+			data := "- key1\n- key2\n- \"0123\"\n"
+			keys, err := loader.parseKeys(data)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(keys).To(gomega.Equal([]string{"key1", "key2", "0123"}))
+		})
+
+		ginkgo.It("should collect keys fields from multi-document YAML", func() {
+			data := `---
+cluster: prod
+keys:
+  - key1
+  - key2
+---
+# bootstrap data without keys
+rootTokenPath: /bootstrap/root
+---
+keys: [key3]
+`
+			keys, err := loader.parseKeys(data)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(keys).To(gomega.Equal([]string{"key1", "key2", "key3"}))
+		})
+
+		ginkgo.It("should keep numeric-looking YAML keys as written", func() {
+			keys, err := loader.parseKeys("- 12e45\n- 0x1f\n")
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(keys).To(gomega.Equal([]string{"12e45", "0x1f"}))
+		})
+
+		ginkgo.It("should reject YAML without keys or with nested keys", func() {
+			_, err := loader.parseKeys("---\nrootTokenPath: /bootstrap/root\n")
+			gomega.Expect(err).To(gomega.HaveOccurred())
+
+			_, err = loader.parseKeys("keys:\n  - [key1]\n")
+			gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("keys must be strings")))
+		})
+
 		ginkgo.It("should return error for empty data", func() {
 			data := ""
 			_, err := loader.parseKeys(data)
@@ -84,6 +124,41 @@ var _ = ginkgo.Describe("Secrets Loader", func() {
 	})
 
 	ginkgo.Context("LoadUnsealKeys", func() {
+		ginkgo.It("should skip entries that are not key shares", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "test"},
+				Data: map[string][]byte{
+					"keys": []byte("# production shares\n\"key1\",\nUnseal Key 2: key2\nkey3\n"),
+				},
+			}
+			gomega.Expect(k8sClient.Create(ctx, secret)).To(gomega.Succeed())
+
+			vaultUnsealer := opsv1alpha1.NewVaultUnsealer("test", "main").WithUnsealKeysSecret("vault-keys", "keys")
+			keys, skipped, err := loader.LoadUnsealKeysChecked(ctx, vaultUnsealer)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(keys).To(gomega.HaveLen(2))
+			gomega.Expect(keys[0].Reveal()).To(gomega.Equal("key1"))
+			gomega.Expect(keys[1].Reveal()).To(gomega.Equal("key3"))
+			gomega.Expect(skipped).To(gomega.HaveLen(2))
+			gomega.Expect(skipped[0].String()).To(gomega.Equal(
+				"secret test/vault-keys key keys entry 1: contains characters outside the hex and base64 alphabets"))
+			gomega.Expect(skipped[1].Entry).To(gomega.Equal(3))
+			gomega.Expect(skipped[1].String()).NotTo(gomega.ContainSubstring("key2"), "entries are never echoed")
+		})
+
+		ginkgo.It("should fail when every entry is invalid", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "vault-keys", Namespace: "test"},
+				Data:       map[string][]byte{"keys": []byte("not a share\n")},
+			}
+			gomega.Expect(k8sClient.Create(ctx, secret)).To(gomega.Succeed())
+
+			vaultUnsealer := opsv1alpha1.NewVaultUnsealer("test", "main").WithUnsealKeysSecret("vault-keys", "keys")
+			_, skipped, err := loader.LoadUnsealKeysChecked(ctx, vaultUnsealer)
+			gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("1 invalid entries skipped")))
+			gomega.Expect(skipped).To(gomega.HaveLen(1))
+		})
+
 		ginkgo.It("should load keys from multiple secrets", func() {
 			// Create test secrets
 			secret1 := &corev1.Secret{
@@ -115,7 +190,7 @@ var _ = ginkgo.Describe("Secrets Loader", func() {
 
 			keys, err := loader.LoadUnsealKeys(ctx, "test", secretRefs, 0)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
-			gomega.Expect(keys).To(gomega.ConsistOf("key1", "key2", "key3", "key4"))
+			gomega.Expect(revealed(keys)).To(gomega.ConsistOf("key1", "key2", "key3", "key4"))
 		})
 
 		ginkgo.It("should deduplicate keys", func() {
@@ -139,7 +214,27 @@ var _ = ginkgo.Describe("Secrets Loader", func() {
 
 			keys, err := loader.LoadUnsealKeys(ctx, "test", secretRefs, 0)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
-			gomega.Expect(keys).To(gomega.ConsistOf("key1", "key2", "key3"))
+			gomega.Expect(revealed(keys)).To(gomega.ConsistOf("key1", "key2", "key3"))
+		})
+
+		ginkgo.It("should not read secrets once the threshold is met", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "primary", Namespace: "test"},
+				Data:       map[string][]byte{"keys": []byte(`["key1", "key1", "key2"]`)},
+			}
+			gomega.Expect(k8sClient.Create(ctx, secret)).To(gomega.Succeed())
+
+			// The second Secret does not exist, so reading it would fail.
+			secretRefs := []opsv1alpha1.SecretRef{
+				{Name: "primary", Key: "keys"},
+				{Name: "escrow", Key: "keys"},
+			}
+			keys, err := loader.LoadUnsealKeys(ctx, "test", secretRefs, 2)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(revealed(keys)).To(gomega.Equal([]string{"key1", "key2"}))
+
+			_, err = loader.LoadUnsealKeys(ctx, "test", secretRefs, 3)
+			gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("escrow")), "a duplicate does not count towards the threshold")
 		})
 
 		ginkgo.It("should respect key threshold", func() {
@@ -164,7 +259,7 @@ var _ = ginkgo.Describe("Secrets Loader", func() {
 			gomega.Expect(len(keys)).To(gomega.Equal(3))
 			// Verify all returned keys are from the original set
 			allKeys := []string{"key1", "key2", "key3", "key4", "key5"}
-			for _, key := range keys {
+			for _, key := range revealed(keys) {
 				gomega.Expect(allKeys).To(gomega.ContainElement(key))
 			}
 		})
@@ -188,7 +283,7 @@ var _ = ginkgo.Describe("Secrets Loader", func() {
 
 			keys, err := loader.LoadUnsealKeys(ctx, "test", secretRefs, 0)
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
-			gomega.Expect(keys).To(gomega.Equal([]string{"key1", "key2"}))
+			gomega.Expect(revealed(keys)).To(gomega.Equal([]string{"key1", "key2"}))
 		})
 
 		ginkgo.It("should return error for missing secret", func() {
@@ -221,8 +316,283 @@ var _ = ginkgo.Describe("Secrets Loader", func() {
 			gomega.Expect(err).To(gomega.HaveOccurred())
 		})
 	})
+
+	ginkgo.Context("LoadCustodianKeys", func() {
+		ginkgo.BeforeEach(func() {
+			for name, keys := range map[string]string{
+				"alice-keys": "a1\na2\na3",
+				"bob-keys":   "b1\nb2",
+				"carol-keys": "not a share\n",
+			} {
+				gomega.Expect(k8sClient.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+					Data:       map[string][]byte{"keys": []byte(keys)},
+				})).To(gomega.Succeed())
+			}
+		})
+
+		ginkgo.It("should take shares from each custodian in turn", func() {
+			vaultUnsealer := opsv1alpha1.NewVaultUnsealer("test", "main").
+				WithCustodian("alice", "alice-keys", "keys", 2).
+				WithCustodian("bob", "bob-keys", "keys", 0).
+				WithKeyThreshold(3)
+			keys, _, contributions, err := loader.LoadCustodianKeys(ctx, vaultUnsealer)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(revealed(keys)).To(gomega.Equal([]string{"a1", "b1", "a2"}))
+			gomega.Expect(contributions).To(gomega.Equal([]CustodianContribution{
+				{Name: "alice", Available: 3, Used: 2},
+				{Name: "bob", Available: 2, Used: 1},
+			}))
+		})
+
+		ginkgo.It("should cap each custodian at maxShares", func() {
+			vaultUnsealer := opsv1alpha1.NewVaultUnsealer("test", "main").
+				WithCustodian("alice", "alice-keys", "keys", 1).
+				WithCustodian("bob", "bob-keys", "keys", 1)
+			keys, _, _, err := loader.LoadCustodianKeys(ctx, vaultUnsealer)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(revealed(keys)).To(gomega.Equal([]string{"a1", "b1"}))
+		})
+
+		ginkgo.It("should fail when too few custodians contribute", func() {
+			vaultUnsealer := opsv1alpha1.NewVaultUnsealer("test", "main").
+				WithCustodian("alice", "alice-keys", "keys", 0).
+				WithCustodian("carol", "carol-keys", "keys", 0).
+				WithMinCustodians(2)
+			keys, skipped, contributions, err := loader.LoadCustodianKeys(ctx, vaultUnsealer)
+			gomega.Expect(err).To(gomega.MatchError(ErrCustodianQuorum))
+			gomega.Expect(err.Error()).To(gomega.ContainSubstring("1 of 2 custodians contributed shares"))
+			gomega.Expect(keys).To(gomega.BeEmpty())
+			gomega.Expect(skipped).To(gomega.HaveLen(1))
+			gomega.Expect(contributions[1]).To(gomega.Equal(CustodianContribution{Name: "carol"}))
+		})
+
+		ginkgo.It("should be used by LoadUnsealKeysFor", func() {
+			vaultUnsealer := opsv1alpha1.NewVaultUnsealer("test", "main").
+				WithCustodian("bob", "bob-keys", "keys", 0)
+			keys, err := loader.LoadUnsealKeysFor(ctx, vaultUnsealer)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(revealed(keys)).To(gomega.Equal([]string{"b1", "b2"}))
+		})
+	})
+
+	ginkgo.Context("LoadUnsealKeysAs", func() {
+		var impersonated client.Client
+
+		ginkgo.BeforeEach(func() {
+			impersonated = fake.NewClientBuilder().WithScheme(scheme).Build()
+			loader.WithImpersonation(func(namespace, serviceAccount string) (client.Client, error) {
+				gomega.Expect(ServiceAccountUsername(namespace, serviceAccount)).To(gomega.Equal("system:serviceaccount:test:key-reader"))
+				return impersonated, nil
+			})
+		})
+
+		ginkgo.It("should read cross-namespace secrets as the service account", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "other-namespace"},
+				Data:       map[string][]byte{"keys": []byte(`["key1"]`)},
+			}
+			// Visible to the operator but not granted to the service account.
+			gomega.Expect(k8sClient.Create(ctx, secret)).To(gomega.Succeed())
+
+			secretRefs := []opsv1alpha1.SecretRef{
+				{Name: "secret", Namespace: "other-namespace", Key: "keys"},
+			}
+			_, err := loader.LoadUnsealKeysAs(ctx, "test", "key-reader", secretRefs, 0)
+			gomega.Expect(err).To(gomega.HaveOccurred())
+
+			granted := secret.DeepCopy()
+			granted.ResourceVersion = ""
+			gomega.Expect(impersonated.Create(ctx, granted)).To(gomega.Succeed())
+			keys, err := loader.LoadUnsealKeysAs(ctx, "test", "key-reader", secretRefs, 0)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(revealed(keys)).To(gomega.Equal([]string{"key1"}))
+		})
+
+		ginkgo.It("should read same-namespace secrets with the operator client", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "test"},
+				Data:       map[string][]byte{"keys": []byte(`["key1"]`)},
+			}
+			gomega.Expect(k8sClient.Create(ctx, secret)).To(gomega.Succeed())
+
+			secretRefs := []opsv1alpha1.SecretRef{{Name: "secret", Key: "keys"}}
+			keys, err := loader.LoadUnsealKeysAs(ctx, "test", "key-reader", secretRefs, 0)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(revealed(keys)).To(gomega.Equal([]string{"key1"}))
+		})
+
+		ginkgo.It("should fail when impersonation is not configured", func() {
+			secretRefs := []opsv1alpha1.SecretRef{
+				{Name: "secret", Namespace: "other-namespace", Key: "keys"},
+			}
+			_, err := NewLoader(k8sClient).LoadUnsealKeysAs(ctx, "test", "key-reader", secretRefs, 0)
+			gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("impersonation is not configured")))
+		})
+	})
+
+	ginkgo.Context("Keyset modification time", func() {
+		managedFields := func(fields string, at time.Time) metav1.ManagedFieldsEntry {
+			return metav1.ManagedFieldsEntry{
+				Manager:    "kubectl",
+				Operation:  metav1.ManagedFieldsOperationUpdate,
+				Time:       &metav1.Time{Time: at},
+				FieldsType: "FieldsV1",
+				FieldsV1:   &metav1.FieldsV1{Raw: []byte(fields)},
+			}
+		}
+
+		ginkgo.It("should use the newest entry that owns the data", func() {
+			created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				CreationTimestamp: metav1.Time{Time: created},
+				ManagedFields: []metav1.ManagedFieldsEntry{
+					managedFields(`{"f:data":{"f:keys":{}}}`, created.Add(24*time.Hour)),
+					managedFields(`{"f:metadata":{"f:labels":{}}}`, created.Add(48*time.Hour)),
+				},
+			}}
+			gomega.Expect(SecretModifiedAt(secret)).To(gomega.Equal(created.Add(24 * time.Hour)))
+		})
+
+		ginkgo.It("should fall back to the creation time", func() {
+			created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Time{Time: created}}}
+			gomega.Expect(SecretModifiedAt(secret)).To(gomega.Equal(created))
+		})
+
+		ginkgo.It("should report who created and last modified the keys", func() {
+			created := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			creator := managedFields(`{"f:data":{"f:keys":{}}}`, created)
+			creator.Manager = "helm"
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				CreationTimestamp: metav1.Time{Time: created},
+				ManagedFields:     []metav1.ManagedFieldsEntry{creator},
+			}}
+			origin := SecretOrigin(secret)
+			gomega.Expect(origin.CreatedBy).To(gomega.Equal("helm"))
+			gomega.Expect(origin.ModifiedBy).To(gomega.Equal("helm"))
+
+			secret.ManagedFields = append(secret.ManagedFields, managedFields(`{"f:data":{"f:keys":{}}}`, created.Add(time.Hour)))
+			origin = SecretOrigin(secret)
+			gomega.Expect(origin.CreatedBy).To(gomega.Equal("helm"))
+			gomega.Expect(origin.ModifiedBy).To(gomega.Equal("kubectl"))
+			gomega.Expect(origin.ModifiedAt).To(gomega.Equal(created.Add(time.Hour)))
+
+			secret.Annotations = map[string]string{opsv1alpha1.AnnotationModifiedBy: "alice"}
+			gomega.Expect(SecretOrigin(secret).ModifiedBy).To(gomega.Equal("alice"))
+		})
+
+		ginkgo.It("should report the least recently modified secret", func() {
+			refs := []opsv1alpha1.SecretRef{{Name: "old", Key: "keys"}, {Name: "new", Key: "keys"}}
+			oldTime := time.Now().Add(-400 * 24 * time.Hour).Truncate(time.Second)
+			for name, at := range map[string]time.Time{"old": oldTime, "new": time.Now().Truncate(time.Second)} {
+				gomega.Expect(k8sClient.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:          name,
+						Namespace:     "test",
+						ManagedFields: []metav1.ManagedFieldsEntry{managedFields(`{"f:data":{"f:keys":{}}}`, at)},
+					},
+					Data: map[string][]byte{"keys": []byte(`["key1"]`)},
+				})).To(gomega.Succeed())
+			}
+
+			vaultUnsealer := opsv1alpha1.NewVaultUnsealer("test", "vault")
+			vaultUnsealer.Spec.UnsealKeysSecretRefs = refs
+			modifiedAt, ok, err := loader.KeysetModifiedAt(ctx, vaultUnsealer)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ok).To(gomega.BeTrue())
+			gomega.Expect(modifiedAt).To(gomega.BeTemporally("==", oldTime))
+
+			vaultUnsealer.Spec.UnsealKeysSecretRefs = nil
+			_, ok, err = loader.KeysetModifiedAt(ctx, vaultUnsealer)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ok).To(gomega.BeFalse())
+		})
+	})
+
+	ginkgo.Context("ResidentKeysFor", func() {
+		ginkgo.It("should report the key fields read with the loader's client", func() {
+			gomega.Expect(k8sClient.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "resident", Namespace: "test"},
+				Data:       map[string][]byte{"keys": []byte(`["key1"]`), "other": []byte("ignored")},
+			})).To(gomega.Succeed())
+
+			vaultUnsealer := opsv1alpha1.NewVaultUnsealer("test", "vault")
+			vaultUnsealer.Spec.SecretsServiceAccountName = "key-reader"
+			vaultUnsealer.Spec.UnsealKeysSecretRefs = []opsv1alpha1.SecretRef{
+				{Name: "resident", Key: "keys"},
+				{Name: "missing", Key: "keys"},
+				{Name: "impersonated", Namespace: "other-namespace", Key: "keys"},
+			}
+			resident, err := loader.ResidentKeysFor(ctx, vaultUnsealer)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(resident).To(gomega.HaveLen(1))
+			gomega.Expect(resident[0].Secret).To(gomega.Equal(types.NamespacedName{Namespace: "test", Name: "resident"}))
+			gomega.Expect(resident[0].Key).To(gomega.Equal("keys"))
+			gomega.Expect(resident[0].Bytes).To(gomega.Equal(len(`["key1"]`)))
+		})
+	})
+
+	ginkgo.Context("RequireGrants", func() {
+		var secretRefs []opsv1alpha1.SecretRef
+
+		ginkgo.BeforeEach(func() {
+			loader.RequireGrants()
+			gomega.Expect(k8sClient.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "other-namespace"},
+				Data:       map[string][]byte{"keys": []byte(`["key1"]`)},
+			})).To(gomega.Succeed())
+			secretRefs = []opsv1alpha1.SecretRef{{Name: "secret", Namespace: "other-namespace", Key: "keys"}}
+		})
+
+		createNamespace := func(allowList string) {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other-namespace"}}
+			if allowList != "" {
+				ns.Annotations = map[string]string{opsv1alpha1.AnnotationAllowedUnsealerNamespaces: allowList}
+			}
+			gomega.Expect(k8sClient.Create(ctx, ns)).To(gomega.Succeed())
+		}
+
+		ginkgo.It("should refuse namespaces without a grant", func() {
+			createNamespace("")
+			_, err := loader.LoadUnsealKeys(ctx, "test", secretRefs, 0)
+			gomega.Expect(err).To(gomega.MatchError(ErrAccessNotGranted))
+		})
+
+		ginkgo.It("should refuse namespaces granting other namespaces", func() {
+			createNamespace("tenant-a, tenant-b")
+			_, err := loader.LoadUnsealKeys(ctx, "test", secretRefs, 0)
+			gomega.Expect(err).To(gomega.MatchError(ErrAccessNotGranted))
+		})
+
+		ginkgo.It("should allow namespaces listing the VaultUnsealer namespace", func() {
+			createNamespace("tenant-a, test")
+			keys, err := loader.LoadUnsealKeys(ctx, "test", secretRefs, 0)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(revealed(keys)).To(gomega.Equal([]string{"key1"}))
+		})
+
+		ginkgo.It("should allow wildcard grants", func() {
+			createNamespace("*")
+			_, err := loader.LoadUnsealKeys(ctx, "test", secretRefs, 0)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("should not require a grant within the same namespace", func() {
+			_, err := loader.LoadUnsealKeys(ctx, "other-namespace", []opsv1alpha1.SecretRef{{Name: "secret", Key: "keys"}}, 0)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+	})
 })
 
+func revealed(keys []SecretString) []string {
+	values := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, key.Reveal())
+	}
+	return values
+}
+
 func TestSecretsLoader(t *testing.T) {
 	gomega.RegisterFailHandler(ginkgo.Fail)
 	ginkgo.RunSpecs(t, "Secrets Loader Suite")
@@ -0,0 +1,90 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// KeySecretOrigin records who created and last changed an unseal keys
+// Secret, so seal incidents can be matched with key rotations.
+type KeySecretOrigin struct {
+	Secret types.NamespacedName
+	// ResourceVersion identifies the copy of the Secret that was read.
+	ResourceVersion string
+
+	CreatedAt time.Time
+	// CreatedBy is the field manager that created the Secret, while its
+	// managedFields entry is still present.
+	CreatedBy string
+
+	// ModifiedAt is when the data last changed, as SecretModifiedAt.
+	ModifiedAt time.Time
+	// ModifiedBy is the AnnotationModifiedBy annotation when set, otherwise
+	// the field manager that last wrote the data.
+	ModifiedBy string
+}
+
+// SecretOrigin reads the origin of secret from its managedFields and
+// annotations. Field managers name the client that made a change, such as
+// kubectl-edit or argocd-controller, not the user, so rotation tooling can
+// record the user in the AnnotationModifiedBy annotation.
+func SecretOrigin(secret *corev1.Secret) KeySecretOrigin {
+	origin := KeySecretOrigin{
+		Secret:          types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name},
+		ResourceVersion: secret.ResourceVersion,
+		CreatedAt:       secret.CreationTimestamp.Time,
+	}
+	for _, entry := range secret.ManagedFields {
+		if entry.Time != nil && entry.Time.Equal(&secret.CreationTimestamp) {
+			origin.CreatedBy = entry.Manager
+			break
+		}
+	}
+	origin.ModifiedAt, origin.ModifiedBy = lastDataWrite(secret)
+	if modifiedBy := secret.Annotations[opsv1alpha1.AnnotationModifiedBy]; modifiedBy != "" {
+		origin.ModifiedBy = modifiedBy
+	}
+	return origin
+}
+
+// KeySecretOriginsFor returns the origin of every unseal keys Secret of
+// vaultUnsealer, in spec order, enforcing grants and impersonation.
+func (l *Loader) KeySecretOriginsFor(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) ([]KeySecretOrigin, error) {
+	secretRefs := vaultUnsealer.Spec.KeySecretRefs()
+	origins := make([]KeySecretOrigin, 0, len(secretRefs))
+	for _, secretRef := range secretRefs {
+		secret, err := l.getSecret(ctx, vaultUnsealer.Namespace, vaultUnsealer.Spec.SecretsServiceAccountName, secretRef)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read secret %s/%s: %w", secretRef.Namespace, secretRef.Name, err)
+		}
+		origin := SecretOrigin(secret)
+		// Several keys of one Secret may be referenced.
+		if !slices.ContainsFunc(origins, func(o KeySecretOrigin) bool { return o.Secret == origin.Secret }) {
+			origins = append(origins, origin)
+		}
+	}
+	return origins, nil
+}
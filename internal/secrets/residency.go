@@ -0,0 +1,75 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// ResidentKey describes one unseal key field of a Secret held by the
+// Loader's client. In the manager that client is backed by the shared
+// informer cache, which keeps every Secret in memory until it is deleted.
+type ResidentKey struct {
+	Secret types.NamespacedName
+	// Key is the referenced field of the Secret.
+	Key string
+	// ResourceVersion identifies the copy of the Secret that is held.
+	ResourceVersion string
+	// Bytes is the size of the field, in bytes.
+	Bytes int
+}
+
+// ResidentKeysFor reports the key fields of vaultUnsealer that are read with
+// the Loader's own client. Secrets read by impersonation use uncached clients
+// and are never resident, so they are left out, as are missing Secrets.
+func (l *Loader) ResidentKeysFor(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) ([]ResidentKey, error) {
+	var resident []ResidentKey
+	for _, secretRef := range vaultUnsealer.Spec.KeySecretRefs() {
+		namespace := secretRef.Namespace
+		if namespace == "" {
+			namespace = vaultUnsealer.Namespace
+		}
+		if serviceAccount := vaultUnsealer.Spec.SecretsServiceAccountName; serviceAccount != "" && namespace != vaultUnsealer.Namespace {
+			continue
+		}
+
+		// Grants are not checked: a Secret the VaultUnsealer may not read is
+		// held by the client all the same.
+		key := types.NamespacedName{Namespace: namespace, Name: secretRef.Name}
+		secret := &corev1.Secret{}
+		if err := l.client.Get(ctx, key, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read secret %s: %w", key, err)
+		}
+		resident = append(resident, ResidentKey{
+			Secret:          key,
+			Key:             secretRef.Key,
+			ResourceVersion: secret.ResourceVersion,
+			Bytes:           len(secret.Data[secretRef.Key]),
+		})
+	}
+	return resident, nil
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import "fmt"
+
+const redacted = "[REDACTED]"
+
+// SecretString holds unseal key material. Printing, logging or serializing it
+// in any form yields "[REDACTED]", so a key cannot leak into status, events or
+// logs by accident; Reveal is the only way to read the value.
+type SecretString struct {
+	value string
+}
+
+// NewSecretString wraps value.
+func NewSecretString(value string) SecretString {
+	return SecretString{value: value}
+}
+
+// Reveal returns the wrapped value. Only pass the result straight to Vault.
+func (s SecretString) Reveal() string {
+	return s.value
+}
+
+// String implements fmt.Stringer.
+func (s SecretString) String() string {
+	return redacted
+}
+
+// GoString implements fmt.GoStringer, covering the %#v verb.
+func (s SecretString) GoString() string {
+	return redacted
+}
+
+// Format implements fmt.Formatter so that no verb prints the value.
+func (s SecretString) Format(f fmt.State, _ rune) {
+	_, _ = f.Write([]byte(redacted))
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s SecretString) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + redacted + `"`), nil
+}
+
+// MarshalText implements encoding.TextMarshaler, used by YAML and map keys.
+func (s SecretString) MarshalText() ([]byte, error) {
+	return []byte(redacted), nil
+}
+
+// MarshalLog implements logr.Marshaler.
+func (s SecretString) MarshalLog() any {
+	return redacted
+}
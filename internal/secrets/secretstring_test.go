@@ -0,0 +1,54 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+func TestSecretString_NeverRevealsValue(t *testing.T) {
+	const key = "s3cr3t-unseal-share"
+	s := NewSecretString(key)
+	assert.Equal(t, key, s.Reveal())
+
+	for _, verb := range []string{"%v", "%+v", "%#v", "%s", "%q", "%x", "%d"} {
+		assert.NotContains(t, fmt.Sprintf(verb, s), key, verb)
+		assert.NotContains(t, fmt.Sprintf(verb, []SecretString{s}), key, verb)
+	}
+	assert.NotContains(t, fmt.Sprint(struct{ Key SecretString }{s}), key)
+
+	data, err := json.Marshal(map[string]any{"key": s, "keys": []SecretString{s}})
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), key)
+
+	data, err = yaml.Marshal(struct{ Key SecretString }{s})
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), key)
+
+	var logged string
+	logger := funcr.New(func(prefix, args string) { logged += args }, funcr.Options{})
+	logger.Info("loaded", "key", s, "keys", []SecretString{s})
+	assert.NotContains(t, logged, key)
+	assert.Contains(t, logged, redacted)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxShareLength bounds a single key share. Vault's shares are well under
+// a hundred characters in hex or base64; anything this long is not one.
+const maxShareLength = 1024
+
+// SkippedKey describes a key entry that was dropped as not being a key share.
+// It never carries the entry itself, which may be a mangled share.
+type SkippedKey struct {
+	// Source names where the entry was read from, such as
+	// "secret vault/vault-keys key keys.json".
+	Source string
+	// Entry is the 1-based position of the entry within Source.
+	Entry int
+	// Reason says why the entry is not a key share.
+	Reason string
+}
+
+func (s SkippedKey) String() string {
+	return fmt.Sprintf("%s entry %d: %s", s.Source, s.Entry, s.Reason)
+}
+
+// normalizeShare trims the decoration that copying shares between tools tends
+// to add, surrounding whitespace, quotes and a trailing comma, and rejects
+// entries that cannot be a hex or base64 share, such as comments or stray
+// lines in a text Secret.
+func normalizeShare(entry string) (string, error) {
+	share := strings.TrimSuffix(strings.TrimSpace(entry), ",")
+	if len(share) >= 2 && (share[0] == '"' || share[0] == '\'') && share[len(share)-1] == share[0] {
+		share = share[1 : len(share)-1]
+	}
+
+	switch {
+	case share == "":
+		return "", fmt.Errorf("is empty")
+	case len(share) > maxShareLength:
+		return "", fmt.Errorf("is longer than %d characters", maxShareLength)
+	}
+	for _, c := range share {
+		if !isShareChar(c) {
+			return "", fmt.Errorf("contains characters outside the hex and base64 alphabets")
+		}
+	}
+	unpadded := strings.TrimRight(share, "=")
+	if strings.Contains(unpadded, "=") || len(share)-len(unpadded) > 2 {
+		return "", fmt.Errorf("has misplaced base64 padding")
+	}
+	return share, nil
+}
+
+// isShareChar reports whether c belongs to the standard or URL-safe base64
+// alphabet, hex being a subset of both.
+func isShareChar(c rune) bool {
+	switch {
+	case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+		return true
+	}
+	return strings.ContainsRune("+/-_=", c)
+}
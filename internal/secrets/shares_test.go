@@ -0,0 +1,51 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/package secrets
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeShare(t *testing.T) {
+	for entry, want := range map[string]string{
+		"  0a1b2c3d  ":     "0a1b2c3d",
+		"\"aGVsbG8=\",":    "aGVsbG8=",
+		"'aGVsbG8-_w'":     "aGVsbG8-_w",
+		"key-1":            "key-1",
+		"YWJjZA==\r":       "YWJjZA==",
+		"+/+/abcdABCD0123": "+/+/abcdABCD0123",
+	} {
+		got, err := normalizeShare(entry)
+		if assert.NoError(t, err, entry) {
+			assert.Equal(t, want, got, entry)
+		}
+	}
+
+	for entry, reason := range map[string]string{
+		"":                                    "is empty",
+		"\"\"":                                "is empty",
+		"# production shares":                 "outside the hex and base64 alphabets",
+		"Unseal Key 1: aGVsbG8=":              "outside the hex and base64 alphabets",
+		"aGV=sbG8":                            "misplaced base64 padding",
+		"aGVsbG8===":                          "misplaced base64 padding",
+		strings.Repeat("a", maxShareLength+1): "longer than",
+	} {
+		_, err := normalizeShare(entry)
+		assert.ErrorContains(t, err, reason, entry)
+	}
+}
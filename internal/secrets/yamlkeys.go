@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// isYAMLKeys reports whether data is in the YAML format: a list of keys, or
+// documents carrying a keys field, possibly several separated by "---". Key
+// shares are hex or base64, so none of these markers can start a line of the
+// newline-separated format.
+func isYAMLKeys(data string) bool {
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "---" || strings.HasPrefix(line, "--- ") ||
+			line == "-" || strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "keys:") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseYAMLKeys collects the keys of every YAML document in data. A document
+// is either a list of keys or a mapping whose keys field lists them; other
+// fields, and documents without keys, are bootstrap data stored alongside
+// and are ignored. Keys are read as written, so a share that looks like a
+// number is not reformatted.
+func parseYAMLKeys(data string) ([]string, error) {
+	decoder := yaml.NewDecoder(strings.NewReader(data))
+	var keys []string
+	for i := 0; ; i++ {
+		var document yaml.Node
+		if err := decoder.Decode(&document); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to parse YAML document %d: %w", i, err)
+		}
+		if len(document.Content) == 0 {
+			continue
+		}
+
+		list := document.Content[0]
+		if list.Kind == yaml.MappingNode {
+			list = yamlField(list, "keys")
+			if list == nil {
+				continue
+			}
+		}
+		if list.Kind != yaml.SequenceNode {
+			return nil, fmt.Errorf("YAML document %d: keys must be a list", i)
+		}
+		for _, item := range list.Content {
+			if item.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("YAML document %d: keys must be strings", i)
+			}
+			if key := strings.TrimSpace(item.Value); key != "" {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no keys found in YAML secret data")
+	}
+	return keys, nil
+}
+
+// yamlField returns the value of the named field of a mapping, or nil.
+func yamlField(mapping *yaml.Node, name string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == name {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -19,18 +19,64 @@ package vault
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/vault/api"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/secrets"
 )
 
+// DefaultHealthPath is the Vault health endpoint probed for HA roles.
+const DefaultHealthPath = "/v1/sys/health"
+
+// DefaultHealthStatusCodes are the /sys/health codes of a healthy, unsealed
+// node: active, standby, DR secondary and performance standby.
+var DefaultHealthStatusCodes = []int{http.StatusOK, http.StatusTooManyRequests, 472, 473}
+
+// ErrNotVaultEndpoint is returned when the address answers, but not with
+// the Vault API, as when a selector matches an exporter or agent pod.
+var ErrNotVaultEndpoint = errors.New("not a Vault endpoint")
+
+// RequestIDHeader carries the operator's reconcile ID on every Vault request,
+// so Vault audit log entries can be matched with operator logs and Events.
+const RequestIDHeader = "X-Request-Id"
+
 type Client struct {
 	client *api.Client
+
+	healthPath        string
+	healthStatusCodes []int
+
+	// statusToken authenticates seal status and health requests.
+	statusToken secrets.SecretString
+	// sealMigration submits keys with migrate set, as a seal migration needs.
+	sealMigration bool
+	// observeResponse, when set, is told about every HTTP response.
+	observeResponse ResponseObserver
+	// pathPrefix is the path every API request is made under, if any.
+	pathPrefix string
+	// host, when set, replaces the Host header of every request.
+	host string
+	// transport is the HTTP transport the API client dials through.
+	transport *http.Transport
 }
 
+// ResponseObserver is called for every HTTP exchange with Vault, including
+// retries, with the API path such as "sys/unseal" and the status class such
+// as "2xx", or "error" when no response was received.
+type ResponseObserver func(endpoint, statusClass string)
+
 type SealStatus struct {
 	Sealed      bool   `json:"sealed"`
 	T           int    `json:"t"`
@@ -45,15 +91,17 @@ type SealStatus struct {
 }
 
 type UnsealResponse struct {
-	Sealed   bool `json:"sealed"`
-	T        int  `json:"t"`
-	N        int  `json:"n"`
-	Progress int  `json:"progress"`
+	Sealed   bool   `json:"sealed"`
+	T        int    `json:"t"`
+	N        int    `json:"n"`
+	Progress int    `json:"progress"`
+	Nonce    string `json:"nonce"`
 }
 
 func NewClient(address string, tlsConfig *tls.Config) (*Client, error) {
 	config := api.DefaultConfig()
 	config.Address = address
+	config.CheckRetry = retryPolicy
 
 	if tlsConfig != nil {
 		if config.HttpClient.Transport == nil {
@@ -64,18 +112,152 @@ func NewClient(address string, tlsConfig *tls.Config) (*Client, error) {
 		}
 	}
 
+	transport, _ := config.HttpClient.Transport.(*http.Transport)
+	return newClient(config, config.HttpClient.Transport, transport)
+}
+
+// NewClientWithTransport returns a client sending every request through
+// transport, such as one authenticating to the Kubernetes apiserver whose
+// pod proxy forwards the requests to Vault.
+func NewClientWithTransport(address string, transport http.RoundTripper) (*Client, error) {
+	config := api.DefaultConfig()
+	config.Address = address
+	config.CheckRetry = retryPolicy
+	return newClient(config, transport, nil)
+}
+
+func newClient(config *api.Config, next http.RoundTripper, transport *http.Transport) (*Client, error) {
+	c := &Client{healthPath: DefaultHealthPath, healthStatusCodes: DefaultHealthStatusCodes, transport: transport}
+	config.HttpClient.Transport = &observingTransport{next: next, client: c}
+
 	client, err := api.NewClient(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Vault client: %w", err)
 	}
+	c.client = client
+	return c, nil
+}
 
-	return &Client{client: client}, nil
+// SetResponseObserver registers observe to be called for every response.
+func (c *Client) SetResponseObserver(observe ResponseObserver) {
+	c.observeResponse = observe
+}
+
+// observingTransport reports each exchange to the client's ResponseObserver.
+type observingTransport struct {
+	next   http.RoundTripper
+	client *Client
+}
+
+func (t *observingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if host := t.client.host; host != "" {
+		req = req.Clone(req.Context())
+		req.Host = host
+	}
+	resp, err := next.RoundTrip(req)
+	if observe := t.client.observeResponse; observe != nil {
+		statusClass := "error"
+		if err == nil {
+			statusClass = fmt.Sprintf("%dxx", resp.StatusCode/100)
+		}
+		observe(strings.TrimPrefix(strings.TrimPrefix(req.URL.Path, t.client.pathPrefix), "/v1/"), statusClass)
+	}
+	return resp, err
+}
+
+// SetPathPrefix makes every request, including the health check, under
+// prefix, so sys/seal-status is read from <prefix>/v1/sys/seal-status.
+func (c *Client) SetPathPrefix(prefix string) error {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return nil
+	}
+	address, err := url.Parse(c.client.Address())
+	if err != nil {
+		return fmt.Errorf("invalid vault address: %w", err)
+	}
+	address.Path = path.Join("/", address.Path, prefix)
+	if err := c.client.SetAddress(address.String()); err != nil {
+		return fmt.Errorf("failed to set vault path prefix: %w", err)
+	}
+	c.pathPrefix = address.Path
+	return nil
+}
+
+// SetHost sends host in the Host header instead of the host of the address,
+// for proxies such as a mesh sidecar that route requests by it.
+func (c *Client) SetHost(host string) {
+	c.host = host
+}
+
+// SetResolver resolves the hostname of the address through resolver when
+// connecting, instead of through the system resolver on every connection.
+// It has no effect on a client created with NewClientWithTransport.
+func (c *Client) SetResolver(resolver *Resolver) {
+	if c.transport != nil {
+		c.transport.DialContext = resolver.DialContext
+	}
+}
+
+// SetRequestID sends id in the RequestIDHeader on all subsequent requests.
+func (c *Client) SetRequestID(id string) {
+	c.client.AddHeader(RequestIDHeader, id)
+}
+
+// SetStatusToken sends token with seal status and health requests, and with
+// unseal requests that Vault rejects as unauthorized without it.
+func (c *Client) SetStatusToken(token secrets.SecretString) {
+	c.statusToken = token
+}
+
+// authenticated returns a copy of the client carrying the status token, or
+// the client itself when none is set.
+func (c *Client) authenticated() (*api.Client, error) {
+	if c.statusToken.Reveal() == "" {
+		return c.client, nil
+	}
+	authClient, err := c.client.CloneWithHeaders()
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone vault client: %w", err)
+	}
+	authClient.SetToken(c.statusToken.Reveal())
+	return authClient, nil
+}
+
+// SetSealMigration sets whether unseal requests carry the migrate flag,
+// which Vault requires while a seal migration is in progress.
+func (c *Client) SetSealMigration(migrate bool) {
+	c.sealMigration = migrate
+}
+
+// SetHealthCheck overrides the health endpoint path and the status codes
+// treated as healthy. Empty values keep the defaults.
+func (c *Client) SetHealthCheck(path string, acceptedStatusCodes []int) {
+	if path != "" {
+		c.healthPath = path
+	}
+	if len(acceptedStatusCodes) > 0 {
+		c.healthStatusCodes = acceptedStatusCodes
+	}
 }
 
 func (c *Client) GetSealStatus(ctx context.Context) (*SealStatus, error) {
-	resp, err := c.client.Logical().ReadRawWithContext(ctx, "sys/seal-status")
+	statusClient, err := c.authenticated()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get seal status: %w", err)
+		return nil, err
+	}
+	resp, err := statusClient.Logical().ReadRawWithContext(ctx, "sys/seal-status")
+	if err != nil {
+		// Every Vault serves sys/seal-status, sealed or not.
+		var respErr *api.ResponseError
+		if errors.As(err, &respErr) && respErr.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("failed to get seal status: %w: sys/seal-status returned 404", ErrNotVaultEndpoint)
+		}
+		return nil, fmt.Errorf("failed to get seal status: %w", rateLimited(resp, err))
 	}
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil {
@@ -83,23 +265,45 @@ func (c *Client) GetSealStatus(ctx context.Context) (*SealStatus, error) {
 		}
 	}()
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seal status: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, fmt.Errorf("failed to decode seal status: %w: response is not JSON (%s)", ErrNotVaultEndpoint, resp.Header.Get("Content-Type"))
+	}
+	if _, ok := fields["sealed"]; !ok {
+		return nil, fmt.Errorf("failed to decode seal status: %w: response has no sealed field", ErrNotVaultEndpoint)
+	}
 	var status SealStatus
-	if err := resp.DecodeJSON(&status); err != nil {
+	if err := json.Unmarshal(body, &status); err != nil {
 		return nil, fmt.Errorf("failed to decode seal status: %w", err)
 	}
 
 	return &status, nil
 }
 
-func (c *Client) Unseal(ctx context.Context, key string) (*UnsealResponse, error) {
-	data := map[string]interface{}{"key": key}
+func (c *Client) Unseal(ctx context.Context, key secrets.SecretString) (*UnsealResponse, error) {
+	data := map[string]interface{}{"key": key.Reveal()}
+	if c.sealMigration {
+		data["migrate"] = true
+	}
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal unseal data: %w", err)
 	}
 	resp, err := c.client.Logical().WriteRawWithContext(ctx, "sys/unseal", jsonData)
+	if unauthorized(err) && c.statusToken.Reveal() != "" {
+		// The endpoint is behind the same authenticating proxy as seal status.
+		var authClient *api.Client
+		if authClient, err = c.authenticated(); err != nil {
+			return nil, err
+		}
+		resp, err = authClient.Logical().WriteRawWithContext(ctx, "sys/unseal", jsonData)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to unseal: %w", err)
+		return nil, fmt.Errorf("failed to unseal: %w", rateLimited(resp, err))
 	}
 	defer func() {
 		if closeErr := resp.Body.Close(); closeErr != nil {
@@ -114,3 +318,94 @@ func (c *Client) Unseal(ctx context.Context, key string) (*UnsealResponse, error
 
 	return &unsealResp, nil
 }
+
+// GetRole returns the HA role of an unsealed node from the health endpoint
+// status code. standbyok is deliberately not set, since it would report
+// standbys as 200 like the active node. Accepted codes that are not one of
+// Vault's standard ones return an empty role.
+func (c *Client) GetRole(ctx context.Context) (string, error) {
+	// Standbys answer 429, which the API client would otherwise retry.
+	healthClient, err := c.client.CloneWithHeaders()
+	if err != nil {
+		return "", fmt.Errorf("failed to clone vault client: %w", err)
+	}
+	healthClient.SetMaxRetries(0)
+	if token := c.statusToken.Reveal(); token != "" {
+		healthClient.SetToken(token)
+	}
+
+	healthURL, err := url.Parse(c.healthPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid health check path %q: %w", c.healthPath, err)
+	}
+	req := healthClient.NewRequest(http.MethodGet, healthURL.Path)
+	req.Params = healthURL.Query()
+	resp, err := healthClient.RawRequestWithContext(ctx, req)
+	if resp == nil {
+		return "", fmt.Errorf("failed to get health: %w", err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.FromContext(ctx).Error(closeErr, "Failed to close response body")
+		}
+	}()
+
+	if !slices.Contains(c.healthStatusCodes, resp.StatusCode) {
+		return "", fmt.Errorf("health check returned status %d", resp.StatusCode)
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return opsv1alpha1.PodRoleActive, nil
+	case http.StatusTooManyRequests:
+		return opsv1alpha1.PodRoleStandby, nil
+	case 472:
+		return opsv1alpha1.PodRoleDRSecondary, nil
+	case 473:
+		return opsv1alpha1.PodRolePerformanceStandby, nil
+	default:
+		return "", nil
+	}
+}
+
+// IsKeyRejected reports whether err is Vault refusing an unseal key share,
+// as opposed to a transport or server failure.
+func IsKeyRejected(err error) bool {
+	var respErr *api.ResponseError
+	return errors.As(err, &respErr) && respErr.StatusCode == http.StatusBadRequest
+}
+
+// IsTLSError reports whether err is a failed TLS handshake or certificate
+// verification, which points at a CA or TLS misconfiguration rather than
+// Vault being unavailable.
+func IsTLSError(err error) bool {
+	var (
+		verificationErr  *tls.CertificateVerificationError
+		unknownAuthority x509.UnknownAuthorityError
+		hostnameErr      x509.HostnameError
+		invalidErr       x509.CertificateInvalidError
+		recordHeaderErr  tls.RecordHeaderError
+		alertErr         tls.AlertError
+	)
+	return errors.As(err, &verificationErr) ||
+		errors.As(err, &unknownAuthority) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &invalidErr) ||
+		errors.As(err, &recordHeaderErr) ||
+		errors.As(err, &alertErr) ||
+		// net/http reports an https URL pointing at a plain HTTP listener
+		// only as an unexported error.
+		(err != nil && strings.Contains(err.Error(), "server gave HTTP response to HTTPS client"))
+}
+
+// IsNotVaultEndpoint reports whether err means the address is not serving
+// the Vault API at all.
+func IsNotVaultEndpoint(err error) bool {
+	return errors.Is(err, ErrNotVaultEndpoint)
+}
+
+// unauthorized reports whether err is a 401 or 403 response.
+func unauthorized(err error) bool {
+	var respErr *api.ResponseError
+	return errors.As(err, &respErr) &&
+		(respErr.StatusCode == http.StatusUnauthorized || respErr.StatusCode == http.StatusForbidden)
+}
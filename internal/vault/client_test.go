@@ -0,0 +1,452 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vault
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/secrets"
+	"github.com/panteparak/vault-unsealer/pkg/vaultfake"
+)
+
+func TestClient_UnsealAgainstFake(t *testing.T) {
+	ctx := context.Background()
+	fake := vaultfake.NewServer(vaultfake.WithKeys([]string{"key-1", "key-2", "key-3"}, 2))
+	defer fake.Close()
+
+	client, err := NewClient(fake.URL(), nil)
+	require.NoError(t, err)
+
+	status, err := client.GetSealStatus(ctx)
+	require.NoError(t, err)
+	assert.True(t, status.Sealed)
+	assert.Equal(t, 2, status.T)
+	assert.Equal(t, 3, status.N)
+	assert.Equal(t, vaultfake.Version, status.Version)
+
+	resp, err := client.Unseal(ctx, secrets.NewSecretString("key-1"))
+	require.NoError(t, err)
+	assert.True(t, resp.Sealed)
+	assert.Equal(t, 1, resp.Progress)
+
+	resp, err = client.Unseal(ctx, secrets.NewSecretString("key-2"))
+	require.NoError(t, err)
+	assert.False(t, resp.Sealed)
+	assert.False(t, fake.Sealed())
+}
+
+func TestClient_GetRole(t *testing.T) {
+	ctx := context.Background()
+	fake := vaultfake.NewServer(vaultfake.WithKeys([]string{"key-1"}, 1), vaultfake.WithUnsealed())
+	defer fake.Close()
+
+	client, err := NewClient(fake.URL(), nil)
+	require.NoError(t, err)
+
+	role, err := client.GetRole(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, opsv1alpha1.PodRoleActive, role)
+
+	fake.SetStandby(true)
+	role, err = client.GetRole(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, opsv1alpha1.PodRoleStandby, role)
+
+	fake.FailNext(1, 473)
+	role, err = client.GetRole(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, opsv1alpha1.PodRolePerformanceStandby, role)
+
+	fake.FailNext(1, 472)
+	role, err = client.GetRole(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, opsv1alpha1.PodRoleDRSecondary, role)
+}
+
+func TestClient_GetRoleCustomHealthCheck(t *testing.T) {
+	ctx := context.Background()
+	var status int
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/vault/health" || r.URL.Query().Get("standbycode") != "299" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(status)
+	}))
+	defer proxy.Close()
+
+	client, err := NewClient(proxy.URL, nil)
+	require.NoError(t, err)
+	client.SetHealthCheck("/vault/health?standbycode=299", []int{http.StatusOK, 299})
+
+	status = http.StatusOK
+	role, err := client.GetRole(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, opsv1alpha1.PodRoleActive, role)
+
+	status = 299
+	role, err = client.GetRole(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, role, "accepted non-standard code has no role")
+
+	status = http.StatusTooManyRequests
+	_, err = client.GetRole(ctx)
+	assert.ErrorContains(t, err, "status 429")
+}
+
+func TestClient_Errors(t *testing.T) {
+	ctx := context.Background()
+	fake := vaultfake.NewServer(vaultfake.WithKeys([]string{"key-1"}, 1))
+	defer fake.Close()
+
+	client, err := NewClient(fake.URL(), nil)
+	require.NoError(t, err)
+
+	_, err = client.Unseal(ctx, secrets.NewSecretString("wrong"))
+	assert.ErrorContains(t, err, "invalid key")
+	assert.True(t, IsKeyRejected(err))
+
+	// 5xx responses are retried by the Vault API client, so use a 4xx.
+	fake.FailNext(1, http.StatusForbidden)
+	_, err = client.GetSealStatus(ctx)
+	assert.Error(t, err)
+	assert.False(t, IsKeyRejected(err))
+}
+
+func TestClient_RateLimited(t *testing.T) {
+	ctx := context.Background()
+	fake := vaultfake.NewServer(vaultfake.WithKeys([]string{"key-1", "key-2"}, 2))
+	defer fake.Close()
+
+	client, err := NewClient(fake.URL(), nil)
+	require.NoError(t, err)
+
+	fake.RateLimitNext(1, 30*time.Second)
+	_, err = client.GetSealStatus(ctx)
+	wait, throttled := IsRateLimited(err)
+	assert.True(t, throttled, "429 from seal status is a rate limit: %v", err)
+	assert.Equal(t, 30*time.Second, wait)
+
+	// 429 responses are not retried, so the single injected failure is the
+	// only request that reached the fake.
+	fake.RateLimitNext(1, 0)
+	_, err = client.Unseal(ctx, secrets.NewSecretString("key-1"))
+	wait, throttled = IsRateLimited(err)
+	assert.True(t, throttled)
+	assert.Zero(t, wait)
+	assert.Equal(t, 1, fake.UnsealRequests())
+
+	fake.FailNext(1, http.StatusInternalServerError)
+	_, err = client.GetSealStatus(ctx)
+	_, throttled = IsRateLimited(err)
+	assert.False(t, throttled)
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+	}{
+		{name: "none", header: http.Header{}, want: 0},
+		{name: "seconds", header: http.Header{"Retry-After": {"7"}}, want: 7 * time.Second},
+		{name: "http date", header: http.Header{"Retry-After": {now.Add(time.Minute).Format(http.TimeFormat)}}, want: time.Minute},
+		{name: "date in the past", header: http.Header{"Retry-After": {now.Add(-time.Minute).Format(http.TimeFormat)}}, want: 0},
+		{name: "reset fallback", header: http.Header{"X-Ratelimit-Reset": {"12"}}, want: 12 * time.Second},
+		{name: "unparsable", header: http.Header{"Retry-After": {"soon"}}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, retryAfter(tt.header, now))
+		})
+	}
+}
+
+func TestIsTLSError(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	client, err := NewClient(server.URL, nil)
+	require.NoError(t, err)
+	_, err = client.GetSealStatus(ctx)
+	require.Error(t, err)
+	assert.True(t, IsTLSError(err), "untrusted certificate: %v", err)
+
+	plain := httptest.NewServer(http.NotFoundHandler())
+	defer plain.Close()
+	client, err = NewClient(strings.Replace(plain.URL, "http://", "https://", 1), nil)
+	require.NoError(t, err)
+	_, err = client.GetSealStatus(ctx)
+	require.Error(t, err)
+	assert.True(t, IsTLSError(err), "plain HTTP listener: %v", err)
+
+	assert.False(t, IsTLSError(errors.New("connection refused")))
+}
+
+func TestIsNotVaultEndpoint(t *testing.T) {
+	ctx := context.Background()
+	handlers := map[string]http.HandlerFunc{
+		"404": http.NotFound,
+		"html": func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte("<html><body>node exporter</body></html>"))
+		},
+		"other json": func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"status":"ok"}`))
+		},
+	}
+	for name, handler := range handlers {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			client, err := NewClient(server.URL, nil)
+			require.NoError(t, err)
+			_, err = client.GetSealStatus(ctx)
+			require.Error(t, err)
+			assert.True(t, IsNotVaultEndpoint(err), "%v", err)
+		})
+	}
+
+	fake := vaultfake.NewServer(vaultfake.WithKeys([]string{"key-1"}, 1))
+	defer fake.Close()
+	client, err := NewClient(fake.URL(), nil)
+	require.NoError(t, err)
+	fake.FailNext(1, http.StatusForbidden)
+	_, err = client.GetSealStatus(ctx)
+	require.Error(t, err)
+	assert.False(t, IsNotVaultEndpoint(err), "an API error from Vault itself")
+}
+
+func TestClient_SetRequestID(t *testing.T) {
+	ctx := context.Background()
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get(RequestIDHeader))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"sealed":false,"t":1,"n":1,"progress":0}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, nil)
+	require.NoError(t, err)
+	client.SetRequestID("abc123")
+
+	_, err = client.GetSealStatus(ctx)
+	require.NoError(t, err)
+	_, err = client.Unseal(ctx, secrets.NewSecretString("key"))
+	require.NoError(t, err)
+	_, err = client.GetRole(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"abc123", "abc123", "abc123"}, seen)
+}
+
+func TestClient_SetHost(t *testing.T) {
+	ctx := context.Background()
+	var seen []string
+	sidecar := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Host)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"sealed":false,"t":1,"n":1,"progress":0}`))
+	}))
+	defer sidecar.Close()
+
+	client, err := NewClient(sidecar.URL, nil)
+	require.NoError(t, err)
+	client.SetHost("10.0.0.7:8200")
+
+	_, err = client.GetSealStatus(ctx)
+	require.NoError(t, err)
+	_, err = client.Unseal(ctx, secrets.NewSecretString("key"))
+	require.NoError(t, err)
+	_, err = client.GetRole(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"10.0.0.7:8200", "10.0.0.7:8200", "10.0.0.7:8200"}, seen)
+}
+
+func TestClient_SetResolver(t *testing.T) {
+	fake := vaultfake.NewServer(vaultfake.WithKeys([]string{"key-1"}, 1))
+	defer fake.Close()
+	fakeURL, err := url.Parse(fake.URL())
+	require.NoError(t, err)
+	address := "http://vault.test:" + fakeURL.Port()
+
+	for _, cache := range []bool{false, true} {
+		resolver := NewResolver("", cache)
+		lookups := 0
+		resolver.lookup = func(_ context.Context, host string) ([]string, error) {
+			lookups++
+			assert.Equal(t, "vault.test", host)
+			return []string{"127.0.0.1"}, nil
+		}
+
+		// Every client dials a connection of its own.
+		for range 3 {
+			client, err := NewClient(address, nil)
+			require.NoError(t, err)
+			client.SetResolver(resolver)
+			_, err = client.GetSealStatus(context.Background())
+			require.NoError(t, err)
+		}
+		if cache {
+			assert.Equal(t, 1, lookups, "the host is looked up once")
+		} else {
+			assert.Equal(t, 3, lookups, "every connection looks the host up")
+		}
+	}
+}
+
+func TestResolver_DoesNotCacheFailures(t *testing.T) {
+	resolver := NewResolver("", true)
+	lookups := 0
+	resolver.lookup = func(context.Context, string) ([]string, error) {
+		lookups++
+		if lookups == 1 {
+			return nil, errors.New("i/o timeout")
+		}
+		return []string{"10.0.0.7"}, nil
+	}
+
+	_, err := resolver.resolve(context.Background(), "vault.test")
+	assert.ErrorContains(t, err, "failed to resolve vault.test")
+	addrs, err := resolver.resolve(context.Background(), "vault.test")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.7"}, addrs)
+	_, err = resolver.resolve(context.Background(), "vault.test")
+	require.NoError(t, err)
+	assert.Equal(t, 2, lookups)
+}
+
+func TestClient_SetStatusToken(t *testing.T) {
+	ctx := context.Background()
+	t.Setenv("VAULT_TOKEN", "")
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("X-Vault-Token")
+		seen = append(seen, r.URL.Path+"="+token)
+		if token != "s.proxy" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"sealed":false,"t":1,"n":1,"progress":0}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, nil)
+	require.NoError(t, err)
+	_, err = client.GetSealStatus(ctx)
+	require.Error(t, err, "the proxy rejects unauthenticated requests")
+
+	client.SetStatusToken(secrets.NewSecretString("s.proxy"))
+	seen = nil
+	_, err = client.GetSealStatus(ctx)
+	require.NoError(t, err)
+	_, err = client.GetRole(ctx)
+	require.NoError(t, err)
+	_, err = client.Unseal(ctx, secrets.NewSecretString("key"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"/v1/sys/seal-status=s.proxy",
+		"/v1/sys/health=s.proxy",
+		"/v1/sys/unseal=",
+		"/v1/sys/unseal=s.proxy",
+	}, seen, "keys are only submitted with the token after an unauthenticated attempt is rejected")
+}
+
+func TestClient_ResponseObserver(t *testing.T) {
+	ctx := context.Background()
+	fake := vaultfake.NewServer(vaultfake.WithKeys([]string{"key-1"}, 1))
+	defer fake.Close()
+
+	client, err := NewClient(fake.URL(), nil)
+	require.NoError(t, err)
+	var seen []string
+	client.SetResponseObserver(func(endpoint, statusClass string) {
+		seen = append(seen, endpoint+" "+statusClass)
+	})
+
+	_, err = client.GetSealStatus(ctx)
+	require.NoError(t, err)
+	_, err = client.Unseal(ctx, secrets.NewSecretString("stale"))
+	require.Error(t, err)
+	_, err = client.GetRole(ctx)
+	require.Error(t, err, "sealed Vault answers health with 503")
+	assert.Equal(t, []string{"sys/seal-status 2xx", "sys/unseal 4xx", "sys/health 5xx"}, seen)
+
+	fake.Close()
+	seen = nil
+	client.client.SetMaxRetries(0)
+	_, err = client.GetSealStatus(ctx)
+	require.Error(t, err)
+	assert.Equal(t, []string{"sys/seal-status error"}, seen)
+}
+
+func TestClient_SetPathPrefix(t *testing.T) {
+	ctx := context.Background()
+	fake := vaultfake.NewServer(vaultfake.WithKeys([]string{"key-1"}, 1))
+	defer fake.Close()
+	fakeURL, err := url.Parse(fake.URL())
+	require.NoError(t, err)
+	// The ingress only routes requests under /vault to Vault.
+	ingress := httptest.NewServer(http.StripPrefix("/vault", httputil.NewSingleHostReverseProxy(fakeURL)))
+	defer ingress.Close()
+
+	client, err := NewClient(ingress.URL, nil)
+	require.NoError(t, err)
+	require.NoError(t, client.SetPathPrefix("/vault/"))
+	var seen []string
+	client.SetResponseObserver(func(endpoint, statusClass string) {
+		seen = append(seen, endpoint+" "+statusClass)
+	})
+
+	status, err := client.GetSealStatus(ctx)
+	require.NoError(t, err)
+	assert.True(t, status.Sealed)
+	_, err = client.Unseal(ctx, secrets.NewSecretString("key-1"))
+	require.NoError(t, err)
+	role, err := client.GetRole(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, opsv1alpha1.PodRoleActive, role)
+	assert.Equal(t, []string{"sys/seal-status 2xx", "sys/unseal 2xx", "sys/health 2xx"}, seen, "endpoints are reported without the prefix")
+
+	unprefixed, err := NewClient(ingress.URL, nil)
+	require.NoError(t, err)
+	require.NoError(t, unprefixed.SetPathPrefix(""))
+	unprefixed.client.SetMaxRetries(0)
+	_, err = unprefixed.GetSealStatus(ctx)
+	assert.Error(t, err, "an empty prefix leaves the paths unchanged")
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/package vault
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+)
+
+// Headers Vault sets on responses to requests subject to a rate limit quota,
+// when enable_rate_limit_response_headers is on.
+const (
+	headerRetryAfter     = "Retry-After"
+	headerRateLimitReset = "X-Ratelimit-Reset"
+)
+
+// RateLimitError is returned when Vault answers 429 Too Many Requests on the
+// seal status or unseal endpoint, as it does once a rate limit quota is
+// exhausted.
+type RateLimitError struct {
+	// RetryAfter is how long Vault asked to wait before the next request, or
+	// 0 when the response said nothing about it.
+	RetryAfter time.Duration
+
+	err error
+}
+
+func (e *RateLimitError) Error() string { return e.err.Error() }
+
+func (e *RateLimitError) Unwrap() error { return e.err }
+
+// IsRateLimited reports whether err is Vault throttling the request, and how
+// long it asked the client to wait.
+func IsRateLimited(err error) (time.Duration, bool) {
+	var rateLimitErr *RateLimitError
+	if !errors.As(err, &rateLimitErr) {
+		return 0, false
+	}
+	return rateLimitErr.RetryAfter, true
+}
+
+// rateLimited wraps err in a RateLimitError when resp is a 429 response.
+func rateLimited(resp *api.Response, err error) error {
+	var respErr *api.ResponseError
+	if !errors.As(err, &respErr) || respErr.StatusCode != http.StatusTooManyRequests {
+		return err
+	}
+	var header http.Header
+	if resp != nil && resp.Response != nil {
+		header = resp.Header
+	}
+	return &RateLimitError{RetryAfter: retryAfter(header, time.Now()), err: err}
+}
+
+// retryAfter reads the wait Vault asked for from Retry-After, in seconds or
+// as an HTTP date, falling back to X-Ratelimit-Reset.
+func retryAfter(header http.Header, now time.Time) time.Duration {
+	for _, name := range []string{headerRetryAfter, headerRateLimitReset} {
+		value := header.Get(name)
+		if value == "" {
+			continue
+		}
+		if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if at, err := http.ParseTime(value); err == nil {
+			return max(at.Sub(now).Round(time.Second), 0)
+		}
+	}
+	return 0
+}
+
+// retryPolicy is the API client's retry policy, except that 429 responses
+// are returned at once: retrying them only uses up more of the quota.
+func retryPolicy(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	if err == nil && resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return false, nil
+	}
+	return api.DefaultRetryPolicy(ctx, resp, err)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// defaultDNSPort is the port of a resolver address given without one.
+const defaultDNSPort = "53"
+
+// Resolver looks up Vault hostnames for the clients it is set on, through a
+// chosen DNS server and, when caching, only once for all of those clients.
+type Resolver struct {
+	lookup func(ctx context.Context, host string) ([]string, error)
+	dialer *net.Dialer
+	cache  bool
+
+	mu    sync.Mutex
+	hosts map[string][]string
+}
+
+// NewResolver returns a Resolver querying server, or the system resolver
+// when server is empty. With cache set every hostname is looked up once and
+// its addresses are reused for the lifetime of the Resolver.
+func NewResolver(server string, cache bool) *Resolver {
+	resolver := net.DefaultResolver
+	if server != "" {
+		if _, _, err := net.SplitHostPort(server); err != nil {
+			server = net.JoinHostPort(server, defaultDNSPort)
+		}
+		resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, network, server)
+			},
+		}
+	}
+	return &Resolver{
+		lookup: resolver.LookupHost,
+		dialer: &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second},
+		cache:  cache,
+		hosts:  make(map[string][]string),
+	}
+}
+
+// DialContext connects to address, trying each address its host resolves to
+// in turn.
+func (r *Resolver) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil || net.ParseIP(host) != nil {
+		return r.dialer.DialContext(ctx, network, address)
+	}
+	addrs, err := r.resolve(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	var errs []error
+	for _, addr := range addrs {
+		conn, err := r.dialer.DialContext(ctx, network, net.JoinHostPort(addr, port))
+		if err == nil {
+			return conn, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, errors.Join(errs...)
+}
+
+// resolve returns the addresses of host. Failed lookups are not cached, so
+// the next attempt looks the host up again.
+func (r *Resolver) resolve(ctx context.Context, host string) ([]string, error) {
+	if r.cache {
+		r.mu.Lock()
+		addrs, ok := r.hosts[host]
+		r.mu.Unlock()
+		if ok {
+			return addrs, nil
+		}
+	}
+	addrs, err := r.lookup(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", host, err)
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("failed to resolve %s: no addresses", host)
+	}
+	if r.cache {
+		r.mu.Lock()
+		r.hosts[host] = addrs
+		r.mu.Unlock()
+	}
+	return addrs, nil
+}
@@ -18,12 +18,19 @@ package webhook
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -32,6 +39,9 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/controller"
+	"github.com/panteparak/vault-unsealer/internal/metrics"
+	"github.com/panteparak/vault-unsealer/internal/schedule"
 )
 
 // log is for logging in this package.
@@ -40,6 +50,11 @@ var vaultunsealeradmissionlog = logf.Log.WithName("vaultunsealer-admission")
 // VaultUnsealerValidator validates VaultUnsealer resources
 type VaultUnsealerValidator struct {
 	Client client.Client
+
+	// MinInterval and MaxInterval are the bounds the controller clamps
+	// spec.interval to. Zero values use the API defaults.
+	MinInterval time.Duration
+	MaxInterval time.Duration
 }
 
 //+kubebuilder:webhook:path=/validate-ops-autounseal-vault-io-v1alpha1-vaultunsealer,mutating=false,failurePolicy=fail,sideEffects=None,groups=ops.autounseal.vault.io,resources=vaultunsealers,verbs=create;update,versions=v1alpha1,name=vvaultunsealer.kb.io,admissionReviewVersions=v1
@@ -51,7 +66,9 @@ func (v *VaultUnsealerValidator) ValidateCreate(ctx context.Context, obj runtime
 	vaultUnsealer := obj.(*opsv1alpha1.VaultUnsealer)
 	vaultunsealeradmissionlog.Info("validate create", "name", vaultUnsealer.Name)
 
-	return v.validateVaultUnsealer(ctx, vaultUnsealer)
+	warnings, err := v.validateVaultUnsealer(ctx, vaultUnsealer)
+	recordValidation("create", warnings, err)
+	return warnings, err
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -59,7 +76,9 @@ func (v *VaultUnsealerValidator) ValidateUpdate(ctx context.Context, oldObj, new
 	vaultUnsealer := newObj.(*opsv1alpha1.VaultUnsealer)
 	vaultunsealeradmissionlog.Info("validate update", "name", vaultUnsealer.Name)
 
-	return v.validateVaultUnsealer(ctx, vaultUnsealer)
+	warnings, err := v.validateVaultUnsealer(ctx, vaultUnsealer)
+	recordValidation("update", warnings, err)
+	return warnings, err
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -71,6 +90,42 @@ func (v *VaultUnsealerValidator) ValidateDelete(ctx context.Context, obj runtime
 	return nil, nil
 }
 
+// Validate runs the admission validation rules against a VaultUnsealer without
+// an admission request, e.g. for offline linting of manifests.
+func (v *VaultUnsealerValidator) Validate(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) (admission.Warnings, error) {
+	return v.validateVaultUnsealer(ctx, vaultUnsealer)
+}
+
+// listIndex matches list indices in field paths so they can be collapsed in metric labels.
+var listIndex = regexp.MustCompile(`\[[0-9]+\]`)
+
+// recordValidation records the outcome of an admission validation and, for
+// denials, the field and error type of each rule that fired.
+func recordValidation(operation string, warnings admission.Warnings, err error) {
+	outcome := "allowed"
+	switch {
+	case err != nil:
+		outcome = "denied"
+	case len(warnings) > 0:
+		outcome = "warned"
+	}
+	metrics.WebhookValidations.WithLabelValues(operation, outcome).Inc()
+
+	var aggregate utilerrors.Aggregate
+	if !errors.As(err, &aggregate) {
+		return
+	}
+	for _, e := range aggregate.Errors() {
+		var fieldErr *field.Error
+		if !errors.As(e, &fieldErr) {
+			metrics.WebhookValidationFailures.WithLabelValues("", "Unknown").Inc()
+			continue
+		}
+		fieldPath := listIndex.ReplaceAllString(fieldErr.Field, "[*]")
+		metrics.WebhookValidationFailures.WithLabelValues(fieldPath, string(fieldErr.Type)).Inc()
+	}
+}
+
 // validateVaultUnsealer performs comprehensive validation of VaultUnsealer spec
 func (v *VaultUnsealerValidator) validateVaultUnsealer(ctx context.Context, vaultUnsealer *opsv1alpha1.VaultUnsealer) (admission.Warnings, error) {
 	var allErrs field.ErrorList
@@ -80,14 +135,51 @@ func (v *VaultUnsealerValidator) validateVaultUnsealer(ctx context.Context, vaul
 	if errs := v.validateVaultConnection(vaultUnsealer.Spec.Vault); len(errs) > 0 {
 		allErrs = append(allErrs, errs...)
 	}
+	if vaultUnsealer.Spec.Vault.AllowPodAddressOverride {
+		warnings = append(warnings, fmt.Sprintf("spec.vault.allowPodAddressOverride lets anyone who can annotate the Vault pods send unseal keys to any address through %s",
+			opsv1alpha1.AnnotationPodAddress))
+	}
 
-	// Validate unseal keys secret references
-	if errs := v.validateUnsealKeysSecretRefs(vaultUnsealer.Spec.UnsealKeysSecretRefs); len(errs) > 0 {
-		allErrs = append(allErrs, errs...)
+	// Validate unseal keys secret references; they are optional when keys
+	// come from HCP Vault Secrets or custodians instead, or are never
+	// submitted in monitor-only mode
+	if len(vaultUnsealer.Spec.UnsealKeysSecretRefs) > 0 ||
+		(vaultUnsealer.Spec.HCPVaultSecrets == nil && len(vaultUnsealer.Spec.Custodians) == 0 && !vaultUnsealer.Spec.Mode.MonitorOnly) {
+		if errs := v.validateUnsealKeysSecretRefs(vaultUnsealer.Spec.UnsealKeysSecretRefs); len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+		}
+	}
+
+	// Validate custodians and their quorum
+	allErrs = append(allErrs, v.validateCustodians(vaultUnsealer.Spec)...)
+
+	// Validate the status ConfigMap name by rendering it for this resource
+	if spec := vaultUnsealer.Spec.StatusConfigMap; spec != nil {
+		namePath := field.NewPath("spec", "statusConfigMap", "nameTemplate")
+		name, err := controller.RenderStatusConfigMapName(spec.NameTemplate,
+			controller.StatusConfigMapNameData{Name: vaultUnsealer.Name, Namespace: vaultUnsealer.Namespace})
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(namePath, spec.NameTemplate, err.Error()))
+		} else if msgs := validation.IsDNS1123Subdomain(name); len(msgs) > 0 {
+			allErrs = append(allErrs, field.Invalid(namePath, spec.NameTemplate,
+				fmt.Sprintf("rendered name %q is not a valid ConfigMap name: %s", name, strings.Join(msgs, "; "))))
+		}
+	}
+
+	// Validate the readiness gate condition type
+	if gate := vaultUnsealer.Spec.ReadinessGate; gate != nil && gate.ConditionType != "" {
+		for _, msg := range validation.IsQualifiedName(gate.ConditionType) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "readinessGate", "conditionType"), gate.ConditionType, msg))
+		}
+	}
+
+	// Validate HCP Vault Secrets source if provided
+	if source := vaultUnsealer.Spec.HCPVaultSecrets; source != nil {
+		allErrs = append(allErrs, validateHCPVaultSecrets(source)...)
 	}
 
 	// Validate vault label selector
-	if errs := v.validateVaultLabelSelector(vaultUnsealer.Spec.VaultLabelSelector); len(errs) > 0 {
+	if errs := v.validateVaultLabelSelector(vaultUnsealer.Spec.VaultLabelSelector, vaultUnsealer.Spec.VaultLabelSelectors); len(errs) > 0 {
 		allErrs = append(allErrs, errs...)
 	}
 
@@ -101,17 +193,96 @@ func (v *VaultUnsealerValidator) validateVaultUnsealer(ctx context.Context, vaul
 
 	// Validate interval if specified
 	if vaultUnsealer.Spec.Interval != nil {
-		if errs := v.validateInterval(*vaultUnsealer.Spec.Interval); len(errs) > 0 {
+		if errs, warns := v.validateInterval(field.NewPath("spec", "interval"), "interval", *vaultUnsealer.Spec.Interval); len(errs) > 0 || len(warns) > 0 {
 			allErrs = append(allErrs, errs...)
+			warnings = append(warnings, warns...)
+		}
+	}
+
+	// Validate fast interval if specified
+	if fast := vaultUnsealer.Spec.FastInterval; fast != nil {
+		errs, warns := v.validateInterval(field.NewPath("spec", "fastInterval"), "fast interval", *fast)
+		allErrs = append(allErrs, errs...)
+		warnings = append(warnings, warns...)
+		interval := opsv1alpha1.DefaultInterval
+		if vaultUnsealer.Spec.Interval != nil {
+			interval = vaultUnsealer.Spec.Interval.Duration
+		}
+		if len(errs) == 0 && fast.Duration > interval {
+			warnings = append(warnings, fmt.Sprintf("fast interval %s is longer than the interval %s, %s will be used", fast.Duration, interval, interval))
+		}
+	}
+
+	// Validate seal watch if specified
+	if vaultUnsealer.Spec.SealWatch != nil {
+		if errs, warns := v.validateSealWatch(*vaultUnsealer.Spec.SealWatch, vaultUnsealer.Spec.Interval); len(errs) > 0 || len(warns) > 0 {
+			allErrs = append(allErrs, errs...)
+			warnings = append(warnings, warns...)
+		}
+	}
+
+	// Validate canary unsealing if enabled
+	if canary := vaultUnsealer.Spec.Canary; canary != nil && canary.Enabled {
+		errs, warns := v.validateCanary(*canary, vaultUnsealer.Spec.Mode)
+		allErrs = append(allErrs, errs...)
+		warnings = append(warnings, warns...)
+	}
+
+	// Validate key submission delay if specified
+	if vaultUnsealer.Spec.KeySubmissionDelay != nil {
+		errs, warns := v.validateKeySubmissionDelay(*vaultUnsealer.Spec.KeySubmissionDelay, vaultUnsealer.Spec.Interval, vaultUnsealer.Spec.KeyThreshold)
+		allErrs = append(allErrs, errs...)
+		warnings = append(warnings, warns...)
+	}
+
+	if vaultUnsealer.Spec.ReconcileBudget != nil {
+		errs, warns := v.validateReconcileBudget(*vaultUnsealer.Spec.ReconcileBudget, vaultUnsealer.Spec.Interval)
+		allErrs = append(allErrs, errs...)
+		warnings = append(warnings, warns...)
+	}
+
+	// Validate secrets service account name
+	if name := vaultUnsealer.Spec.SecretsServiceAccountName; name != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(name) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "secretsServiceAccountName"), name, msg))
 		}
 	}
 
+	// Validate maintenance windows
+	if errs := v.validateMaintenanceWindows(vaultUnsealer.Spec.MaintenanceWindows); len(errs) > 0 {
+		allErrs = append(allErrs, errs...)
+	}
+
 	// Validate mode configuration
 	if errs, warns := v.validateMode(vaultUnsealer.Spec.Mode); len(errs) > 0 || len(warns) > 0 {
 		allErrs = append(allErrs, errs...)
 		warnings = append(warnings, warns...)
 	}
 
+	// Validate readiness policy
+	if errs, warns := v.validateReadinessPolicy(vaultUnsealer.Spec.ReadinessPolicy, vaultUnsealer.Spec.Mode); len(errs) > 0 || len(warns) > 0 {
+		allErrs = append(allErrs, errs...)
+		warnings = append(warnings, warns...)
+	}
+
+	// Validate error policy
+	switch policy := vaultUnsealer.Spec.ErrorPolicy; policy {
+	case "", opsv1alpha1.ErrorPolicyContinueOtherPods, opsv1alpha1.ErrorPolicyAbortReconcile:
+	default:
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("spec", "errorPolicy"), policy, []string{
+			opsv1alpha1.ErrorPolicyAbortReconcile, opsv1alpha1.ErrorPolicyContinueOtherPods,
+		}))
+	}
+
+	// Validate priority
+	switch priority := vaultUnsealer.Spec.Priority; priority {
+	case "", opsv1alpha1.PriorityHigh, opsv1alpha1.PriorityNormal, opsv1alpha1.PriorityLow:
+	default:
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("spec", "priority"), priority, []string{
+			opsv1alpha1.PriorityHigh, opsv1alpha1.PriorityNormal, opsv1alpha1.PriorityLow,
+		}))
+	}
+
 	if len(allErrs) > 0 {
 		return warnings, allErrs.ToAggregate()
 	}
@@ -124,22 +295,125 @@ func (v *VaultUnsealerValidator) validateVaultConnection(vault opsv1alpha1.Vault
 	var allErrs field.ErrorList
 	fldPath := field.NewPath("spec", "vault")
 
-	// Validate URL
-	if vault.URL == "" {
-		allErrs = append(allErrs, field.Required(fldPath.Child("url"), "Vault URL is required"))
+	// Validate URL, read from address or its deprecated alias url
+	addressPath := fldPath.Child("address")
+	if vault.Address == "" && vault.URL != "" {
+		addressPath = fldPath.Child("url")
+	}
+	address := vault.Endpoint()
+	if address == "" {
+		allErrs = append(allErrs, field.Required(addressPath, "Vault URL is required"))
 	} else {
 		// Parse and validate URL format
-		parsedURL, err := url.Parse(vault.URL)
+		parsedURL, err := url.Parse(address)
 		if err != nil {
-			allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), vault.URL, fmt.Sprintf("invalid URL format: %v", err)))
+			allErrs = append(allErrs, field.Invalid(addressPath, address, fmt.Sprintf("invalid URL format: %v", err)))
 		} else {
 			// Validate scheme
 			if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), vault.URL, "URL scheme must be http or https"))
+				allErrs = append(allErrs, field.Invalid(addressPath, address, "URL scheme must be http or https"))
 			}
 			// Validate host
 			if parsedURL.Host == "" {
-				allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), vault.URL, "URL must include host"))
+				allErrs = append(allErrs, field.Invalid(addressPath, address, "URL must include host"))
+			}
+		}
+	}
+	if vault.Address != "" && vault.URL != "" && vault.Address != vault.URL {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), vault.URL, "url is a deprecated alias of address and must match it when both are set"))
+	}
+
+	// Validate per-pod host template by rendering it for a sample pod
+	if vault.PerPodHostTemplate != "" {
+		host, err := controller.RenderPodHost(vault.PerPodHostTemplate, controller.PodHostData{Ordinal: 0, PodName: "vault-0", Namespace: "default"})
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("perPodHostTemplate"), vault.PerPodHostTemplate, err.Error()))
+		} else if msgs := validation.IsDNS1123Subdomain(host); len(msgs) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("perPodHostTemplate"), vault.PerPodHostTemplate,
+				fmt.Sprintf("rendered host %q is not a valid hostname: %s", host, strings.Join(msgs, "; "))))
+		}
+	}
+
+	// Validate the Vault container name
+	if vault.ContainerName != "" {
+		for _, msg := range validation.IsDNS1123Label(vault.ContainerName) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("containerName"), vault.ContainerName, msg))
+		}
+	}
+
+	// The token is sent to the configured address, so it may only come from
+	// the VaultUnsealer's own namespace
+	if ref := vault.TokenSecretRef; ref != nil {
+		tokenPath := fldPath.Child("tokenSecretRef")
+		if ref.Name == "" {
+			allErrs = append(allErrs, field.Required(tokenPath.Child("name"), "secret name is required"))
+		}
+		if ref.Key == "" {
+			allErrs = append(allErrs, field.Required(tokenPath.Child("key"), "secret key is required"))
+		}
+		if ref.Namespace != "" {
+			allErrs = append(allErrs, field.Forbidden(tokenPath.Child("namespace"), "the token secret must be in the VaultUnsealer's namespace"))
+		}
+	}
+
+	if vault.PathPrefix != "" {
+		if u, err := url.Parse(vault.PathPrefix); err != nil || !strings.HasPrefix(vault.PathPrefix, "/") || u.Host != "" || u.RawQuery != "" || u.Fragment != "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("pathPrefix"), vault.PathPrefix, "must be an absolute path without a query string, such as /vault"))
+		}
+	}
+
+	// Validate mesh sidecar listener
+	if sidecar := vault.MeshSidecar; sidecar != nil {
+		sidecarPath := fldPath.Child("meshSidecar")
+		switch sidecar.Scheme {
+		case "", "http", "https":
+		default:
+			allErrs = append(allErrs, field.NotSupported(sidecarPath.Child("scheme"), sidecar.Scheme, []string{"http", "https"}))
+		}
+		if sidecar.Port < 1 || sidecar.Port > 65535 {
+			allErrs = append(allErrs, field.Invalid(sidecarPath.Child("port"), sidecar.Port, "must be a port number between 1 and 65535"))
+		}
+	}
+
+	if dns := vault.DNS; dns != nil {
+		dnsPath := fldPath.Child("dns")
+		switch dns.Resolution {
+		case "", opsv1alpha1.DNSResolutionPerAttempt, opsv1alpha1.DNSResolutionPerReconcile:
+		default:
+			allErrs = append(allErrs, field.NotSupported(dnsPath.Child("resolution"), dns.Resolution,
+				[]string{opsv1alpha1.DNSResolutionPerAttempt, opsv1alpha1.DNSResolutionPerReconcile}))
+		}
+		if dns.Resolver != "" && !validResolverAddress(dns.Resolver) {
+			allErrs = append(allErrs, field.Invalid(dnsPath.Child("resolver"), dns.Resolver, "must be a host or host:port, such as 10.96.0.10:53"))
+		}
+	}
+
+	// The apiserver picks the connection to the pod, so options shaping that
+	// connection do not apply
+	if vault.APIServerProxy {
+		proxyPath := fldPath.Child("apiServerProxy")
+		if vault.MeshSidecar != nil {
+			allErrs = append(allErrs, field.Invalid(proxyPath, vault.APIServerProxy, "cannot be combined with meshSidecar"))
+		}
+		if vault.PerPodHostTemplate != "" {
+			allErrs = append(allErrs, field.Invalid(proxyPath, vault.APIServerProxy, "cannot be combined with perPodHostTemplate"))
+		}
+		if vault.DNS != nil {
+			allErrs = append(allErrs, field.Invalid(proxyPath, vault.APIServerProxy, "cannot be combined with dns"))
+		}
+	}
+
+	// Validate health check overrides
+	if healthCheck := vault.HealthCheck; healthCheck != nil {
+		healthPath := fldPath.Child("healthCheck")
+		if healthCheck.Path != "" {
+			if u, err := url.Parse(healthCheck.Path); err != nil || !strings.HasPrefix(healthCheck.Path, "/") || u.Host != "" {
+				allErrs = append(allErrs, field.Invalid(healthPath.Child("path"), healthCheck.Path, "must be an absolute path such as /v1/sys/health"))
+			}
+		}
+		for i, code := range healthCheck.AcceptedStatusCodes {
+			if code < 100 || code > 599 {
+				allErrs = append(allErrs, field.Invalid(healthPath.Child("acceptedStatusCodes").Index(i), code, "must be an HTTP status code between 100 and 599"))
 			}
 		}
 	}
@@ -150,6 +424,9 @@ func (v *VaultUnsealerValidator) validateVaultConnection(vault opsv1alpha1.Vault
 			allErrs = append(allErrs, errs...)
 		}
 	}
+	for i, ref := range vault.CABundleSecretRefs {
+		allErrs = append(allErrs, v.validateSecretRef(ref, fldPath.Child("caBundleSecretRefs").Index(i))...)
+	}
 
 	return allErrs
 }
@@ -219,13 +496,105 @@ func (v *VaultUnsealerValidator) validateSecretRef(secretRef opsv1alpha1.SecretR
 	return allErrs
 }
 
-// validateVaultLabelSelector validates the vault label selector
-func (v *VaultUnsealerValidator) validateVaultLabelSelector(labelSelector string) field.ErrorList {
+// validateCustodians validates the custodian key sources and
+// spec.minCustodians
+func (v *VaultUnsealerValidator) validateCustodians(spec opsv1alpha1.VaultUnsealerSpec) field.ErrorList {
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("spec", "custodians")
+	minPath := field.NewPath("spec", "minCustodians")
+
+	if len(spec.Custodians) == 0 {
+		if spec.MinCustodians > 0 {
+			allErrs = append(allErrs, field.Invalid(minPath, spec.MinCustodians, "minCustodians requires spec.custodians"))
+		}
+		return allErrs
+	}
+
+	// Shares from other sources would count towards the threshold without
+	// belonging to any custodian, undermining the quorum
+	if len(spec.UnsealKeysSecretRefs) > 0 || spec.HCPVaultSecrets != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "custodians cannot be combined with unsealKeysSecretRefs or hcpVaultSecrets"))
+	}
+
+	seen := make(map[string]int)
+	for i, custodian := range spec.Custodians {
+		if custodian.Name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Index(i).Child("name"), "custodian name is required"))
+		} else if prevIndex, exists := seen[custodian.Name]; exists {
+			allErrs = append(allErrs, field.Duplicate(fldPath.Index(i).Child("name"), fmt.Sprintf("duplicate custodian name (same as index %d)", prevIndex)))
+		} else {
+			seen[custodian.Name] = i
+		}
+		allErrs = append(allErrs, v.validateSecretRef(custodian.SecretRef, fldPath.Index(i).Child("secretRef"))...)
+		if custodian.MaxShares < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("maxShares"), custodian.MaxShares, "maxShares must be positive"))
+		}
+	}
+
+	switch {
+	case spec.MinCustodians < 0:
+		allErrs = append(allErrs, field.Invalid(minPath, spec.MinCustodians, "minCustodians must be non-negative"))
+	case int(spec.MinCustodians) > len(spec.Custodians):
+		allErrs = append(allErrs, field.Invalid(minPath, spec.MinCustodians,
+			fmt.Sprintf("minCustodians cannot exceed the number of custodians (%d)", len(spec.Custodians))))
+	case spec.KeyThreshold > 0 && int(spec.MinCustodians) > spec.KeyThreshold:
+		// Each submitted share comes from one custodian
+		allErrs = append(allErrs, field.Invalid(minPath, spec.MinCustodians,
+			fmt.Sprintf("minCustodians cannot exceed keyThreshold (%d)", spec.KeyThreshold)))
+	}
+
+	return allErrs
+}
+
+// validateHCPVaultSecrets validates the HCP Vault Secrets key source
+func validateHCPVaultSecrets(source *opsv1alpha1.HCPVaultSecretsSource) field.ErrorList {
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("spec", "hcpVaultSecrets")
+
+	for _, required := range []struct{ name, value string }{
+		{"organizationID", source.OrganizationID},
+		{"projectID", source.ProjectID},
+		{"appName", source.AppName},
+	} {
+		if required.value == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child(required.name), required.name+" is required"))
+		}
+	}
+
+	if len(source.SecretNames) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("secretNames"), "at least one secret name is required"))
+	}
+	for i, name := range source.SecretNames {
+		if name == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("secretNames").Index(i), "secret name must not be empty"))
+		}
+	}
+
+	if source.CredentialsSecretName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("credentialsSecretName"), "credentials secret name is required"))
+	} else if !isValidKubernetesName(source.CredentialsSecretName) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("credentialsSecretName"), source.CredentialsSecretName, "invalid Kubernetes secret name"))
+	}
+
+	return allErrs
+}
+
+// validateVaultLabelSelector validates the vault label selectors
+func (v *VaultUnsealerValidator) validateVaultLabelSelector(labelSelector string, labelSelectors []string) field.ErrorList {
 	var allErrs field.ErrorList
 	fldPath := field.NewPath("spec", "vaultLabelSelector")
 
+	listPath := field.NewPath("spec", "vaultLabelSelectors")
+	for i, selector := range labelSelectors {
+		if !isValidLabelSelector(selector) {
+			allErrs = append(allErrs, field.Invalid(listPath.Index(i), selector, "invalid label selector format"))
+		}
+	}
+
 	if labelSelector == "" {
-		allErrs = append(allErrs, field.Required(fldPath, "vault label selector is required"))
+		if len(labelSelectors) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath, "vault label selector is required"))
+		}
 		return allErrs
 	}
 
@@ -262,29 +631,153 @@ func (v *VaultUnsealerValidator) validateKeyThreshold(keyThreshold int, secretRe
 	return allErrs, warnings
 }
 
-// validateInterval validates the reconciliation interval
-func (v *VaultUnsealerValidator) validateInterval(interval metav1.Duration) field.ErrorList {
+// validateInterval validates a reconciliation interval; name is how it is
+// called in messages
+func (v *VaultUnsealerValidator) validateInterval(fldPath *field.Path, name string, interval metav1.Duration) (field.ErrorList, admission.Warnings) {
 	var allErrs field.ErrorList
-	fldPath := field.NewPath("spec", "interval")
+	var warnings admission.Warnings
 
 	duration := interval.Duration
 	if duration <= 0 {
-		allErrs = append(allErrs, field.Invalid(fldPath, interval.String(), "interval must be positive"))
+		allErrs = append(allErrs, field.Invalid(fldPath, interval.String(), name+" must be positive"))
+		return allErrs, warnings
+	}
+
+	minInterval, maxInterval := v.intervalBounds()
+	if duration < minInterval {
+		warnings = append(warnings, fmt.Sprintf("%s %s is below the minimum of %s, %s will be used", name, duration, minInterval, minInterval))
+	}
+	if duration > maxInterval {
+		warnings = append(warnings, fmt.Sprintf("%s %s is above the maximum of %s, %s will be used", name, duration, maxInterval, maxInterval))
+	}
+
+	return allErrs, warnings
+}
+
+// intervalBounds returns the configured interval bounds, falling back to the API defaults.
+func (v *VaultUnsealerValidator) intervalBounds() (time.Duration, time.Duration) {
+	minInterval, maxInterval := v.MinInterval, v.MaxInterval
+	if minInterval <= 0 {
+		minInterval = opsv1alpha1.DefaultMinInterval
+	}
+	if maxInterval <= 0 {
+		maxInterval = opsv1alpha1.DefaultMaxInterval
+	}
+	return minInterval, maxInterval
+}
+
+// validateSealWatch validates the seal watch configuration
+func (v *VaultUnsealerValidator) validateSealWatch(sealWatch opsv1alpha1.SealWatchSpec, interval *metav1.Duration) (field.ErrorList, admission.Warnings) {
+	var allErrs field.ErrorList
+	var warnings admission.Warnings
+	fldPath := field.NewPath("spec", "sealWatch", "interval")
+
+	if sealWatch.Interval.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, sealWatch.Interval.String(), "seal watch interval must be positive"))
+		return allErrs, warnings
+	}
+
+	reconcileInterval := opsv1alpha1.DefaultInterval
+	if interval != nil {
+		reconcileInterval = interval.Duration
+	}
+	if sealWatch.Interval.Duration >= reconcileInterval {
+		warnings = append(warnings, fmt.Sprintf("seal watch interval %s is not shorter than the reconcile interval %s, so it detects nothing the reconcile would not", sealWatch.Interval.Duration, reconcileInterval))
+	}
+
+	return allErrs, warnings
+}
+
+// validateCanary validates the canary unsealing configuration
+func (v *VaultUnsealerValidator) validateCanary(canary opsv1alpha1.CanarySpec, mode opsv1alpha1.ModeSpec) (field.ErrorList, admission.Warnings) {
+	var allErrs field.ErrorList
+	var warnings admission.Warnings
+	fldPath := field.NewPath("spec", "canary")
+
+	if canary.PodCount < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("podCount"), canary.PodCount, "canary pod count must not be negative"))
+	}
+	if canary.SoakTime != nil && canary.SoakTime.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("soakTime"), canary.SoakTime.String(), "canary soak time must not be negative"))
 	}
+	if mode.MonitorOnly {
+		warnings = append(warnings, "canary unsealing has no effect when mode.monitorOnly is set, since no keys are submitted")
+	} else if mode.StopsAfterFirstUnseal() {
+		warnings = append(warnings, "canary unsealing has no effect when mode.stopAfterFirstUnseal is set, since only one pod is unsealed")
+	}
+
+	return allErrs, warnings
+}
+
+// validateKeySubmissionDelay validates the pause between key submissions
+func (v *VaultUnsealerValidator) validateKeySubmissionDelay(delay metav1.Duration, interval *metav1.Duration, keyThreshold int) (field.ErrorList, admission.Warnings) {
+	var allErrs field.ErrorList
+	var warnings admission.Warnings
+	fldPath := field.NewPath("spec", "keySubmissionDelay")
 
-	// Warn about very short intervals (less than 10 seconds)
-	if duration.Seconds() < 10 {
-		// Note: This would be a warning in real implementation, but field.ErrorList doesn't support warnings
-		// In practice, you'd return this as a warning through the admission.Warnings return value
-		// For now, just add a note that this is a very short interval
-		vaultunsealeradmissionlog.Info("Interval is very short, consider using a longer interval", "seconds", duration.Seconds())
+	if delay.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, delay.String(), "key submission delay must not be negative"))
+		return allErrs, warnings
 	}
 
-	// Warn about very long intervals (more than 1 hour)
-	if duration.Seconds() > 3600 {
-		// Note: This would be a warning in real implementation
-		// For now, just add a note that this is a very long interval
-		vaultunsealeradmissionlog.Info("Interval is very long, consider using a shorter interval", "seconds", duration.Seconds())
+	reconcileInterval := opsv1alpha1.DefaultInterval
+	if interval != nil {
+		reconcileInterval = interval.Duration
+	}
+	pauses := max(keyThreshold-1, 1)
+	if total := delay.Duration * time.Duration(pauses); total >= reconcileInterval {
+		warnings = append(warnings, fmt.Sprintf("key submission delay %s adds up to %s per pod, which is not shorter than the reconcile interval %s, so pods may never be unsealed within one reconcile", delay.Duration, total, reconcileInterval))
+	}
+
+	return allErrs, warnings
+}
+
+// validateReconcileBudget validates the time budget of one reconcile
+func (v *VaultUnsealerValidator) validateReconcileBudget(budget metav1.Duration, interval *metav1.Duration) (field.ErrorList, admission.Warnings) {
+	var allErrs field.ErrorList
+	var warnings admission.Warnings
+	fldPath := field.NewPath("spec", "reconcileBudget")
+
+	if budget.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, budget.String(), "reconcile budget must be positive"))
+		return allErrs, warnings
+	}
+
+	reconcileInterval := opsv1alpha1.DefaultInterval
+	if interval != nil {
+		reconcileInterval = interval.Duration
+	}
+	if budget.Duration >= reconcileInterval {
+		warnings = append(warnings, fmt.Sprintf("reconcile budget %s is not shorter than the reconcile interval %s, which already bounds each reconcile, so no pods are ever deferred", budget.Duration, reconcileInterval))
+	}
+
+	return allErrs, warnings
+}
+
+// validateMaintenanceWindows validates the maintenance window schedules
+func (v *VaultUnsealerValidator) validateMaintenanceWindows(windows []opsv1alpha1.MaintenanceWindow) field.ErrorList {
+	var allErrs field.ErrorList
+	fldPath := field.NewPath("spec", "maintenanceWindows")
+
+	for i, window := range windows {
+		idxPath := fldPath.Index(i)
+		if _, err := schedule.Parse(window.Schedule); err != nil {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("schedule"), window.Schedule, err.Error()))
+		}
+		if window.Duration.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(idxPath.Child("duration"), window.Duration.String(), "maintenance window duration must be positive"))
+		}
+		if window.TimeZone != "" {
+			if _, err := time.LoadLocation(window.TimeZone); err != nil {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("timeZone"), window.TimeZone, "unknown time zone"))
+			}
+		}
+		switch window.Mode {
+		case "", opsv1alpha1.MaintenanceWindowSuppress, opsv1alpha1.MaintenanceWindowAllow:
+		default:
+			allErrs = append(allErrs, field.NotSupported(idxPath.Child("mode"), window.Mode,
+				[]string{opsv1alpha1.MaintenanceWindowSuppress, opsv1alpha1.MaintenanceWindowAllow}))
+		}
 	}
 
 	return allErrs
@@ -295,11 +788,38 @@ func (v *VaultUnsealerValidator) validateMode(mode opsv1alpha1.ModeSpec) (field.
 	var allErrs field.ErrorList
 	var warnings admission.Warnings
 
-	// Currently, mode only has HA field, which is a boolean
-	// Future mode configurations could be validated here
+	switch mode.Scope {
+	case "", opsv1alpha1.ReplicaScopeCluster, opsv1alpha1.ReplicaScopeSingle:
+	default:
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("spec", "mode", "scope"), mode.Scope,
+			[]string{opsv1alpha1.ReplicaScopeCluster, opsv1alpha1.ReplicaScopeSingle}))
+	}
+
+	if mode.LegacySingle() {
+		warnings = append(warnings, "HA mode is disabled, unsealing will stop after the first successful pod; "+
+			"spec.mode.ha is deprecated, set spec.mode.scope and spec.mode.stopAfterFirstUnseal instead")
+	}
+
+	return allErrs, warnings
+}
+
+// validateReadinessPolicy validates the readiness policy
+func (v *VaultUnsealerValidator) validateReadinessPolicy(policy string, mode opsv1alpha1.ModeSpec) (field.ErrorList, admission.Warnings) {
+	var allErrs field.ErrorList
+	var warnings admission.Warnings
+	fldPath := field.NewPath("spec", "readinessPolicy")
 
-	if !mode.HA {
-		warnings = append(warnings, "HA mode is disabled, unsealing will stop after the first successful pod")
+	switch policy {
+	case "", opsv1alpha1.ReadinessPolicyAnyPod:
+	case opsv1alpha1.ReadinessPolicyQuorum, opsv1alpha1.ReadinessPolicyAllPods, opsv1alpha1.ReadinessPolicyActivePod:
+		if mode.ReplicaScope() == opsv1alpha1.ReplicaScopeSingle || mode.StopsAfterFirstUnseal() {
+			warnings = append(warnings, fmt.Sprintf("readinessPolicy %s has no effect with a single replica or spec.mode.stopAfterFirstUnseal", policy))
+		}
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath, policy, []string{
+			opsv1alpha1.ReadinessPolicyActivePod, opsv1alpha1.ReadinessPolicyAllPods, opsv1alpha1.ReadinessPolicyAnyPod,
+			opsv1alpha1.ReadinessPolicyQuorum,
+		}))
 	}
 
 	return allErrs, warnings
@@ -359,3 +879,17 @@ func (v *VaultUnsealerValidator) SetupWithManager(mgr ctrl.Manager) error {
 		WithValidator(v).
 		Complete()
 }
+
+// validResolverAddress reports whether address is an IP or hostname,
+// optionally with a port.
+func validResolverAddress(address string) bool {
+	host := address
+	if h, port, err := net.SplitHostPort(address); err == nil {
+		n, err := strconv.Atoi(port)
+		if err != nil || n < 1 || n > 65535 {
+			return false
+		}
+		host = h
+	}
+	return net.ParseIP(host) != nil || len(validation.IsDNS1123Subdomain(host)) == 0
+}
@@ -21,13 +21,17 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/metrics"
 )
 
 func TestVaultUnsealerValidator_ValidateCreate(t *testing.T) {
@@ -57,7 +61,7 @@ func TestVaultUnsealerValidator_ValidateCreate(t *testing.T) {
 					},
 					VaultLabelSelector: "app.kubernetes.io/name=vault",
 					Mode: opsv1alpha1.ModeSpec{
-						HA: true,
+						HA: ptr.To(true),
 					},
 					KeyThreshold: 3,
 				},
@@ -65,6 +69,62 @@ func TestVaultUnsealerValidator_ValidateCreate(t *testing.T) {
 			wantErr:      false,
 			wantWarnings: 0,
 		},
+		{
+			name: "explicit scope without ha",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						Scope:                opsv1alpha1.ReplicaScopeSingle,
+						StopAfterFirstUnseal: ptr.To(true),
+					},
+					KeyThreshold: 3,
+				},
+			},
+			wantErr:      false,
+			wantWarnings: 0,
+		},
+		{
+			name: "invalid replica scope",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						Scope: "Everything",
+					},
+					KeyThreshold: 3,
+				},
+			},
+			wantErr:       true,
+			wantWarnings:  0,
+			errorContains: "spec.mode.scope",
+		},
 		{
 			name: "missing Vault URL",
 			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
@@ -74,7 +134,1131 @@ func TestVaultUnsealerValidator_ValidateCreate(t *testing.T) {
 				},
 				Spec: opsv1alpha1.VaultUnsealerSpec{
 					Vault: opsv1alpha1.VaultConnectionSpec{
-						URL: "", // Missing URL
+						URL: "", // Missing URL
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3, // Avoid warning
+				},
+			},
+			wantErr:       true,
+			errorContains: "Vault URL is required",
+		},
+		{
+			name: "valid Vault address",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						Address: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid Vault address",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						Address: "ftp://vault.example.com",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3,
+				},
+			},
+			wantErr:       true,
+			errorContains: "spec.vault.address",
+		},
+		{
+			name: "url not matching address",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						Address: "https://vault.example.com:8200",
+						URL:     "https://other.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3,
+				},
+			},
+			wantErr:       true,
+			errorContains: "must match it when both are set",
+		},
+		{
+			name: "invalid Vault URL",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "not-a-valid-url",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3, // Avoid warning
+				},
+			},
+			wantErr:       true,
+			errorContains: "URL scheme must be http or https",
+		},
+		{
+			name: "empty unseal keys secret refs",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{}, // Empty
+					VaultLabelSelector:   "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3, // Avoid warning
+				},
+			},
+			wantErr:       true,
+			errorContains: "at least one unseal keys secret reference is required",
+		},
+		{
+			name: "missing secret name",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "", // Missing name
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3, // Avoid warning
+				},
+			},
+			wantErr:       true,
+			errorContains: "secret name is required",
+		},
+		{
+			name: "missing secret key",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "", // Missing key
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3, // Avoid warning
+				},
+			},
+			wantErr:       true,
+			errorContains: "secret key is required",
+		},
+		{
+			name: "duplicate secret references",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json", // Duplicate
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3, // Avoid warning
+				},
+			},
+			wantErr:       true,
+			errorContains: "duplicate secret reference",
+		},
+		{
+			name: "missing vault label selector",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "", // Missing
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3, // Avoid warning
+				},
+			},
+			wantErr:       true,
+			errorContains: "vault label selector is required",
+		},
+		{
+			name: "label selectors without vaultLabelSelector",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelectors: []string{"app=vault", "app.kubernetes.io/name=vault"},
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid entry in label selectors",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelectors: []string{"app=vault", "app in (vault"},
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3,
+				},
+			},
+			wantErr:       true,
+			errorContains: "spec.vaultLabelSelectors[1]",
+		},
+		{
+			name: "negative key threshold",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: -1, // Negative
+				},
+			},
+			wantErr:       true,
+			errorContains: "keyThreshold must be non-negative",
+		},
+		{
+			name: "zero key threshold with warning",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 0, // Zero - should warn
+				},
+			},
+			wantErr:      false,
+			wantWarnings: 1,
+		},
+		{
+			name: "HA disabled warning",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(false), // Should warn
+					},
+					KeyThreshold: 0, // This also generates a warning
+				},
+			},
+			wantErr:      false,
+			wantWarnings: 2, // HA disabled + zero key threshold
+		},
+		{
+			name: "empty mode does not stop after the first pod",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode:               opsv1alpha1.ModeSpec{},
+					KeyThreshold:       0, // Only the zero key threshold warns
+				},
+			},
+			wantErr:      false,
+			wantWarnings: 1,
+		},
+		{
+			name: "invalid interval",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3,                                            // Avoid warning
+					Interval:     &metav1.Duration{Duration: -1 * time.Second}, // Negative
+				},
+			},
+			wantErr:       true,
+			errorContains: "interval must be positive",
+		},
+		{
+			name: "interval below minimum warns",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3,
+					Interval:     &metav1.Duration{Duration: time.Second},
+				},
+			},
+			wantErr:      false,
+			wantWarnings: 1,
+		},
+		{
+			name: "interval above maximum warns",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3,
+					Interval:     &metav1.Duration{Duration: 48 * time.Hour},
+				},
+			},
+			wantErr:      false,
+			wantWarnings: 1,
+		},
+		{
+			name: "seal watch interval not shorter than reconcile interval warns",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3,
+					SealWatch:    &opsv1alpha1.SealWatchSpec{Interval: metav1.Duration{Duration: time.Minute}},
+				},
+			},
+			wantErr:      false,
+			wantWarnings: 1,
+		},
+		{
+			name: "key submission delay exceeding the reconcile interval warns",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold:       3,
+					KeySubmissionDelay: &metav1.Duration{Duration: 30 * time.Second},
+				},
+			},
+			wantErr:      false,
+			wantWarnings: 1,
+		},
+		{
+			name: "valid canary",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3,
+					Canary:       &opsv1alpha1.CanarySpec{Enabled: true, SoakTime: &metav1.Duration{Duration: time.Minute}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative canary soak time",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3,
+					Canary:       &opsv1alpha1.CanarySpec{Enabled: true, SoakTime: &metav1.Duration{Duration: -time.Minute}},
+				},
+			},
+			wantErr:       true,
+			errorContains: "canary soak time must not be negative",
+		},
+		{
+			name: "canary with stop after first unseal warns",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA:                   ptr.To(true),
+						StopAfterFirstUnseal: ptr.To(true),
+					},
+					KeyThreshold: 3,
+					Canary:       &opsv1alpha1.CanarySpec{Enabled: true, SoakTime: &metav1.Duration{Duration: time.Minute}},
+				},
+			},
+			wantErr:      false,
+			wantWarnings: 1,
+		},
+		{
+			name: "invalid error policy",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3,
+					ErrorPolicy:  "StopOnError",
+				},
+			},
+			wantErr:       true,
+			errorContains: "spec.errorPolicy",
+		},
+		{
+			name: "invalid priority",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3,
+					Priority:     "Urgent",
+				},
+			},
+			wantErr:       true,
+			errorContains: "spec.priority",
+		},
+		{
+			name: "negative key submission delay",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold:       3,
+					KeySubmissionDelay: &metav1.Duration{Duration: -time.Second},
+				},
+			},
+			wantErr:       true,
+			errorContains: "key submission delay must not be negative",
+		},
+		{
+			name: "zero reconcile budget",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold:    3,
+					ReconcileBudget: &metav1.Duration{},
+				},
+			},
+			wantErr:       true,
+			errorContains: "reconcile budget must be positive",
+		},
+		{
+			name: "reconcile budget as long as the interval warns",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold:    3,
+					ReconcileBudget: &metav1.Duration{Duration: time.Minute},
+				},
+			},
+			wantErr:      false,
+			wantWarnings: 1,
+		},
+		{
+			name: "monitor only needs no unseal keys secret refs",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA:          ptr.To(true),
+						MonitorOnly: true,
+					},
+				},
+			},
+			wantErr:      false,
+			wantWarnings: 0,
+		},
+		{
+			name: "custodians replace unseal keys secret refs",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					Custodians: []opsv1alpha1.Custodian{
+						{Name: "alice", SecretRef: opsv1alpha1.SecretRef{Name: "alice-keys", Key: "keys.json"}, MaxShares: 2},
+						{Name: "bob", SecretRef: opsv1alpha1.SecretRef{Name: "bob-keys", Key: "keys.json"}},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold:  3,
+					MinCustodians: 2,
+				},
+			},
+			wantErr:      false,
+			wantWarnings: 0,
+		},
+		{
+			name: "custodians combined with unseal keys secret refs",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					Custodians: []opsv1alpha1.Custodian{
+						{Name: "alice", SecretRef: opsv1alpha1.SecretRef{Name: "alice-keys", Key: "keys.json"}, MaxShares: 2},
+						{Name: "bob", SecretRef: opsv1alpha1.SecretRef{Name: "bob-keys", Key: "keys.json"}},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold:  3,
+					MinCustodians: 2,
+				},
+			},
+			wantErr:       true,
+			errorContains: "custodians cannot be combined",
+		},
+		{
+			name: "min custodians above the number of custodians",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					Custodians: []opsv1alpha1.Custodian{
+						{Name: "alice", SecretRef: opsv1alpha1.SecretRef{Name: "alice-keys", Key: "keys.json"}, MaxShares: 2},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold:  3,
+					MinCustodians: 2,
+				},
+			},
+			wantErr:       true,
+			errorContains: "minCustodians cannot exceed the number of custodians",
+		},
+		{
+			name: "fast interval longer than the interval warns",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3,
+					Interval:     &metav1.Duration{Duration: 30 * time.Second},
+					FastInterval: &metav1.Duration{Duration: time.Minute},
+				},
+			},
+			wantErr:      false,
+			wantWarnings: 1,
+		},
+		{
+			name: "invalid seal watch interval",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3,
+					SealWatch:    &opsv1alpha1.SealWatchSpec{},
+				},
+			},
+			wantErr:       true,
+			errorContains: "seal watch interval must be positive",
+		},
+		{
+			name: "invalid maintenance window",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3,
+					MaintenanceWindows: []opsv1alpha1.MaintenanceWindow{
+						{Schedule: "0 2 * * *", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Europe/Berlin"},
+						{Schedule: "0 25 * * *", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Mars/Olympus"},
+					},
+				},
+			},
+			wantErr:       true,
+			errorContains: "unknown time zone",
+		},
+		{
+			name: "unsupported readiness policy",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold:    3,
+					ReadinessPolicy: "Majority",
+				},
+			},
+			wantErr:       true,
+			errorContains: "spec.readinessPolicy",
+		},
+		{
+			name: "keys from HCP Vault Secrets only",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					HCPVaultSecrets: &opsv1alpha1.HCPVaultSecretsSource{
+						OrganizationID:        "org",
+						ProjectID:             "proj",
+						AppName:               "unseal",
+						SecretNames:           []string{"shares"},
+						CredentialsSecretName: "hcp-sp",
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "HCP Vault Secrets without project",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+					},
+					HCPVaultSecrets: &opsv1alpha1.HCPVaultSecretsSource{
+						OrganizationID:        "org",
+						ProjectID:             "",
+						AppName:               "unseal",
+						SecretNames:           []string{"shares"},
+						CredentialsSecretName: "hcp-sp",
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+				},
+			},
+			wantErr:       true,
+			errorContains: "spec.hcpVaultSecrets.projectID",
+		},
+		{
+			name: "invalid health check",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+						HealthCheck: &opsv1alpha1.HealthCheckSpec{
+							Path:                "v1/sys/health",
+							AcceptedStatusCodes: []int{200, 1000},
+						},
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3,
+				},
+			},
+			wantErr:       true,
+			errorContains: "spec.vault.healthCheck.acceptedStatusCodes[1]",
+		},
+		{
+			name: "valid path prefix",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL:        "https://ingress.example.com",
+						PathPrefix: "/vault",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "relative path prefix",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL:        "https://ingress.example.com",
+						PathPrefix: "vault",
 					},
 					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
 						{
@@ -84,16 +1268,16 @@ func TestVaultUnsealerValidator_ValidateCreate(t *testing.T) {
 					},
 					VaultLabelSelector: "app.kubernetes.io/name=vault",
 					Mode: opsv1alpha1.ModeSpec{
-						HA: true,
+						HA: ptr.To(true),
 					},
-					KeyThreshold: 3, // Avoid warning
+					KeyThreshold: 3,
 				},
 			},
 			wantErr:       true,
-			errorContains: "Vault URL is required",
+			errorContains: "spec.vault.pathPrefix",
 		},
 		{
-			name: "invalid Vault URL",
+			name: "path prefix with query",
 			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-unsealer",
@@ -101,7 +1285,8 @@ func TestVaultUnsealerValidator_ValidateCreate(t *testing.T) {
 				},
 				Spec: opsv1alpha1.VaultUnsealerSpec{
 					Vault: opsv1alpha1.VaultConnectionSpec{
-						URL: "not-a-valid-url",
+						URL:        "https://ingress.example.com",
+						PathPrefix: "/vault?x=1",
 					},
 					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
 						{
@@ -111,16 +1296,16 @@ func TestVaultUnsealerValidator_ValidateCreate(t *testing.T) {
 					},
 					VaultLabelSelector: "app.kubernetes.io/name=vault",
 					Mode: opsv1alpha1.ModeSpec{
-						HA: true,
+						HA: ptr.To(true),
 					},
-					KeyThreshold: 3, // Avoid warning
+					KeyThreshold: 3,
 				},
 			},
 			wantErr:       true,
-			errorContains: "URL scheme must be http or https",
+			errorContains: "spec.vault.pathPrefix",
 		},
 		{
-			name: "empty unseal keys secret refs",
+			name: "invalid additional CA bundle reference",
 			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-unsealer",
@@ -128,21 +1313,31 @@ func TestVaultUnsealerValidator_ValidateCreate(t *testing.T) {
 				},
 				Spec: opsv1alpha1.VaultUnsealerSpec{
 					Vault: opsv1alpha1.VaultConnectionSpec{
-						URL: "https://vault.example.com:8200",
+						URL:               "https://vault.example.com:8200",
+						CABundleSecretRef: &opsv1alpha1.SecretRef{Name: "vault-ca-old", Key: "ca.crt"},
+						CABundleSecretRefs: []opsv1alpha1.SecretRef{
+							{Name: "vault-ca-new", Key: "ca.crt"},
+							{Name: "vault-ca-next"},
+						},
 					},
-					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{}, // Empty
-					VaultLabelSelector:   "app.kubernetes.io/name=vault",
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
 					Mode: opsv1alpha1.ModeSpec{
-						HA: true,
+						HA: ptr.To(true),
 					},
-					KeyThreshold: 3, // Avoid warning
+					KeyThreshold: 3,
 				},
 			},
 			wantErr:       true,
-			errorContains: "at least one unseal keys secret reference is required",
+			errorContains: "spec.vault.caBundleSecretRefs[1].key",
 		},
 		{
-			name: "missing secret name",
+			name: "valid mesh sidecar",
 			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-unsealer",
@@ -150,26 +1345,54 @@ func TestVaultUnsealerValidator_ValidateCreate(t *testing.T) {
 				},
 				Spec: opsv1alpha1.VaultUnsealerSpec{
 					Vault: opsv1alpha1.VaultConnectionSpec{
-						URL: "https://vault.example.com:8200",
+						URL:         "https://vault.example.com:8200",
+						MeshSidecar: &opsv1alpha1.MeshSidecarSpec{Port: 15001},
 					},
 					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
 						{
-							Name: "", // Missing name
+							Name: "vault-keys-1",
 							Key:  "keys.json",
 						},
 					},
 					VaultLabelSelector: "app.kubernetes.io/name=vault",
 					Mode: opsv1alpha1.ModeSpec{
-						HA: true,
+						HA: ptr.To(true),
 					},
-					KeyThreshold: 3, // Avoid warning
+					KeyThreshold: 3,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid mesh sidecar scheme",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL:         "https://vault.example.com:8200",
+						MeshSidecar: &opsv1alpha1.MeshSidecarSpec{Scheme: "tcp", Port: 15001},
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3,
 				},
 			},
 			wantErr:       true,
-			errorContains: "secret name is required",
+			errorContains: "spec.vault.meshSidecar.scheme",
 		},
 		{
-			name: "missing secret key",
+			name: "valid DNS settings",
 			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-unsealer",
@@ -178,25 +1401,53 @@ func TestVaultUnsealerValidator_ValidateCreate(t *testing.T) {
 				Spec: opsv1alpha1.VaultUnsealerSpec{
 					Vault: opsv1alpha1.VaultConnectionSpec{
 						URL: "https://vault.example.com:8200",
+						DNS: &opsv1alpha1.DNSSpec{Resolution: opsv1alpha1.DNSResolutionPerReconcile, Resolver: "10.96.0.10:53"},
 					},
 					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
 						{
 							Name: "vault-keys-1",
-							Key:  "", // Missing key
+							Key:  "keys.json",
 						},
 					},
 					VaultLabelSelector: "app.kubernetes.io/name=vault",
 					Mode: opsv1alpha1.ModeSpec{
-						HA: true,
+						HA: ptr.To(true),
 					},
-					KeyThreshold: 3, // Avoid warning
+					KeyThreshold: 3,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid DNS resolution",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.example.com:8200",
+						DNS: &opsv1alpha1.DNSSpec{Resolution: "Never"},
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3,
 				},
 			},
 			wantErr:       true,
-			errorContains: "secret key is required",
+			errorContains: "spec.vault.dns.resolution",
 		},
 		{
-			name: "duplicate secret references",
+			name: "invalid DNS resolver port",
 			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-unsealer",
@@ -205,29 +1456,82 @@ func TestVaultUnsealerValidator_ValidateCreate(t *testing.T) {
 				Spec: opsv1alpha1.VaultUnsealerSpec{
 					Vault: opsv1alpha1.VaultConnectionSpec{
 						URL: "https://vault.example.com:8200",
+						DNS: &opsv1alpha1.DNSSpec{Resolver: "node-local-dns:99999"},
 					},
 					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
 						{
 							Name: "vault-keys-1",
 							Key:  "keys.json",
 						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3,
+				},
+			},
+			wantErr:       true,
+			errorContains: "spec.vault.dns.resolver",
+		},
+		{
+			name: "valid apiserver proxy",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL:            "https://vault.vault.svc:8200",
+						APIServerProxy: true,
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
 						{
 							Name: "vault-keys-1",
-							Key:  "keys.json", // Duplicate
+							Key:  "keys.json",
 						},
 					},
 					VaultLabelSelector: "app.kubernetes.io/name=vault",
 					Mode: opsv1alpha1.ModeSpec{
-						HA: true,
+						HA: ptr.To(true),
 					},
-					KeyThreshold: 3, // Avoid warning
+					KeyThreshold: 3,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "apiserver proxy with mesh sidecar",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL:            "https://vault.vault.svc:8200",
+						APIServerProxy: true,
+						MeshSidecar:    &opsv1alpha1.MeshSidecarSpec{Port: 15001},
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3,
 				},
 			},
 			wantErr:       true,
-			errorContains: "duplicate secret reference",
+			errorContains: "cannot be combined with meshSidecar",
 		},
 		{
-			name: "missing vault label selector",
+			name: "valid status ConfigMap",
 			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-unsealer",
@@ -235,7 +1539,7 @@ func TestVaultUnsealerValidator_ValidateCreate(t *testing.T) {
 				},
 				Spec: opsv1alpha1.VaultUnsealerSpec{
 					Vault: opsv1alpha1.VaultConnectionSpec{
-						URL: "https://vault.example.com:8200",
+						URL: "https://vault.vault.svc:8200",
 					},
 					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
 						{
@@ -243,18 +1547,46 @@ func TestVaultUnsealerValidator_ValidateCreate(t *testing.T) {
 							Key:  "keys.json",
 						},
 					},
-					VaultLabelSelector: "", // Missing
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
 					Mode: opsv1alpha1.ModeSpec{
-						HA: true,
+						HA: ptr.To(true),
 					},
-					KeyThreshold: 3, // Avoid warning
+					KeyThreshold:    3,
+					StatusConfigMap: &opsv1alpha1.StatusConfigMapSpec{},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "status ConfigMap name template renders an invalid name",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.vault.svc:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold:    3,
+					StatusConfigMap: &opsv1alpha1.StatusConfigMapSpec{NameTemplate: "{{ .Name }}_Status"},
 				},
 			},
 			wantErr:       true,
-			errorContains: "vault label selector is required",
+			errorContains: "spec.statusConfigMap.nameTemplate",
 		},
 		{
-			name: "negative key threshold",
+			name: "valid readiness gate",
 			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-unsealer",
@@ -262,7 +1594,7 @@ func TestVaultUnsealerValidator_ValidateCreate(t *testing.T) {
 				},
 				Spec: opsv1alpha1.VaultUnsealerSpec{
 					Vault: opsv1alpha1.VaultConnectionSpec{
-						URL: "https://vault.example.com:8200",
+						URL: "https://vault.vault.svc:8200",
 					},
 					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
 						{
@@ -272,16 +1604,44 @@ func TestVaultUnsealerValidator_ValidateCreate(t *testing.T) {
 					},
 					VaultLabelSelector: "app.kubernetes.io/name=vault",
 					Mode: opsv1alpha1.ModeSpec{
-						HA: true,
+						HA: ptr.To(true),
 					},
-					KeyThreshold: -1, // Negative
+					KeyThreshold:  3,
+					ReadinessGate: &opsv1alpha1.ReadinessGateSpec{ConditionType: "example.com/VaultUnsealed"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "readiness gate with an invalid condition type",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL: "https://vault.vault.svc:8200",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold:  3,
+					ReadinessGate: &opsv1alpha1.ReadinessGateSpec{ConditionType: "not a condition"},
 				},
 			},
 			wantErr:       true,
-			errorContains: "keyThreshold must be non-negative",
+			errorContains: "spec.readinessGate.conditionType",
 		},
 		{
-			name: "zero key threshold with warning",
+			name: "valid per-pod host template",
 			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-unsealer",
@@ -289,7 +1649,8 @@ func TestVaultUnsealerValidator_ValidateCreate(t *testing.T) {
 				},
 				Spec: opsv1alpha1.VaultUnsealerSpec{
 					Vault: opsv1alpha1.VaultConnectionSpec{
-						URL: "https://vault.example.com:8200",
+						URL:                "https://vault.example.com:8200",
+						PerPodHostTemplate: "vault-{{ .Ordinal }}.vault.example.com",
 					},
 					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
 						{
@@ -299,16 +1660,43 @@ func TestVaultUnsealerValidator_ValidateCreate(t *testing.T) {
 					},
 					VaultLabelSelector: "app.kubernetes.io/name=vault",
 					Mode: opsv1alpha1.ModeSpec{
-						HA: true,
+						HA: ptr.To(true),
 					},
-					KeyThreshold: 0, // Zero - should warn
+					KeyThreshold: 3,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "pod address override warns",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						Address:                 "https://vault.example.com:8200",
+						AllowPodAddressOverride: true,
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 1,
 				},
 			},
 			wantErr:      false,
 			wantWarnings: 1,
 		},
 		{
-			name: "HA disabled warning",
+			name: "invalid container name",
 			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-unsealer",
@@ -316,7 +1704,8 @@ func TestVaultUnsealerValidator_ValidateCreate(t *testing.T) {
 				},
 				Spec: opsv1alpha1.VaultUnsealerSpec{
 					Vault: opsv1alpha1.VaultConnectionSpec{
-						URL: "https://vault.example.com:8200",
+						Address:       "https://vault.example.com:8200",
+						ContainerName: "Vault_Server",
 					},
 					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
 						{
@@ -326,16 +1715,16 @@ func TestVaultUnsealerValidator_ValidateCreate(t *testing.T) {
 					},
 					VaultLabelSelector: "app.kubernetes.io/name=vault",
 					Mode: opsv1alpha1.ModeSpec{
-						HA: false, // Should warn
+						HA: ptr.To(true),
 					},
-					KeyThreshold: 0, // This also generates a warning
+					KeyThreshold: 1,
 				},
 			},
-			wantErr:      false,
-			wantWarnings: 2, // HA disabled + zero key threshold
+			wantErr:       true,
+			errorContains: "spec.vault.containerName",
 		},
 		{
-			name: "invalid interval",
+			name: "token secret in another namespace",
 			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-unsealer",
@@ -343,7 +1732,12 @@ func TestVaultUnsealerValidator_ValidateCreate(t *testing.T) {
 				},
 				Spec: opsv1alpha1.VaultUnsealerSpec{
 					Vault: opsv1alpha1.VaultConnectionSpec{
-						URL: "https://vault.example.com:8200",
+						Address: "https://vault.example.com:8200",
+						TokenSecretRef: &opsv1alpha1.SecretRef{
+							Name:      "vault-proxy",
+							Namespace: "vault",
+							Key:       "token",
+						},
 					},
 					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
 						{
@@ -353,14 +1747,41 @@ func TestVaultUnsealerValidator_ValidateCreate(t *testing.T) {
 					},
 					VaultLabelSelector: "app.kubernetes.io/name=vault",
 					Mode: opsv1alpha1.ModeSpec{
-						HA: true,
+						HA: ptr.To(true),
 					},
-					KeyThreshold: 3,                                            // Avoid warning
-					Interval:     &metav1.Duration{Duration: -1 * time.Second}, // Negative
+					KeyThreshold: 1,
 				},
 			},
 			wantErr:       true,
-			errorContains: "interval must be positive",
+			errorContains: "spec.vault.tokenSecretRef.namespace",
+		},
+		{
+			name: "invalid per-pod host template",
+			vaultUnsealer: &opsv1alpha1.VaultUnsealer{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-unsealer",
+					Namespace: "default",
+				},
+				Spec: opsv1alpha1.VaultUnsealerSpec{
+					Vault: opsv1alpha1.VaultConnectionSpec{
+						URL:                "https://vault.example.com:8200",
+						PerPodHostTemplate: "vault-{{ .Index }}.vault.example.com",
+					},
+					UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
+						{
+							Name: "vault-keys-1",
+							Key:  "keys.json",
+						},
+					},
+					VaultLabelSelector: "app.kubernetes.io/name=vault",
+					Mode: opsv1alpha1.ModeSpec{
+						HA: ptr.To(true),
+					},
+					KeyThreshold: 3,
+				},
+			},
+			wantErr:       true,
+			errorContains: "spec.vault.perPodHostTemplate",
 		},
 	}
 
@@ -424,7 +1845,7 @@ func TestVaultUnsealerValidator_ValidateUpdate(t *testing.T) {
 			},
 			VaultLabelSelector: "app.kubernetes.io/name=vault",
 			Mode: opsv1alpha1.ModeSpec{
-				HA: true,
+				HA: ptr.To(true),
 			},
 		},
 	}
@@ -446,7 +1867,7 @@ func TestVaultUnsealerValidator_ValidateUpdate(t *testing.T) {
 			},
 			VaultLabelSelector: "app.kubernetes.io/name=vault",
 			Mode: opsv1alpha1.ModeSpec{
-				HA: true,
+				HA: ptr.To(true),
 			},
 		},
 	}
@@ -529,3 +1950,25 @@ func Test_isValidLabelSelector(t *testing.T) {
 		})
 	}
 }
+
+func Test_recordValidation(t *testing.T) {
+	denied := metrics.WebhookValidations.WithLabelValues("create", "denied")
+	warned := metrics.WebhookValidations.WithLabelValues("update", "warned")
+	urlFailures := metrics.WebhookValidationFailures.WithLabelValues("spec.vault.url", string(field.ErrorTypeRequired))
+	refFailures := metrics.WebhookValidationFailures.WithLabelValues("spec.unsealKeysSecretRefs[*].name", string(field.ErrorTypeRequired))
+	deniedBefore, warnedBefore := testutil.ToFloat64(denied), testutil.ToFloat64(warned)
+	urlBefore, refBefore := testutil.ToFloat64(urlFailures), testutil.ToFloat64(refFailures)
+
+	err := field.ErrorList{
+		field.Required(field.NewPath("spec", "vault", "url"), "Vault URL is required"),
+		field.Required(field.NewPath("spec", "unsealKeysSecretRefs").Index(0).Child("name"), "secret name is required"),
+		field.Required(field.NewPath("spec", "unsealKeysSecretRefs").Index(1).Child("name"), "secret name is required"),
+	}.ToAggregate()
+	recordValidation("create", nil, err)
+	recordValidation("update", []string{"HA mode is disabled"}, nil)
+
+	assert.Equal(t, deniedBefore+1, testutil.ToFloat64(denied))
+	assert.Equal(t, warnedBefore+1, testutil.ToFloat64(warned))
+	assert.Equal(t, urlBefore+1, testutil.ToFloat64(urlFailures))
+	assert.Equal(t, refBefore+2, testutil.ToFloat64(refFailures), "list indices are collapsed")
+}
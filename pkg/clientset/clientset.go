@@ -0,0 +1,132 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clientset provides a typed client for the ops.autounseal.vault.io
+// API so external Go tooling can manage VaultUnsealer resources without
+// importing the operator's internal packages.
+package clientset
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+// Interface gives access to the VaultUnsealer API.
+type Interface interface {
+	VaultUnsealers(namespace string) VaultUnsealerInterface
+}
+
+// VaultUnsealerInterface manages VaultUnsealer resources in a single namespace.
+type VaultUnsealerInterface interface {
+	Get(ctx context.Context, name string) (*opsv1alpha1.VaultUnsealer, error)
+	List(ctx context.Context, opts ...client.ListOption) (*opsv1alpha1.VaultUnsealerList, error)
+	Create(ctx context.Context, vu *opsv1alpha1.VaultUnsealer) error
+	Update(ctx context.Context, vu *opsv1alpha1.VaultUnsealer) error
+	Patch(ctx context.Context, vu *opsv1alpha1.VaultUnsealer, patch client.Patch) error
+	Delete(ctx context.Context, name string) error
+}
+
+// NewScheme returns a scheme with the core Kubernetes types and the
+// VaultUnsealer API registered.
+func NewScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(opsv1alpha1.AddToScheme(scheme))
+	return scheme
+}
+
+// Clientset implements Interface on top of a controller-runtime client.
+type Clientset struct {
+	client client.Client
+}
+
+var _ Interface = &Clientset{}
+
+// NewForConfig creates a Clientset for the given REST config.
+func NewForConfig(cfg *rest.Config) (*Clientset, error) {
+	c, err := client.New(cfg, client.Options{Scheme: NewScheme()})
+	if err != nil {
+		return nil, err
+	}
+	return New(c), nil
+}
+
+// New wraps an existing client, which must have the VaultUnsealer API in its scheme.
+func New(c client.Client) *Clientset {
+	return &Clientset{client: c}
+}
+
+// VaultUnsealers returns a client for VaultUnsealers in namespace. An empty
+// namespace lists across all namespaces.
+func (c *Clientset) VaultUnsealers(namespace string) VaultUnsealerInterface {
+	return &vaultUnsealers{client: c.client, namespace: namespace}
+}
+
+type vaultUnsealers struct {
+	client    client.Client
+	namespace string
+}
+
+func (v *vaultUnsealers) Get(ctx context.Context, name string) (*opsv1alpha1.VaultUnsealer, error) {
+	vu := &opsv1alpha1.VaultUnsealer{}
+	if err := v.client.Get(ctx, client.ObjectKey{Namespace: v.namespace, Name: name}, vu); err != nil {
+		return nil, err
+	}
+	return vu, nil
+}
+
+func (v *vaultUnsealers) List(ctx context.Context, opts ...client.ListOption) (*opsv1alpha1.VaultUnsealerList, error) {
+	list := &opsv1alpha1.VaultUnsealerList{}
+	if v.namespace != "" {
+		opts = append(opts, client.InNamespace(v.namespace))
+	}
+	if err := v.client.List(ctx, list, opts...); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (v *vaultUnsealers) Create(ctx context.Context, vu *opsv1alpha1.VaultUnsealer) error {
+	v.defaultNamespace(vu)
+	return v.client.Create(ctx, vu)
+}
+
+func (v *vaultUnsealers) Update(ctx context.Context, vu *opsv1alpha1.VaultUnsealer) error {
+	v.defaultNamespace(vu)
+	return v.client.Update(ctx, vu)
+}
+
+func (v *vaultUnsealers) Patch(ctx context.Context, vu *opsv1alpha1.VaultUnsealer, patch client.Patch) error {
+	v.defaultNamespace(vu)
+	return v.client.Patch(ctx, vu, patch)
+}
+
+func (v *vaultUnsealers) Delete(ctx context.Context, name string) error {
+	return v.client.Delete(ctx, opsv1alpha1.NewVaultUnsealer(v.namespace, name))
+}
+
+func (v *vaultUnsealers) defaultNamespace(vu *opsv1alpha1.VaultUnsealer) {
+	if vu.Namespace == "" {
+		vu.Namespace = v.namespace
+	}
+}
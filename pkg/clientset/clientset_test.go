@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientset
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+)
+
+func TestClientset_CRUD(t *testing.T) {
+	ctx := context.Background()
+	cs := New(fake.NewClientBuilder().WithScheme(NewScheme()).Build())
+	vaultUnsealers := cs.VaultUnsealers("vault")
+
+	vu := opsv1alpha1.NewVaultUnsealer("", "main").
+		WithVaultURL("https://vault.vault.svc:8200").
+		WithUnsealKeysSecret("vault-keys", "keys.json").
+		WithLabelSelector("app.kubernetes.io/name=vault").
+		WithHA(true).
+		WithKeyThreshold(3).
+		WithInterval(30 * time.Second)
+	require.NoError(t, vaultUnsealers.Create(ctx, vu))
+	assert.Equal(t, "vault", vu.Namespace, "namespace defaults to the client's")
+
+	got, err := vaultUnsealers.Get(ctx, "main")
+	require.NoError(t, err)
+	assert.Equal(t, vu.Spec, got.Spec)
+
+	patch := client.MergeFrom(got.DeepCopy())
+	got.WithKeyThreshold(2)
+	require.NoError(t, vaultUnsealers.Patch(ctx, got, patch))
+
+	list, err := cs.VaultUnsealers("").List(ctx)
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, 2, list.Items[0].Spec.KeyThreshold)
+
+	require.NoError(t, vaultUnsealers.Delete(ctx, "main"))
+	_, err = vaultUnsealers.Get(ctx, "main")
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestNewVaultUnsealer_TypeMeta(t *testing.T) {
+	vu := opsv1alpha1.NewVaultUnsealer("ns", "name").WithLabels(map[string]string{"team": "platform"})
+	assert.Equal(t, opsv1alpha1.GroupVersion.String(), vu.APIVersion)
+	assert.Equal(t, "VaultUnsealer", vu.Kind)
+	assert.Equal(t, "platform", vu.Labels["team"])
+}
@@ -0,0 +1,400 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vaultfake provides an in-process HTTP server that mimics the Vault
+// seal, unseal, init and health endpoints, so code that talks to Vault can be
+// tested without Docker.
+package vaultfake
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Version is the Vault version reported by the fake server.
+const Version = "1.15.2"
+
+// Server is a fake Vault server. It is safe for concurrent use.
+type Server struct {
+	mu sync.Mutex
+
+	initialized bool
+	sealed      bool
+	standby     bool
+	migration   bool
+	keys        []string
+	threshold   int
+	rootToken   string
+
+	// Current unseal round.
+	nonce    string
+	provided []string
+
+	unsealRequests int
+	failNext       int
+	failStatus     int
+	failRetryAfter time.Duration
+
+	httpServer *httptest.Server
+}
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithKeys starts the server initialised and sealed with the given unseal
+// keys and threshold, as if `vault operator init` had already been run.
+func WithKeys(keys []string, threshold int) Option {
+	return func(s *Server) {
+		s.initialized = true
+		s.sealed = true
+		s.keys = slices.Clone(keys)
+		s.threshold = threshold
+		s.rootToken = "root"
+	}
+}
+
+// WithUnsealed starts an initialised server in the unsealed state.
+func WithUnsealed() Option {
+	return func(s *Server) {
+		s.sealed = false
+	}
+}
+
+// NewServer starts a fake Vault server. Without options it is uninitialised
+// and sealed. Callers must Close it when done.
+func NewServer(opts ...Option) *Server {
+	s := &Server{sealed: true}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/sys/seal-status", s.handleSealStatus)
+	mux.HandleFunc("PUT /v1/sys/unseal", s.handleUnseal)
+	mux.HandleFunc("POST /v1/sys/unseal", s.handleUnseal)
+	mux.HandleFunc("PUT /v1/sys/init", s.handleInit)
+	mux.HandleFunc("POST /v1/sys/init", s.handleInit)
+	mux.HandleFunc("PUT /v1/sys/seal", s.handleSeal)
+	mux.HandleFunc("POST /v1/sys/seal", s.handleSeal)
+	mux.HandleFunc("GET /v1/sys/health", s.handleHealth)
+	s.httpServer = httptest.NewServer(mux)
+	return s
+}
+
+// URL returns the server's base address, e.g. http://127.0.0.1:41234.
+func (s *Server) URL() string {
+	return s.httpServer.URL
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.httpServer.Close()
+}
+
+// Keys returns the unseal keys, or nil while uninitialised.
+func (s *Server) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.keys)
+}
+
+// RootToken returns the root token, or "" while uninitialised.
+func (s *Server) RootToken() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rootToken
+}
+
+// Seal seals the server and discards any unseal progress.
+func (s *Server) Seal() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sealed = true
+	s.resetRound()
+}
+
+// SetStandby makes an unsealed server report itself as an HA standby.
+func (s *Server) SetStandby(standby bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.standby = standby
+}
+
+// Sealed reports whether the server is sealed.
+func (s *Server) Sealed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sealed
+}
+
+// UnsealRequests returns how many unseal requests the server has received.
+func (s *Server) UnsealRequests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.unsealRequests
+}
+
+// SetMigration reports a pending seal migration. While it is pending, keys
+// are only accepted with the migrate flag, and a successful unseal completes
+// the migration.
+func (s *Server) SetMigration(migration bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.migration = migration
+}
+
+// FailNext makes the next n requests fail with the given HTTP status code.
+func (s *Server) FailNext(n, status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failNext = n
+	s.failStatus = status
+	s.failRetryAfter = 0
+}
+
+// RateLimitNext makes the next n requests fail like a Vault rate limit quota
+// does: 429 Too Many Requests, with Retry-After set to retryAfter.
+func (s *Server) RateLimitNext(n int, retryAfter time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failNext = n
+	s.failStatus = http.StatusTooManyRequests
+	s.failRetryAfter = retryAfter
+}
+
+// SealStatus mirrors the /v1/sys/seal-status response.
+type SealStatus struct {
+	Type        string `json:"type"`
+	Initialized bool   `json:"initialized"`
+	Sealed      bool   `json:"sealed"`
+	T           int    `json:"t"`
+	N           int    `json:"n"`
+	Progress    int    `json:"progress"`
+	Nonce       string `json:"nonce"`
+	Version     string `json:"version"`
+	Migration   bool   `json:"migration"`
+}
+
+func (s *Server) status() SealStatus {
+	return SealStatus{
+		Type:        "shamir",
+		Initialized: s.initialized,
+		Sealed:      s.sealed,
+		T:           s.threshold,
+		N:           len(s.keys),
+		Progress:    len(s.provided),
+		Nonce:       s.nonce,
+		Version:     Version,
+		Migration:   s.migration,
+	}
+}
+
+// injectFailure consumes a pending FailNext failure. Callers hold s.mu.
+func (s *Server) injectFailure(w http.ResponseWriter) bool {
+	if s.failNext <= 0 {
+		return false
+	}
+	s.failNext--
+	if s.failStatus == http.StatusTooManyRequests && s.failRetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(s.failRetryAfter.Seconds())))
+		writeErrors(w, s.failStatus, "rate limit quota exceeded")
+		return true
+	}
+	writeErrors(w, s.failStatus, "injected failure")
+	return true
+}
+
+func (s *Server) handleSealStatus(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.injectFailure(w) {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.status())
+}
+
+func (s *Server) handleUnseal(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Key     string `json:"key"`
+		Reset   bool   `json:"reset"`
+		Migrate bool   `json:"migrate"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrors(w, http.StatusBadRequest, "failed to parse JSON input: "+err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.unsealRequests++
+	if s.injectFailure(w) {
+		return
+	}
+
+	if !s.initialized {
+		writeErrors(w, http.StatusBadRequest, "Vault is not initialized")
+		return
+	}
+	if req.Reset {
+		s.resetRound()
+		writeJSON(w, http.StatusOK, s.status())
+		return
+	}
+	if req.Key == "" {
+		writeErrors(w, http.StatusBadRequest, "'key' must be specified in request body as JSON, or 'reset' set to true")
+		return
+	}
+	if !s.sealed {
+		writeJSON(w, http.StatusOK, s.status())
+		return
+	}
+	if s.migration && !req.Migrate {
+		writeErrors(w, http.StatusBadRequest, "'migrate' parameter must be set true in JSON body when in seal migration mode")
+		return
+	}
+	if !slices.Contains(s.keys, req.Key) {
+		s.resetRound()
+		writeErrors(w, http.StatusBadRequest, "Error unsealing: invalid key")
+		return
+	}
+
+	if s.nonce == "" {
+		s.nonce = newNonce()
+	}
+	// Vault ignores a share that was already provided in this round.
+	if !slices.Contains(s.provided, req.Key) {
+		s.provided = append(s.provided, req.Key)
+	}
+	if len(s.provided) >= s.threshold {
+		s.sealed = false
+		s.migration = false
+		s.resetRound()
+	}
+	writeJSON(w, http.StatusOK, s.status())
+}
+
+func (s *Server) handleInit(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		SecretShares    int `json:"secret_shares"`
+		SecretThreshold int `json:"secret_threshold"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrors(w, http.StatusBadRequest, "failed to parse JSON input: "+err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.injectFailure(w) {
+		return
+	}
+	if s.initialized {
+		writeErrors(w, http.StatusBadRequest, "Vault is already initialized")
+		return
+	}
+	if req.SecretShares < 1 || req.SecretThreshold < 1 || req.SecretThreshold > req.SecretShares {
+		writeErrors(w, http.StatusBadRequest, "invalid seal configuration: threshold must be between 1 and the number of shares")
+		return
+	}
+
+	s.keys = make([]string, req.SecretShares)
+	keysB64 := make([]string, req.SecretShares)
+	for i := range s.keys {
+		raw := make([]byte, 33)
+		_, _ = rand.Read(raw)
+		s.keys[i] = hex.EncodeToString(raw)
+		keysB64[i] = base64.StdEncoding.EncodeToString(raw)
+	}
+	s.threshold = req.SecretThreshold
+	s.initialized = true
+	s.sealed = false
+	s.rootToken = "hvs." + newNonce()
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys":        s.keys,
+		"keys_base64": keysB64,
+		"root_token":  s.rootToken,
+	})
+}
+
+func (s *Server) handleSeal(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.injectFailure(w) {
+		return
+	}
+	if !s.initialized || r.Header.Get("X-Vault-Token") != s.rootToken {
+		writeErrors(w, http.StatusForbidden, "permission denied")
+		return
+	}
+	s.sealed = true
+	s.resetRound()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.injectFailure(w) {
+		return
+	}
+
+	code := http.StatusOK
+	switch {
+	case !s.initialized:
+		code = http.StatusNotImplemented
+	case s.sealed:
+		code = http.StatusServiceUnavailable
+	case s.standby:
+		code = http.StatusTooManyRequests
+	}
+	writeJSON(w, code, map[string]any{
+		"initialized": s.initialized,
+		"sealed":      s.sealed,
+		"standby":     s.standby,
+		"version":     Version,
+	})
+}
+
+// resetRound discards the shares provided so far. Callers hold s.mu.
+func (s *Server) resetRound() {
+	s.nonce = ""
+	s.provided = nil
+}
+
+func newNonce() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeErrors(w http.ResponseWriter, code int, msg string) {
+	writeJSON(w, code, map[string][]string{"errors": {msg}})
+}
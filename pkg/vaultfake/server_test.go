@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vaultfake
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func do(t *testing.T, s *Server, method, path string, body any, out any) int {
+	var buf bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&buf).Encode(body))
+	}
+	req, err := http.NewRequest(method, s.URL()+path, &buf)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	if out != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode
+}
+
+func TestServer_InitAndHealth(t *testing.T) {
+	s := NewServer()
+	defer s.Close()
+
+	assert.Equal(t, http.StatusNotImplemented, do(t, s, http.MethodGet, "/v1/sys/health", nil, nil))
+
+	var initResp struct {
+		Keys      []string `json:"keys"`
+		RootToken string   `json:"root_token"`
+	}
+	require.Equal(t, http.StatusOK, do(t, s, http.MethodPut, "/v1/sys/init",
+		map[string]int{"secret_shares": 5, "secret_threshold": 3}, &initResp))
+	assert.Len(t, initResp.Keys, 5)
+	assert.Equal(t, s.Keys(), initResp.Keys)
+	assert.Equal(t, http.StatusOK, do(t, s, http.MethodGet, "/v1/sys/health", nil, nil))
+
+	assert.Equal(t, http.StatusBadRequest, do(t, s, http.MethodPut, "/v1/sys/init",
+		map[string]int{"secret_shares": 1, "secret_threshold": 1}, nil), "already initialized")
+
+	req, _ := http.NewRequest(http.MethodPut, s.URL()+"/v1/sys/seal", nil)
+	req.Header.Set("X-Vault-Token", initResp.RootToken)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, http.StatusServiceUnavailable, do(t, s, http.MethodGet, "/v1/sys/health", nil, nil))
+}
+
+func TestServer_UnsealProgressAndNonce(t *testing.T) {
+	keys := []string{"k1", "k2", "k3"}
+	s := NewServer(WithKeys(keys, 2))
+	defer s.Close()
+
+	var status SealStatus
+	require.Equal(t, http.StatusOK, do(t, s, http.MethodPut, "/v1/sys/unseal", map[string]string{"key": "k1"}, &status))
+	assert.True(t, status.Sealed)
+	assert.Equal(t, 1, status.Progress)
+	assert.NotEmpty(t, status.Nonce)
+
+	// Repeating a share does not advance progress.
+	require.Equal(t, http.StatusOK, do(t, s, http.MethodPut, "/v1/sys/unseal", map[string]string{"key": "k1"}, &status))
+	assert.Equal(t, 1, status.Progress)
+
+	require.Equal(t, http.StatusOK, do(t, s, http.MethodPut, "/v1/sys/unseal", map[string]string{"key": "k3"}, &status))
+	assert.False(t, status.Sealed)
+	assert.Equal(t, 0, status.Progress)
+	assert.Empty(t, status.Nonce)
+	assert.Equal(t, 3, s.UnsealRequests())
+}
+
+func TestServer_InvalidKeyResetsProgress(t *testing.T) {
+	s := NewServer(WithKeys([]string{"k1", "k2"}, 2))
+	defer s.Close()
+
+	do(t, s, http.MethodPut, "/v1/sys/unseal", map[string]string{"key": "k1"}, nil)
+	assert.Equal(t, http.StatusBadRequest, do(t, s, http.MethodPut, "/v1/sys/unseal", map[string]string{"key": "bad"}, nil))
+
+	var status SealStatus
+	do(t, s, http.MethodGet, "/v1/sys/seal-status", nil, &status)
+	assert.Equal(t, 0, status.Progress)
+	assert.True(t, status.Sealed)
+}
+
+func TestServer_FailNext(t *testing.T) {
+	s := NewServer(WithKeys([]string{"k1"}, 1))
+	defer s.Close()
+
+	s.FailNext(1, http.StatusInternalServerError)
+	assert.Equal(t, http.StatusInternalServerError, do(t, s, http.MethodGet, "/v1/sys/seal-status", nil, nil))
+	assert.Equal(t, http.StatusOK, do(t, s, http.MethodGet, "/v1/sys/seal-status", nil, nil))
+}
+
+func TestServer_RateLimitNext(t *testing.T) {
+	s := NewServer(WithKeys([]string{"k1"}, 1))
+	defer s.Close()
+
+	s.RateLimitNext(1, 30*time.Second)
+	resp, err := http.Get(s.URL() + "/v1/sys/seal-status")
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "30", resp.Header.Get("Retry-After"))
+	assert.Equal(t, http.StatusOK, do(t, s, http.MethodGet, "/v1/sys/seal-status", nil, nil))
+}
+
+func TestServer_SealMigration(t *testing.T) {
+	s := NewServer(WithKeys([]string{"k1"}, 1))
+	defer s.Close()
+	s.SetMigration(true)
+
+	var status SealStatus
+	do(t, s, http.MethodGet, "/v1/sys/seal-status", nil, &status)
+	assert.True(t, status.Migration)
+
+	assert.Equal(t, http.StatusBadRequest, do(t, s, http.MethodPut, "/v1/sys/unseal", map[string]any{"key": "k1"}, nil))
+	assert.True(t, s.Sealed())
+
+	assert.Equal(t, http.StatusOK, do(t, s, http.MethodPut, "/v1/sys/unseal", map[string]any{"key": "k1", "migrate": true}, &status))
+	assert.False(t, status.Sealed)
+	assert.False(t, status.Migration, "unsealing completes the migration")
+}
@@ -22,40 +22,21 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/testcontainers/testcontainers-go"
-	"github.com/testcontainers/testcontainers-go/wait"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
 	"github.com/panteparak/vault-unsealer/internal/secrets"
+	"github.com/panteparak/vault-unsealer/test/framework"
 )
 
-func cleanKubeconfig(data []byte) ([]byte, error) {
-	// Remove control characters and find where yaml actually starts
-	str := string(data)
-
-	// Look for "apiVersion:" which should be the start of valid YAML
-	re := regexp.MustCompile(`apiVersion:\s*v1`)
-	loc := re.FindStringIndex(str)
-	if loc == nil {
-		return nil, fmt.Errorf("could not find valid YAML start in kubeconfig")
-	}
-
-	// Extract the clean YAML starting from apiVersion
-	cleanStr := str[loc[0]:]
-	return []byte(cleanStr), nil
-}
-
 func TestK3sE2EBasic(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping E2E test in short mode")
@@ -70,13 +51,14 @@ func TestK3sE2EBasic(t *testing.T) {
 	// Step 1: Start k3s container
 	stepStart := time.Now()
 	t.Log("📦 STEP 1: Starting k3s container...")
-	container, err := startK3sContainer(ctx)
+	cluster, err := framework.StartK3s(ctx)
 	if err != nil {
 		t.Fatalf("❌ STEP 1 FAILED: Failed to start k3s container: %v", err)
 	}
+	container := cluster.Container
 	defer func() {
 		t.Log("🧹 CLEANUP: Terminating k3s container...")
-		if termErr := container.Terminate(ctx); termErr != nil {
+		if termErr := cluster.Terminate(ctx); termErr != nil {
 			t.Logf("⚠️  Warning: Failed to terminate container: %v", termErr)
 		} else {
 			t.Log("✅ CLEANUP: Container terminated successfully")
@@ -88,17 +70,15 @@ func TestK3sE2EBasic(t *testing.T) {
 	// Step 2: Setup Kubernetes client
 	stepStart = time.Now()
 	t.Log("🔗 STEP 2: Setting up Kubernetes client...")
-	k8sClient, kubeClient, err := setupKubernetesClient(ctx, container)
-	if err != nil {
-		t.Fatalf("❌ STEP 2 FAILED: Failed to setup Kubernetes client: %v", err)
-	}
+	// framework.StartK3s connects the clients while starting the cluster.
+	k8sClient := cluster.Client
 	stepDuration = time.Since(stepStart)
 	t.Logf("✅ STEP 2 COMPLETED: Kubernetes client configured (took %v)", stepDuration)
 
 	// Step 3: Wait for API server to be ready
 	stepStart = time.Now()
 	t.Log("⏳ STEP 3: Waiting for API server to be ready...")
-	if err := waitForAPIServer(ctx, kubeClient); err != nil {
+	if err := cluster.WaitForAPIServer(ctx, 30*time.Second); err != nil {
 		t.Fatalf("❌ STEP 3 FAILED: API server not ready: %v", err)
 	}
 	stepDuration = time.Since(stepStart)
@@ -155,135 +135,6 @@ func TestK3sE2EBasic(t *testing.T) {
 	t.Logf("✅ All 8 test steps passed successfully!")
 }
 
-func startK3sContainer(ctx context.Context) (testcontainers.Container, error) {
-	req := testcontainers.ContainerRequest{
-		Image:        "rancher/k3s:v1.28.5-k3s1",
-		ExposedPorts: []string{"6443/tcp"},
-		Env: map[string]string{
-			"K3S_KUBECONFIG_OUTPUT": "/output/kubeconfig.yaml",
-			"K3S_KUBECONFIG_MODE":   "666",
-		},
-		Cmd: []string{
-			"server",
-			"--disable=traefik",
-			"--disable=servicelb",
-			"--disable=metrics-server",
-			"--disable=local-storage",
-			"--write-kubeconfig-mode=666",
-		},
-		WaitingFor: wait.ForAll(
-			wait.ForLog("Node controller sync successful").WithStartupTimeout(2*time.Minute),
-			wait.ForListeningPort("6443/tcp"),
-		),
-		Privileged: true,
-	}
-
-	return testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
-		ContainerRequest: req,
-		Started:          true,
-	})
-}
-
-func setupKubernetesClient(ctx context.Context, container testcontainers.Container) (client.Client, kubernetes.Interface, error) {
-	// First check if the kubeconfig file exists and list directory contents
-	_, reader, err := container.Exec(ctx, []string{"ls", "-la", "/output/"})
-	if err == nil {
-		output, _ := io.ReadAll(reader)
-		_ = output // Suppress debug output
-	}
-
-	// Try the default k3s kubeconfig location first
-	var exitCode int
-	exitCode, reader, err = container.Exec(ctx, []string{"cat", "/etc/rancher/k3s/k3s.yaml"})
-	if err != nil {
-		return nil, nil, err
-	}
-	if exitCode != 0 {
-		// If that fails, try the output location
-		exitCode, reader, err = container.Exec(ctx, []string{"cat", "/output/kubeconfig.yaml"})
-		if err != nil {
-			return nil, nil, err
-		}
-		if exitCode != 0 {
-			return nil, nil, fmt.Errorf("failed to read kubeconfig from both locations, exit code: %d", exitCode)
-		}
-	}
-
-	kubeconfigBytes, err := io.ReadAll(reader)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	// Clean the kubeconfig by removing control characters
-	cleanKubeconfigBytes, err := cleanKubeconfig(kubeconfigBytes)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to clean kubeconfig: %w", err)
-	}
-
-	// Debug: print cleaned kubeconfig (suppressed for cleaner output)
-	_ = cleanKubeconfigBytes
-
-	// Get container connection details
-	host, err := container.Host(ctx)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	port, err := container.MappedPort(ctx, "6443")
-	if err != nil {
-		return nil, nil, err
-	}
-
-	// Replace localhost with actual container host
-	kubeconfig := strings.ReplaceAll(string(cleanKubeconfigBytes), "https://127.0.0.1:6443", fmt.Sprintf("https://%s:%s", host, port.Port()))
-
-	// Create rest config
-	config, err := clientcmd.RESTConfigFromKubeConfig([]byte(kubeconfig))
-	if err != nil {
-		return nil, nil, err
-	}
-
-	// Create Kubernetes clientset
-	kubeClient, err := kubernetes.NewForConfig(config)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	// Create controller-runtime client
-	scheme := runtime.NewScheme()
-	if err := corev1.AddToScheme(scheme); err != nil {
-		return nil, nil, err
-	}
-	if err := opsv1alpha1.AddToScheme(scheme); err != nil {
-		return nil, nil, err
-	}
-
-	k8sClient, err := client.New(config, client.Options{Scheme: scheme})
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return k8sClient, kubeClient, nil
-}
-
-func waitForAPIServer(ctx context.Context, kubeClient kubernetes.Interface) error {
-	timeout := time.After(30 * time.Second)
-	ticker := time.NewTicker(2 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-timeout:
-			return fmt.Errorf("timeout waiting for API server")
-		case <-ticker.C:
-			_, err := kubeClient.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
-			if err == nil {
-				return nil
-			}
-		}
-	}
-}
-
 func validateCRDInstallation(ctx context.Context, container testcontainers.Container) error {
 	fmt.Printf("  🔍 Checking if CRD exists in cluster...\n")
 
@@ -475,13 +326,13 @@ func testVaultUnsealerCRD(ctx context.Context, k8sClient client.Client) error {
 		},
 		Spec: opsv1alpha1.VaultUnsealerSpec{
 			Vault: opsv1alpha1.VaultConnectionSpec{
-				URL: "http://vault.e2e-test.svc:8200",
+				Address: "http://vault.e2e-test.svc:8200",
 			},
 			UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
 				{Name: "vault-keys", Key: "keys.json"},
 			},
 			VaultLabelSelector: "app.kubernetes.io/name=vault",
-			Mode:               opsv1alpha1.ModeSpec{HA: true},
+			Mode:               opsv1alpha1.ModeSpec{HA: ptr.To(true)},
 			KeyThreshold:       3,
 		},
 	}
@@ -490,9 +341,9 @@ func testVaultUnsealerCRD(ctx context.Context, k8sClient client.Client) error {
 		return fmt.Errorf("failed to create VaultUnsealer: %w", err)
 	}
 	fmt.Printf("    ✅ VaultUnsealer 'test-vault-unsealer' created successfully\n")
-	fmt.Printf("    ℹ️  Vault URL: %s\n", vaultUnsealer.Spec.Vault.URL)
+	fmt.Printf("    ℹ️  Vault URL: %s\n", vaultUnsealer.Spec.Vault.Endpoint())
 	fmt.Printf("    ℹ️  Label Selector: %s\n", vaultUnsealer.Spec.VaultLabelSelector)
-	fmt.Printf("    ℹ️  HA Mode: %t\n", vaultUnsealer.Spec.Mode.HA)
+	fmt.Printf("    ℹ️  HA Mode: %t\n", ptr.Deref(vaultUnsealer.Spec.Mode.HA, false))
 	fmt.Printf("    ℹ️  Key Threshold: %d\n", vaultUnsealer.Spec.KeyThreshold)
 
 	fmt.Printf("  🔍 Verifying VaultUnsealer can be retrieved...\n")
@@ -509,20 +360,20 @@ func testVaultUnsealerCRD(ctx context.Context, k8sClient client.Client) error {
 	fmt.Printf("  🔍 Validating VaultUnsealer spec fields...\n")
 
 	// Verify spec fields
-	if retrievedUnsealer.Spec.Vault.URL != "http://vault.e2e-test.svc:8200" {
-		return fmt.Errorf("VaultUnsealer spec URL mismatch - expected: 'http://vault.e2e-test.svc:8200', got: '%s'", retrievedUnsealer.Spec.Vault.URL)
+	if retrievedUnsealer.Spec.Vault.Endpoint() != "http://vault.e2e-test.svc:8200" {
+		return fmt.Errorf("VaultUnsealer spec URL mismatch - expected: 'http://vault.e2e-test.svc:8200', got: '%s'", retrievedUnsealer.Spec.Vault.Endpoint())
 	}
-	fmt.Printf("    ✅ Vault URL validated: %s\n", retrievedUnsealer.Spec.Vault.URL)
+	fmt.Printf("    ✅ Vault URL validated: %s\n", retrievedUnsealer.Spec.Vault.Endpoint())
 
 	if retrievedUnsealer.Spec.KeyThreshold != 3 {
 		return fmt.Errorf("VaultUnsealer spec KeyThreshold mismatch - expected: 3, got: %d", retrievedUnsealer.Spec.KeyThreshold)
 	}
 	fmt.Printf("    ✅ Key Threshold validated: %d\n", retrievedUnsealer.Spec.KeyThreshold)
 
-	if !retrievedUnsealer.Spec.Mode.HA {
-		return fmt.Errorf("VaultUnsealer spec HA mode mismatch - expected: true, got: %t", retrievedUnsealer.Spec.Mode.HA)
+	if !ptr.Deref(retrievedUnsealer.Spec.Mode.HA, false) {
+		return fmt.Errorf("VaultUnsealer spec HA mode mismatch - expected: true, got: %t", ptr.Deref(retrievedUnsealer.Spec.Mode.HA, false))
 	}
-	fmt.Printf("    ✅ HA Mode validated: %t\n", retrievedUnsealer.Spec.Mode.HA)
+	fmt.Printf("    ✅ HA Mode validated: %t\n", ptr.Deref(retrievedUnsealer.Spec.Mode.HA, false))
 	fmt.Printf("    ✅ Secret references count: %d\n", len(retrievedUnsealer.Spec.UnsealKeysSecretRefs))
 
 	fmt.Printf("  📝 Testing VaultUnsealer status updates...\n")
@@ -647,7 +498,7 @@ func testSecretsLoading(ctx context.Context, k8sClient client.Client) error {
 	// Check specific keys
 	keyMap := make(map[string]bool)
 	for _, key := range keys {
-		keyMap[key] = true
+		keyMap[key.Reveal()] = true
 	}
 
 	expectedKeys := []string{"key1", "key2", "key3", "key4", "key5", "key6", "key7"}
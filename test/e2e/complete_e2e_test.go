@@ -20,18 +20,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"strings"
 	"testing"
 	"time"
 
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/network"
-	"github.com/testcontainers/testcontainers-go/wait"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -41,6 +38,7 @@ import (
 	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
 	"github.com/panteparak/vault-unsealer/internal/controller"
 	"github.com/panteparak/vault-unsealer/internal/secrets"
+	"github.com/panteparak/vault-unsealer/test/framework"
 )
 
 func TestCompleteE2E(t *testing.T) {
@@ -215,7 +213,7 @@ func TestCompleteE2E(t *testing.T) {
 		},
 		Spec: opsv1alpha1.VaultUnsealerSpec{
 			Vault: opsv1alpha1.VaultConnectionSpec{
-				URL: vaultURL, // This should work since it's accessible from the test
+				Address: vaultURL, // This should work since it's accessible from the test
 			},
 			UnsealKeysSecretRefs: []opsv1alpha1.SecretRef{
 				{
@@ -226,7 +224,7 @@ func TestCompleteE2E(t *testing.T) {
 			},
 			VaultLabelSelector: "app.kubernetes.io/name=vault",
 			Mode: opsv1alpha1.ModeSpec{
-				HA: true,
+				HA: ptr.To(true),
 			},
 			KeyThreshold: 3,
 		},
@@ -457,127 +455,27 @@ func TestCompleteE2E(t *testing.T) {
 func deployVaultWithLogging(ctx context.Context, dockerNetwork *testcontainers.DockerNetwork, t *testing.T) (testcontainers.Container, string, []string, string, error) {
 	t.Log("🔧 Starting Vault container...")
 
-	vaultContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
-		ContainerRequest: testcontainers.ContainerRequest{
-			Image:        "hashicorp/vault:1.15.2",
-			ExposedPorts: []string{"8200/tcp"},
-			Env: map[string]string{
-				"VAULT_ADDR":     "http://0.0.0.0:8200",
-				"VAULT_API_ADDR": "http://0.0.0.0:8200",
-				"VAULT_LOCAL_CONFIG": `{
-					"backend": {"file": {"path": "/vault/data"}},
-					"listener": {"tcp": {"address": "0.0.0.0:8200", "tls_disable": true}},
-					"disable_mlock": true,
-					"default_lease_ttl": "168h",
-					"max_lease_ttl": "720h"
-				}`,
-			},
-			Cmd:      []string{"vault", "server", "-config=/vault/config"},
-			Networks: []string{dockerNetwork.Name},
-			NetworkAliases: map[string][]string{
-				dockerNetwork.Name: {"vault"},
-			},
-			WaitingFor: wait.ForAll(
-				wait.ForLog("Vault server started!"),
-				wait.ForHTTP("/v1/sys/health").WithPort("8200/tcp").WithStatusCodeMatcher(func(status int) bool {
-					return status == 501 || status == 200
-				}),
-			).WithDeadline(90 * time.Second),
-		},
-		Started: true,
-	})
+	vaultServer, err := framework.StartVault(ctx, framework.VaultOptions{Network: dockerNetwork})
 	if err != nil {
-		return nil, "", nil, "", fmt.Errorf("failed to start Vault container: %w", err)
+		return nil, "", nil, "", err
 	}
+	t.Logf("🔗 Vault accessible at: %s", vaultServer.URL)
 
-	vaultPort, err := vaultContainer.MappedPort(ctx, "8200")
+	t.Log("🔑 Initializing and sealing Vault...")
+	initResult, err := vaultServer.InitAndSeal(ctx)
 	if err != nil {
-		return nil, "", nil, "", fmt.Errorf("failed to get Vault port: %w", err)
-	}
-
-	vaultURL := fmt.Sprintf("http://127.0.0.1:%s", vaultPort.Port())
-	t.Logf("🔗 Vault accessible at: %s", vaultURL)
-
-	// Initialize Vault
-	t.Log("🔑 Initializing Vault...")
-	vaultKeys, rootToken, err := initializeVaultWithLogging(vaultURL, t)
-	if err != nil {
-		return nil, "", nil, "", fmt.Errorf("failed to initialize Vault: %w", err)
-	}
-
-	t.Logf("🔑 Vault initialized with %d keys", len(vaultKeys))
-
-	// Seal Vault for testing
-	t.Log("🔒 Sealing Vault for testing...")
-	if err := sealVaultWithTokenAndLogging(vaultURL, rootToken, t); err != nil {
-		return nil, "", nil, "", fmt.Errorf("failed to seal Vault: %w", err)
+		return nil, "", nil, "", err
 	}
 
+	t.Logf("🔑 Vault initialized with %d keys", len(initResult.Keys))
 	t.Log("✅ Vault deployment complete")
-	return vaultContainer, vaultURL, vaultKeys, rootToken, nil
-}
-
-func initializeVaultWithLogging(vaultURL string, t *testing.T) ([]string, string, error) {
-	client := &http.Client{Timeout: 30 * time.Second}
-
-	initData := map[string]interface{}{
-		"secret_shares":    5,
-		"secret_threshold": 3,
-	}
-
-	initBody, _ := json.Marshal(initData)
-	t.Logf("🔧 Sending init request to %s", vaultURL+"/v1/sys/init")
-
-	resp, err := client.Post(vaultURL+"/v1/sys/init", "application/json", strings.NewReader(string(initBody)))
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to initialize Vault: %w", err)
-	}
-	defer func() {
-		if closeErr := resp.Body.Close(); closeErr != nil {
-			t.Logf("Warning: Failed to close response body: %v", closeErr)
-		}
-	}()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		t.Logf("❌ Vault init failed with status %d: %s", resp.StatusCode, string(body))
-		return nil, "", fmt.Errorf("vault init failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	var initResp struct {
-		Keys      []string `json:"keys"`
-		RootToken string   `json:"root_token"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&initResp); err != nil {
-		return nil, "", fmt.Errorf("failed to decode init response: %w", err)
-	}
-
-	t.Logf("✅ Vault initialization successful")
-	return initResp.Keys, initResp.RootToken, nil
+	return vaultServer.Container, vaultServer.URL, initResult.Keys, initResult.RootToken, nil
 }
 
 func checkVaultSealStatusDetailed(vaultURL string, t *testing.T) (bool, error) {
-	client := &http.Client{Timeout: 10 * time.Second}
-
-	resp, err := client.Get(vaultURL + "/v1/sys/seal-status")
+	status, err := (&framework.Vault{URL: vaultURL}).SealStatus(context.Background())
 	if err != nil {
-		return false, fmt.Errorf("failed to get seal status: %w", err)
-	}
-	defer func() {
-		if closeErr := resp.Body.Close(); closeErr != nil {
-			t.Logf("Warning: Failed to close response body: %v", closeErr)
-		}
-	}()
-
-	var status struct {
-		Sealed      bool `json:"sealed"`
-		T           int  `json:"t"`
-		N           int  `json:"n"`
-		Progress    int  `json:"progress"`
-		Initialized bool `json:"initialized"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
-		return false, fmt.Errorf("failed to decode seal status: %w", err)
+		return false, err
 	}
 
 	t.Logf("🔍 Vault status: sealed=%v, progress=%d/%d, initialized=%v",
@@ -586,77 +484,14 @@ func checkVaultSealStatusDetailed(vaultURL string, t *testing.T) (bool, error) {
 	return status.Sealed, nil
 }
 
-func sealVaultWithTokenAndLogging(vaultURL, rootToken string, t *testing.T) error {
-	client := &http.Client{Timeout: 30 * time.Second}
-
-	req, err := http.NewRequest("PUT", vaultURL+"/v1/sys/seal", nil)
-	if err != nil {
-		return err
-	}
-	req.Header.Set("X-Vault-Token", rootToken)
-
-	t.Logf("🔧 Sealing Vault at %s", vaultURL+"/v1/sys/seal")
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to seal Vault: %w", err)
-	}
-	defer func() {
-		if closeErr := resp.Body.Close(); closeErr != nil {
-			t.Logf("Warning: Failed to close response body: %v", closeErr)
-		}
-	}()
-
-	if resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		t.Logf("❌ Vault seal failed with status %d: %s", resp.StatusCode, string(body))
-		return fmt.Errorf("vault seal failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	t.Log("✅ Vault sealed successfully")
-	return nil
-}
-
 func manualUnsealTest(vaultURL string, keys []string, t *testing.T) (bool, error) {
-	client := &http.Client{Timeout: 10 * time.Second}
-
 	t.Log("🔧 Testing manual unsealing...")
-	for i, key := range keys {
-		unsealData := map[string]interface{}{"key": key}
-		unsealBody, _ := json.Marshal(unsealData)
-
-		t.Logf("🔑 Using unseal key %d/%d", i+1, len(keys))
-
-		resp, err := client.Post(vaultURL+"/v1/sys/unseal", "application/json", strings.NewReader(string(unsealBody)))
-		if err != nil {
-			return false, fmt.Errorf("failed to unseal with key %d: %w", i+1, err)
-		}
-		defer func() {
-			if closeErr := resp.Body.Close(); closeErr != nil {
-				t.Logf("Warning: Failed to close response body: %v", closeErr)
-			}
-		}()
-
-		if resp.StatusCode != http.StatusOK {
-			body, _ := io.ReadAll(resp.Body)
-			return false, fmt.Errorf("unseal failed with status %d: %s", resp.StatusCode, string(body))
-		}
-
-		var unsealResp struct {
-			Sealed   bool `json:"sealed"`
-			Progress int  `json:"progress"`
-			T        int  `json:"t"`
-		}
-		if err := json.NewDecoder(resp.Body).Decode(&unsealResp); err != nil {
-			return false, fmt.Errorf("failed to decode unseal response: %w", err)
-		}
-
-		t.Logf("📊 Progress: %d/%d, sealed: %v", unsealResp.Progress, unsealResp.T, unsealResp.Sealed)
-
-		if !unsealResp.Sealed {
-			t.Logf("✅ Vault unsealed manually with %d keys!", i+1)
-			return true, nil
-		}
+	unsealed, err := (&framework.Vault{URL: vaultURL}).Unseal(context.Background(), keys)
+	if err != nil {
+		return false, err
 	}
-
-	return false, nil
+	if unsealed {
+		t.Logf("✅ Vault unsealed manually with at most %d keys!", len(keys))
+	}
+	return unsealed, nil
 }
@@ -19,19 +19,16 @@ package e2e
 import (
 	"context"
 	"encoding/json"
-	"fmt"
-	"io"
-	"net/http"
 	"strings"
 	"testing"
 	"time"
 
-	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/network"
-	"github.com/testcontainers/testcontainers-go/wait"
 
 	opsv1alpha1 "github.com/panteparak/vault-unsealer/api/v1alpha1"
+	"github.com/panteparak/vault-unsealer/internal/secrets"
 	"github.com/panteparak/vault-unsealer/internal/vault"
+	"github.com/panteparak/vault-unsealer/test/framework"
 )
 
 // TestQuickE2E runs a quick validation test without full Kubernetes deployment
@@ -69,49 +66,16 @@ func TestQuickE2E(t *testing.T) {
 	t.Log("🏛️ STEP 2: Starting production Vault...")
 	stepStart = time.Now()
 
-	vaultContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
-		ContainerRequest: testcontainers.ContainerRequest{
-			Image:        "hashicorp/vault:1.15.2",
-			ExposedPorts: []string{"8200/tcp"},
-			Env: map[string]string{
-				"VAULT_ADDR":     "http://0.0.0.0:8200",
-				"VAULT_API_ADDR": "http://0.0.0.0:8200",
-				"VAULT_LOCAL_CONFIG": `{
-					"backend": {"file": {"path": "/vault/data"}},
-					"listener": {"tcp": {"address": "0.0.0.0:8200", "tls_disable": true}},
-					"disable_mlock": true,
-					"default_lease_ttl": "168h",
-					"max_lease_ttl": "720h"
-				}`,
-			},
-			Cmd:      []string{"vault", "server", "-config=/vault/config"},
-			Networks: []string{dockerNetwork.Name},
-			NetworkAliases: map[string][]string{
-				dockerNetwork.Name: {"vault"},
-			},
-			WaitingFor: wait.ForAll(
-				wait.ForLog("Vault server started!"),
-				wait.ForHTTP("/v1/sys/health").WithPort("8200/tcp").WithStatusCodeMatcher(func(status int) bool {
-					return status == 501 || status == 200 // 501 = uninitialized, 200 = ready
-				}),
-			).WithDeadline(90 * time.Second),
-		},
-		Started: true,
-	})
+	vaultServer, err := framework.StartVault(ctx, framework.VaultOptions{Network: dockerNetwork})
 	if err != nil {
 		t.Fatalf("❌ Failed to start Vault: %v", err)
 	}
 	defer func() {
-		if err := vaultContainer.Terminate(ctx); err != nil {
+		if err := vaultServer.Terminate(ctx); err != nil {
 			t.Logf("Warning: Failed to terminate vault container: %v", err)
 		}
 	}()
-
-	vaultPort, err := vaultContainer.MappedPort(ctx, "8200")
-	if err != nil {
-		t.Fatalf("❌ Failed to get Vault port: %v", err)
-	}
-	vaultURL := fmt.Sprintf("http://127.0.0.1:%s", vaultPort.Port())
+	vaultURL := vaultServer.URL
 
 	stepDuration = time.Since(stepStart)
 	t.Logf("✅ STEP 2 COMPLETED: Vault started on %s (took %v)", vaultURL, stepDuration)
@@ -120,20 +84,16 @@ func TestQuickE2E(t *testing.T) {
 	t.Log("🔐 STEP 3: Initializing and sealing Vault...")
 	stepStart = time.Now()
 
-	vaultKeys, rootToken, err := quickInitializeVault(vaultURL)
+	initResult, err := vaultServer.InitAndSeal(ctx)
 	if err != nil {
-		t.Fatalf("❌ Failed to initialize Vault: %v", err)
+		t.Fatalf("❌ Failed to initialize and seal Vault: %v", err)
 	}
+	vaultKeys, rootToken := initResult.Keys, initResult.RootToken
 
 	t.Logf("🔑 Vault initialized with %d keys", len(vaultKeys))
 
-	// Seal the Vault
-	if err := quickSealVault(vaultURL, rootToken); err != nil {
-		t.Fatalf("❌ Failed to seal Vault: %v", err)
-	}
-
 	// Verify it's sealed
-	if sealed, err := checkVaultSealStatus(vaultURL); err != nil {
+	if sealed, err := checkVaultSealStatus(ctx, vaultServer); err != nil {
 		t.Fatalf("❌ Failed to check seal status: %v", err)
 	} else if !sealed {
 		t.Fatal("❌ Vault should be sealed but it's not")
@@ -222,7 +182,7 @@ func TestQuickE2E(t *testing.T) {
 	for i, key := range unsealKeys {
 		t.Logf("🔑 Using unseal key %d/%d", i+1, len(unsealKeys))
 
-		status, err := vaultClient.Unseal(context.Background(), key)
+		status, err := vaultClient.Unseal(context.Background(), secrets.NewSecretString(key))
 		if err != nil {
 			t.Fatalf("❌ Failed to unseal with key %d: %v", i+1, err)
 		}
@@ -236,7 +196,7 @@ func TestQuickE2E(t *testing.T) {
 	}
 
 	// Verify unsealing succeeded
-	if sealed, err := checkVaultSealStatus(vaultURL); err != nil {
+	if sealed, err := checkVaultSealStatus(ctx, vaultServer); err != nil {
 		t.Fatalf("❌ Failed to check final seal status: %v", err)
 	} else if sealed {
 		t.Fatal("❌ Vault should be unsealed but it's still sealed")
@@ -250,12 +210,12 @@ func TestQuickE2E(t *testing.T) {
 	stepStart = time.Now()
 
 	// Re-seal vault to test recovery
-	if err := quickSealVault(vaultURL, rootToken); err != nil {
+	if err := vaultServer.Seal(ctx, rootToken); err != nil {
 		t.Fatalf("❌ Failed to re-seal Vault: %v", err)
 	}
 
 	// Verify it's sealed again
-	if sealed, err := checkVaultSealStatus(vaultURL); err != nil {
+	if sealed, err := checkVaultSealStatus(ctx, vaultServer); err != nil {
 		t.Fatalf("❌ Failed to check re-seal status: %v", err)
 	} else if !sealed {
 		t.Fatal("❌ Vault should be sealed after re-sealing")
@@ -263,7 +223,7 @@ func TestQuickE2E(t *testing.T) {
 
 	// Test unsealing again (simulating operator recovery)
 	for i, key := range unsealKeys {
-		status, err := vaultClient.Unseal(context.Background(), key)
+		status, err := vaultClient.Unseal(context.Background(), secrets.NewSecretString(key))
 		if err != nil {
 			t.Fatalf("❌ Failed recovery unsealing with key %d: %v", i+1, err)
 		}
@@ -286,82 +246,11 @@ func TestQuickE2E(t *testing.T) {
 
 // Helper functions for quick test
 
-func quickInitializeVault(vaultURL string) ([]string, string, error) {
-	client := &http.Client{Timeout: 30 * time.Second}
-
-	initData := map[string]interface{}{
-		"secret_shares":    5,
-		"secret_threshold": 3,
-	}
-
-	initBody, _ := json.Marshal(initData)
-	resp, err := client.Post(vaultURL+"/v1/sys/init", "application/json", strings.NewReader(string(initBody)))
+func checkVaultSealStatus(ctx context.Context, vaultServer *framework.Vault) (bool, error) {
+	status, err := vaultServer.SealStatus(ctx)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to initialize Vault: %w", err)
+		return false, err
 	}
-	defer func() {
-		_ = resp.Body.Close() // ignore close error
-	}()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, "", fmt.Errorf("vault init failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	var initResp struct {
-		Keys      []string `json:"keys"`
-		RootToken string   `json:"root_token"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&initResp); err != nil {
-		return nil, "", fmt.Errorf("failed to decode init response: %w", err)
-	}
-
-	return initResp.Keys, initResp.RootToken, nil
-}
-
-func quickSealVault(vaultURL, rootToken string) error {
-	client := &http.Client{Timeout: 30 * time.Second}
-
-	req, err := http.NewRequest("PUT", vaultURL+"/v1/sys/seal", nil)
-	if err != nil {
-		return err
-	}
-	req.Header.Set("X-Vault-Token", rootToken)
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to seal Vault: %w", err)
-	}
-	defer func() {
-		_ = resp.Body.Close() // ignore close error
-	}()
-
-	if resp.StatusCode != http.StatusNoContent {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("vault seal failed with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	return nil
-}
-
-func checkVaultSealStatus(vaultURL string) (bool, error) {
-	client := &http.Client{Timeout: 10 * time.Second}
-
-	resp, err := client.Get(vaultURL + "/v1/sys/seal-status")
-	if err != nil {
-		return false, fmt.Errorf("failed to get seal status: %w", err)
-	}
-	defer func() {
-		_ = resp.Body.Close() // ignore close error
-	}()
-
-	var status struct {
-		Sealed bool `json:"sealed"`
-	}
-	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
-		return false, fmt.Errorf("failed to decode seal status: %w", err)
-	}
-
 	return status.Sealed, nil
 }
 
@@ -0,0 +1,194 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package framework provides testcontainers helpers for running integration
+// tests against a throwaway k3s cluster and real Vault servers. It is used by
+// the operator's own e2e suite and can be reused by downstream projects.
+package framework
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/panteparak/vault-unsealer/pkg/clientset"
+)
+
+// DefaultK3sImage is the k3s image started by StartK3s.
+const DefaultK3sImage = "rancher/k3s:v1.28.5-k3s1"
+
+// K3s is a running single-node k3s cluster with clients connected to it.
+type K3s struct {
+	Container testcontainers.Container
+	Config    *rest.Config
+	// Client uses a scheme with the core and VaultUnsealer types registered.
+	Client     client.Client
+	Kubernetes kubernetes.Interface
+}
+
+// StartK3s starts a k3s container, builds clients for it and waits until the
+// API server answers requests. Callers must Terminate the returned cluster.
+func StartK3s(ctx context.Context) (*K3s, error) {
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        DefaultK3sImage,
+			ExposedPorts: []string{"6443/tcp"},
+			Env: map[string]string{
+				"K3S_KUBECONFIG_OUTPUT": "/output/kubeconfig.yaml",
+				"K3S_KUBECONFIG_MODE":   "666",
+			},
+			Cmd: []string{
+				"server",
+				"--disable=traefik",
+				"--disable=servicelb",
+				"--disable=metrics-server",
+				"--disable=local-storage",
+				"--write-kubeconfig-mode=666",
+			},
+			WaitingFor: wait.ForAll(
+				wait.ForLog("Node controller sync successful").WithStartupTimeout(2*time.Minute),
+				wait.ForListeningPort("6443/tcp"),
+			),
+			Privileged: true,
+		},
+		Started: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start k3s container: %w", err)
+	}
+
+	cluster := &K3s{Container: container}
+	if err := cluster.connect(ctx); err != nil {
+		_ = container.Terminate(ctx)
+		return nil, err
+	}
+	if err := cluster.WaitForAPIServer(ctx, 30*time.Second); err != nil {
+		_ = container.Terminate(ctx)
+		return nil, err
+	}
+	return cluster, nil
+}
+
+// Terminate stops and removes the k3s container.
+func (k *K3s) Terminate(ctx context.Context) error {
+	return k.Container.Terminate(ctx)
+}
+
+// WaitForAPIServer polls the API server until it can list namespaces.
+func (k *K3s) WaitForAPIServer(ctx context.Context, timeout time.Duration) error {
+	deadline := time.After(timeout)
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline:
+			return fmt.Errorf("timeout waiting for API server")
+		case <-ticker.C:
+			if _, err := k.Kubernetes.CoreV1().Namespaces().List(ctx, metav1.ListOptions{}); err == nil {
+				return nil
+			}
+		}
+	}
+}
+
+// Kubectl runs kubectl inside the k3s container and returns its combined output.
+func (k *K3s) Kubectl(ctx context.Context, args ...string) (string, error) {
+	exitCode, reader, err := k.Container.Exec(ctx, append([]string{"kubectl"}, args...))
+	if err != nil {
+		return "", err
+	}
+	output, _ := io.ReadAll(reader)
+	if exitCode != 0 {
+		return string(output), fmt.Errorf("kubectl %s exited with code %d: %s", strings.Join(args, " "), exitCode, output)
+	}
+	return string(output), nil
+}
+
+func (k *K3s) connect(ctx context.Context) error {
+	kubeconfig, err := k.readKubeconfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	host, err := k.Container.Host(ctx)
+	if err != nil {
+		return err
+	}
+	port, err := k.Container.MappedPort(ctx, "6443")
+	if err != nil {
+		return err
+	}
+	kubeconfig = strings.ReplaceAll(kubeconfig, "https://127.0.0.1:6443", fmt.Sprintf("https://%s:%s", host, port.Port()))
+
+	k.Config, err = clientcmd.RESTConfigFromKubeConfig([]byte(kubeconfig))
+	if err != nil {
+		return err
+	}
+	k.Kubernetes, err = kubernetes.NewForConfig(k.Config)
+	if err != nil {
+		return err
+	}
+	k.Client, err = client.New(k.Config, client.Options{Scheme: clientset.NewScheme()})
+	return err
+}
+
+func (k *K3s) readKubeconfig(ctx context.Context) (string, error) {
+	var data []byte
+	for _, path := range []string{"/etc/rancher/k3s/k3s.yaml", "/output/kubeconfig.yaml"} {
+		exitCode, reader, err := k.Container.Exec(ctx, []string{"cat", path})
+		if err != nil {
+			return "", err
+		}
+		if exitCode == 0 {
+			data, err = io.ReadAll(reader)
+			if err != nil {
+				return "", err
+			}
+			break
+		}
+	}
+	if data == nil {
+		return "", fmt.Errorf("failed to read kubeconfig from the k3s container")
+	}
+	return CleanKubeconfig(data)
+}
+
+var kubeconfigStart = regexp.MustCompile(`apiVersion:\s*v1`)
+
+// CleanKubeconfig strips the exec stream header testcontainers prepends to
+// command output, returning the kubeconfig YAML itself.
+func CleanKubeconfig(data []byte) (string, error) {
+	str := string(data)
+	loc := kubeconfigStart.FindStringIndex(str)
+	if loc == nil {
+		return "", fmt.Errorf("could not find valid YAML start in kubeconfig")
+	}
+	return str[loc[0]:], nil
+}
@@ -0,0 +1,33 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCleanKubeconfig(t *testing.T) {
+	kubeconfig, err := CleanKubeconfig([]byte("\x01\x00\x00\x00\x00\x00\x02\xa0apiVersion: v1\nkind: Config\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "apiVersion: v1\nkind: Config\n", kubeconfig)
+
+	_, err = CleanKubeconfig([]byte("not a kubeconfig"))
+	assert.Error(t, err)
+}
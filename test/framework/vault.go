@@ -0,0 +1,213 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// DefaultVaultImage is the Vault image started by StartVault.
+const DefaultVaultImage = "hashicorp/vault:1.15.2"
+
+// VaultOptions configures StartVault.
+type VaultOptions struct {
+	// Image overrides DefaultVaultImage.
+	Image string
+	// Network attaches the container to a Docker network.
+	Network *testcontainers.DockerNetwork
+	// Aliases are the container's host names on Network; defaults to "vault".
+	Aliases []string
+}
+
+// Vault is a running, uninitialised Vault server using file storage.
+type Vault struct {
+	Container testcontainers.Container
+	// URL is the Vault API address reachable from the test process.
+	URL string
+}
+
+// InitResult holds the credentials returned when initialising Vault.
+type InitResult struct {
+	Keys      []string
+	RootToken string
+}
+
+// SealStatus is the subset of /v1/sys/seal-status used by tests.
+type SealStatus struct {
+	Sealed      bool `json:"sealed"`
+	Initialized bool `json:"initialized"`
+	T           int  `json:"t"`
+	N           int  `json:"n"`
+	Progress    int  `json:"progress"`
+}
+
+// StartVault starts a Vault server container and waits until its health
+// endpoint responds. Callers must Terminate the returned server.
+func StartVault(ctx context.Context, opts VaultOptions) (*Vault, error) {
+	image := opts.Image
+	if image == "" {
+		image = DefaultVaultImage
+	}
+
+	req := testcontainers.ContainerRequest{
+		Image:        image,
+		ExposedPorts: []string{"8200/tcp"},
+		Env: map[string]string{
+			"VAULT_ADDR":     "http://0.0.0.0:8200",
+			"VAULT_API_ADDR": "http://0.0.0.0:8200",
+			"VAULT_LOCAL_CONFIG": `{
+				"backend": {"file": {"path": "/vault/data"}},
+				"listener": {"tcp": {"address": "0.0.0.0:8200", "tls_disable": true}},
+				"disable_mlock": true,
+				"default_lease_ttl": "168h",
+				"max_lease_ttl": "720h"
+			}`,
+		},
+		Cmd: []string{"vault", "server", "-config=/vault/config"},
+		WaitingFor: wait.ForAll(
+			wait.ForLog("Vault server started!"),
+			wait.ForHTTP("/v1/sys/health").WithPort("8200/tcp").WithStatusCodeMatcher(func(status int) bool {
+				return status == 501 || status == 200 // 501 = uninitialized, 200 = ready
+			}),
+		).WithDeadline(90 * time.Second),
+	}
+	if opts.Network != nil {
+		aliases := opts.Aliases
+		if len(aliases) == 0 {
+			aliases = []string{"vault"}
+		}
+		req.Networks = []string{opts.Network.Name}
+		req.NetworkAliases = map[string][]string{opts.Network.Name: aliases}
+	}
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start Vault container: %w", err)
+	}
+
+	port, err := container.MappedPort(ctx, "8200")
+	if err != nil {
+		_ = container.Terminate(ctx)
+		return nil, fmt.Errorf("failed to get Vault port: %w", err)
+	}
+	return &Vault{Container: container, URL: fmt.Sprintf("http://127.0.0.1:%s", port.Port())}, nil
+}
+
+// Terminate stops and removes the Vault container.
+func (v *Vault) Terminate(ctx context.Context) error {
+	return v.Container.Terminate(ctx)
+}
+
+// InitAndSeal initialises Vault with 5 key shares and a threshold of 3, then
+// seals it again so tests start from a sealed, initialised server.
+func (v *Vault) InitAndSeal(ctx context.Context) (*InitResult, error) {
+	result, err := v.Initialize(ctx, 5, 3)
+	if err != nil {
+		return nil, err
+	}
+	if err := v.Seal(ctx, result.RootToken); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Initialize initialises Vault with the given number of key shares and threshold.
+func (v *Vault) Initialize(ctx context.Context, shares, threshold int) (*InitResult, error) {
+	body, _ := json.Marshal(map[string]int{"secret_shares": shares, "secret_threshold": threshold})
+
+	var initResp struct {
+		Keys      []string `json:"keys"`
+		RootToken string   `json:"root_token"`
+	}
+	if err := v.do(ctx, http.MethodPost, "/v1/sys/init", "", string(body), http.StatusOK, &initResp); err != nil {
+		return nil, fmt.Errorf("failed to initialize Vault: %w", err)
+	}
+	return &InitResult{Keys: initResp.Keys, RootToken: initResp.RootToken}, nil
+}
+
+// Seal seals Vault using the given token.
+func (v *Vault) Seal(ctx context.Context, token string) error {
+	if err := v.do(ctx, http.MethodPut, "/v1/sys/seal", token, "", http.StatusNoContent, nil); err != nil {
+		return fmt.Errorf("failed to seal Vault: %w", err)
+	}
+	return nil
+}
+
+// SealStatus returns the current seal status.
+func (v *Vault) SealStatus(ctx context.Context) (*SealStatus, error) {
+	status := &SealStatus{}
+	if err := v.do(ctx, http.MethodGet, "/v1/sys/seal-status", "", "", http.StatusOK, status); err != nil {
+		return nil, fmt.Errorf("failed to get seal status: %w", err)
+	}
+	return status, nil
+}
+
+// Unseal submits keys one at a time until Vault reports it is unsealed, and
+// returns whether it ended up unsealed.
+func (v *Vault) Unseal(ctx context.Context, keys []string) (bool, error) {
+	for i, key := range keys {
+		body, _ := json.Marshal(map[string]string{"key": key})
+		status := &SealStatus{}
+		if err := v.do(ctx, http.MethodPost, "/v1/sys/unseal", "", string(body), http.StatusOK, status); err != nil {
+			return false, fmt.Errorf("failed to unseal with key %d: %w", i+1, err)
+		}
+		if !status.Sealed {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (v *Vault) do(ctx context.Context, method, path, token, body string, wantStatus int, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, v.URL+path, strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+
+	resp, err := (&http.Client{Timeout: 30 * time.Second}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != wantStatus {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, string(respBody))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}